@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/x509"
 	"encoding/json"
+	"fmt"
 	stdlog "log"
 	"net/http"
 	"os"
@@ -14,7 +15,6 @@ import (
 
 	"github.com/coreos/go-systemd/daemon"
 	assetfs "github.com/elazarl/go-bindata-assetfs"
-	"github.com/go-acme/lego/v4/challenge"
 	gokitmetrics "github.com/go-kit/kit/metrics"
 	"github.com/sirupsen/logrus"
 	"github.com/traefik/paerser/cli"
@@ -22,6 +22,7 @@ import (
 	"github.com/traefik/traefik/v2/cmd"
 	"github.com/traefik/traefik/v2/cmd/healthcheck"
 	cmdVersion "github.com/traefik/traefik/v2/cmd/version"
+	"github.com/traefik/traefik/v2/pkg/api"
 	tcli "github.com/traefik/traefik/v2/pkg/cli"
 	"github.com/traefik/traefik/v2/pkg/collector"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
@@ -198,18 +199,6 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 
 	acmeProviders := initACMEProvider(staticConfiguration, &providerAggregator, tlsManager, httpChallengeProvider, tlsChallengeProvider)
 
-	// Entrypoints
-
-	serverEntryPointsTCP, err := server.NewTCPEntryPoints(staticConfiguration.EntryPoints)
-	if err != nil {
-		return nil, err
-	}
-
-	serverEntryPointsUDP, err := server.NewUDPEntryPoints(staticConfiguration.EntryPoints)
-	if err != nil {
-		return nil, err
-	}
-
 	// Pilot
 
 	var aviator *pilot.Pilot
@@ -243,17 +232,48 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 	}
 	metricsRegistry := metrics.NewMultiRegistry(metricRegistries)
 
+	// Access log and tracing
+
+	accessLog := setupAccessLog(staticConfiguration.AccessLog, metricsRegistry)
+	chainBuilder := middleware.NewChainBuilder(*staticConfiguration, metricsRegistry, accessLog)
+
+	// Entrypoints
+
+	serverEntryPointsTCP, err := server.NewTCPEntryPoints(staticConfiguration.EntryPoints, metricsRegistry, chainBuilder.Tracer())
+	if err != nil {
+		return nil, err
+	}
+
+	serverEntryPointsUDP, err := server.NewUDPEntryPoints(staticConfiguration.EntryPoints, metricsRegistry)
+	if err != nil {
+		return nil, err
+	}
+
 	// Service manager factory
 
 	roundTripperManager := service.NewRoundTripperManager()
+
+	clientCertificates, err := initClientCertificateManager(staticConfiguration, acmeProviders)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientCertificates != nil {
+		roundTripperManager.SetClientCertificateManager(clientCertificates)
+		routinesPool.GoCtx(clientCertificates.Run)
+	}
+
 	acmeHTTPHandler := getHTTPChallengeHandler(acmeProviders, httpChallengeProvider)
-	managerFactory := service.NewManagerFactory(*staticConfiguration, routinesPool, metricsRegistry, roundTripperManager, acmeHTTPHandler)
+	certificateResolvers := make(map[string]api.CertificateResolver)
+	for _, p := range acmeProviders {
+		certificateResolvers[p.ResolverName] = p
+	}
+
+	managerFactory := service.NewManagerFactory(*staticConfiguration, routinesPool, metricsRegistry, roundTripperManager, acmeHTTPHandler, tlsManager, certificateResolvers)
 
 	// Router factory
 
-	accessLog := setupAccessLog(staticConfiguration.AccessLog)
-	chainBuilder := middleware.NewChainBuilder(*staticConfiguration, metricsRegistry, accessLog)
-	routerFactory := server.NewRouterFactory(*staticConfiguration, managerFactory, tlsManager, chainBuilder, pluginBuilder)
+	routerFactory := server.NewRouterFactory(*staticConfiguration, managerFactory, tlsManager, chainBuilder, pluginBuilder, metricsRegistry, accessLog)
 
 	// Watcher
 
@@ -291,7 +311,7 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 	watcher.AddListener(switchRouter(routerFactory, serverEntryPointsTCP, serverEntryPointsUDP, aviator))
 
 	// Metrics
-	if metricsRegistry.IsEpEnabled() || metricsRegistry.IsSvcEnabled() {
+	if metricsRegistry.IsEpEnabled() || metricsRegistry.IsRouterEnabled() || metricsRegistry.IsSvcEnabled() {
 		var eps []string
 		for key := range serverEntryPointsTCP {
 			eps = append(eps, key)
@@ -372,7 +392,7 @@ func switchRouter(routerFactory *server.RouterFactory, serverEntryPointsTCP serv
 }
 
 // initACMEProvider creates an acme provider from the ACME part of globalConfiguration.
-func initACMEProvider(c *static.Configuration, providerAggregator *aggregator.ProviderAggregator, tlsManager *traefiktls.Manager, httpChallengeProvider, tlsChallengeProvider challenge.Provider) []*acme.Provider {
+func initACMEProvider(c *static.Configuration, providerAggregator *aggregator.ProviderAggregator, tlsManager *traefiktls.Manager, httpChallengeProvider *acme.ChallengeHTTP, tlsChallengeProvider *acme.ChallengeTLSALPN) []*acme.Provider {
 	localStores := map[string]*acme.LocalStore{}
 
 	var resolvers []*acme.Provider
@@ -381,13 +401,29 @@ func initACMEProvider(c *static.Configuration, providerAggregator *aggregator.Pr
 			continue
 		}
 
-		if localStores[resolver.ACME.Storage] == nil {
-			localStores[resolver.ACME.Storage] = acme.NewLocalStore(resolver.ACME.Storage)
+		var store acme.Store
+		if resolver.ACME.KVStorage != nil {
+			ctx := log.With(context.Background(), log.Str(log.ProviderName, name+".acme"))
+
+			kvStore, err := acme.NewKVStore(ctx, resolver.ACME.KVStorage)
+			if err != nil {
+				log.WithoutContext().Errorf("The ACME resolver %q is skipped from the resolvers list because: %v", name, err)
+				continue
+			}
+
+			httpChallengeProvider.AddStore(kvStore)
+			tlsChallengeProvider.AddStore(kvStore)
+			store = kvStore
+		} else {
+			if localStores[resolver.ACME.Storage] == nil {
+				localStores[resolver.ACME.Storage] = acme.NewLocalStore(resolver.ACME.Storage)
+			}
+			store = localStores[resolver.ACME.Storage]
 		}
 
 		p := &acme.Provider{
 			Configuration:         resolver.ACME,
-			Store:                 localStores[resolver.ACME.Storage],
+			Store:                 store,
 			ResolverName:          name,
 			HTTPChallengeProvider: httpChallengeProvider,
 			TLSChallengeProvider:  tlsChallengeProvider,
@@ -408,6 +444,37 @@ func initACMEProvider(c *static.Configuration, providerAggregator *aggregator.Pr
 	return resolvers
 }
 
+// initClientCertificateManager creates the manager of the client certificate presented to the backend servers, if any.
+func initClientCertificateManager(c *static.Configuration, acmeProviders []*acme.Provider) (*traefiktls.ClientCertificateManager, error) {
+	if c.ServersTransport == nil || c.ServersTransport.ClientCertificate == nil {
+		return nil, nil
+	}
+
+	config := c.ServersTransport.ClientCertificate
+
+	if config.CA != nil {
+		ca, err := traefiktls.NewLocalCA(config.CA.CertFile, config.CA.KeyFile, time.Duration(config.Duration))
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the servers transport client certificate authority: %w", err)
+		}
+
+		commonName := config.CommonName
+		if commonName == "" {
+			commonName = "traefik"
+		}
+
+		return traefiktls.NewClientCertificateManager(ca, commonName), nil
+	}
+
+	for _, p := range acmeProviders {
+		if p.ResolverName == config.CertResolver {
+			return traefiktls.NewClientCertificateManager(p, config.CommonName), nil
+		}
+	}
+
+	return nil, fmt.Errorf("the certificates resolver %q of the servers transport client certificate is not available", config.CertResolver)
+}
+
 func registerMetricClients(metricsConfig *types.Metrics) []metrics.Registry {
 	if metricsConfig == nil {
 		return nil
@@ -445,6 +512,16 @@ func registerMetricClients(metricsConfig *types.Metrics) []metrics.Registry {
 			metricsConfig.InfluxDB.Address, metricsConfig.InfluxDB.PushInterval)
 	}
 
+	if metricsConfig.OpenTelemetry != nil {
+		ctx := log.With(context.Background(), log.Str(log.MetricsProviderName, "opentelemetry"))
+		openTelemetryRegistry := metrics.RegisterOpenTelemetry(ctx, metricsConfig.OpenTelemetry)
+		if openTelemetryRegistry != nil {
+			registries = append(registries, openTelemetryRegistry)
+			log.FromContext(ctx).Debugf("Configured OpenTelemetry metrics: pushing to %s once every %s",
+				metricsConfig.OpenTelemetry.Address, metricsConfig.OpenTelemetry.PushInterval)
+		}
+	}
+
 	return registries
 }
 
@@ -462,7 +539,7 @@ func appendCertMetric(gauge gokitmetrics.Gauge, certificate *x509.Certificate) {
 	gauge.With(labels...).Set(notAfter)
 }
 
-func setupAccessLog(conf *types.AccessLog) *accesslog.Handler {
+func setupAccessLog(conf *types.AccessLog, metricsRegistry metrics.Registry) *accesslog.Handler {
 	if conf == nil {
 		return nil
 	}
@@ -473,6 +550,8 @@ func setupAccessLog(conf *types.AccessLog) *accesslog.Handler {
 		return nil
 	}
 
+	accessLoggerMiddleware.SetDroppedLinesCounter(metricsRegistry.AccessLogDroppedLinesCounter())
+
 	return accessLoggerMiddleware
 }
 
@@ -493,7 +572,29 @@ func configureLogging(staticConfiguration *static.Configuration) {
 	if err != nil {
 		log.WithoutContext().Errorf("Error getting level: %v", err)
 	}
-	log.SetLevel(level)
+
+	// configure the levels of the components
+	componentLevels := make(map[string]logrus.Level)
+	if staticConfiguration.Log != nil {
+		for name, lvl := range staticConfiguration.Log.Levels {
+			componentLevel, err := logrus.ParseLevel(strings.ToLower(lvl))
+			if err != nil {
+				log.WithoutContext().Errorf("Error getting level of %s: %v", name, err)
+				continue
+			}
+
+			componentLevels[name] = componentLevel
+		}
+	}
+	log.SetLevels(level, componentLevels)
+
+	if staticConfiguration.Log != nil {
+		log.SetFields(staticConfiguration.Log.Fields)
+
+		if staticConfiguration.Log.RateLimit != nil {
+			log.SetRateLimit(staticConfiguration.Log.RateLimit.Burst, time.Duration(staticConfiguration.Log.RateLimit.Interval))
+		}
+	}
 
 	var logFile string
 	if staticConfiguration.Log != nil && len(staticConfiguration.Log.FilePath) > 0 {
@@ -38,6 +38,7 @@ import (
 	"github.com/traefik/traefik/v2/pkg/tracing/haystack"
 	"github.com/traefik/traefik/v2/pkg/tracing/instana"
 	"github.com/traefik/traefik/v2/pkg/tracing/jaeger"
+	"github.com/traefik/traefik/v2/pkg/tracing/opentelemetry"
 	"github.com/traefik/traefik/v2/pkg/tracing/zipkin"
 	"github.com/traefik/traefik/v2/pkg/types"
 )
@@ -198,6 +199,7 @@ func TestDo_dynamicConfiguration(t *testing.T) {
 				Headers: &dynamic.Headers{
 					CustomRequestHeaders:              map[string]string{"foo": "bar"},
 					CustomResponseHeaders:             map[string]string{"foo": "bar"},
+					JA3Header:                         "foo",
 					AccessControlAllowCredentials:     true,
 					AccessControlAllowHeaders:         []string{"foo"},
 					AccessControlAllowMethods:         []string{"foo"},
@@ -550,6 +552,15 @@ func TestDo_staticConfiguration(t *testing.T) {
 			ResponseHeaderTimeout: ptypes.Duration(111 * time.Second),
 			IdleConnTimeout:       ptypes.Duration(111 * time.Second),
 		},
+		ClientCertificate: &static.ClientCertificate{
+			CommonName:   "foobar",
+			CertResolver: "foobar",
+			CA: &static.ClientCertificateCA{
+				CertFile: "ca.crt",
+				KeyFile:  "ca.key",
+			},
+			Duration: ptypes.Duration(111 * time.Second),
+		},
 	}
 
 	config.Providers.File = &file.Provider{
@@ -823,6 +834,31 @@ func TestDo_staticConfiguration(t *testing.T) {
 			AddEntryPointsLabels: true,
 			AddServicesLabels:    true,
 		},
+		OpenTelemetry: &types.OpenTelemetry{
+			GRPC:     &types.OtelGRPC{},
+			Address:  "localhost:4318",
+			Path:     "/v1/metrics",
+			Insecure: true,
+			Headers: map[string]string{
+				"Authorization": "Bearer foobar",
+			},
+			TLS: &types.ClientTLS{
+				CA:                 "myCa",
+				CAOptional:         true,
+				Cert:               "mycert.pem",
+				Key:                "mycert.key",
+				InsecureSkipVerify: true,
+			},
+			PushInterval:       42,
+			Temporality:        "delta",
+			ExplicitBoundaries: []float64{0.1, 0.3},
+			ResourceAttributes: map[string]string{
+				"foobar": "foobar",
+			},
+			AddEntryPointsLabels: true,
+			AddRoutersLabels:     true,
+			AddServicesLabels:    true,
+		},
 	}
 
 	config.Ping = &ping.Handler{
@@ -835,11 +871,22 @@ func TestDo_staticConfiguration(t *testing.T) {
 		Level:    "Level",
 		FilePath: "/foo/path",
 		Format:   "json",
+		Levels: map[string]string{
+			"foo": "DEBUG",
+		},
+		Fields: map[string]string{
+			"env": "production",
+		},
+		RateLimit: &types.LogRateLimit{
+			Burst:    10,
+			Interval: ptypes.Duration(111 * time.Second),
+		},
 	}
 
 	config.AccessLog = &types.AccessLog{
 		FilePath: "AccessLog FilePath",
 		Format:   "AccessLog Format",
+		Template: "AccessLog Template",
 		Filters: &types.AccessLogFilters{
 			StatusCodes:   []string{"200", "500"},
 			RetryAttempts: true,
@@ -858,6 +905,30 @@ func TestDo_staticConfiguration(t *testing.T) {
 			},
 		},
 		BufferingSize: 42,
+		SampleRate:    0.5,
+		Syslog: &types.AccessLogSyslog{
+			Network:    "tcp",
+			Address:    "syslog.example.com:601",
+			Tag:        "traefik",
+			Facility:   "local0",
+			BufferSize: 42,
+		},
+		HTTP: &types.AccessLogHTTP{
+			URL: "https://logs.example.com",
+			Headers: map[string]string{
+				"Authorization": "foobar",
+			},
+			TLS: &types.ClientTLS{
+				CA:                 "myCa",
+				CAOptional:         true,
+				Cert:               "mycert.pem",
+				Key:                "mycert.key",
+				InsecureSkipVerify: true,
+			},
+			BatchSize:     42,
+			FlushInterval: 42,
+			BufferSize:    42,
+		},
 	}
 
 	config.Tracing = &static.Tracing{
@@ -913,6 +984,26 @@ func TestDo_staticConfiguration(t *testing.T) {
 			SecretToken:        "foobar",
 			ServiceEnvironment: "foobar",
 		},
+		OpenTelemetry: &opentelemetry.Config{
+			GRPC:     &opentelemetry.GRPC{},
+			Address:  "foobar",
+			Path:     "foobar",
+			Insecure: true,
+			Headers: map[string]string{
+				"foobar": "foobar",
+			},
+			TLS: &types.ClientTLS{
+				CA:                 "myCa",
+				CAOptional:         true,
+				Cert:               "mycert.pem",
+				Key:                "mycert.key",
+				InsecureSkipVerify: true,
+			},
+			SampleRate: 42,
+			ResourceAttributes: map[string]string{
+				"foobar": "foobar",
+			},
+		},
 	}
 
 	config.HostResolver = &types.HostResolverConfig{
@@ -924,11 +1015,46 @@ func TestDo_staticConfiguration(t *testing.T) {
 	config.CertificatesResolvers = map[string]static.CertificateResolver{
 		"CertificateResolver0": {
 			ACME: &acme.Configuration{
-				Email:          "acme Email",
-				CAServer:       "CAServer",
-				PreferredChain: "foobar",
-				Storage:        "Storage",
-				KeyType:        "MyKeyType",
+				Email:             "acme Email",
+				CAServer:          "CAServer",
+				PreferredChain:    "foobar",
+				Storage:           "Storage",
+				KeyType:           "MyKeyType",
+				AdditionalKeyType: "MyAdditionalKeyType",
+				KVStorage: &acme.KVStorage{
+					Backend:   "consul",
+					Endpoints: []string{"foobar"},
+					RootKey:   "foobar",
+					Username:  "foobar",
+					Password:  "foobar",
+					TLS: &types.ClientTLS{
+						CA:                 "foobar",
+						CAOptional:         true,
+						Cert:               "foobar",
+						Key:                "foobar",
+						InsecureSkipVerify: true,
+					},
+					LockTTL: ptypes.Duration(111 * time.Second),
+				},
+				OnDemand: &acme.OnDemand{
+					Domains: []string{"foobar"},
+					Ask:     "foobar",
+					Timeout: ptypes.Duration(111 * time.Second),
+					RateLimit: &acme.OnDemandRateLimit{
+						Burst:  42,
+						Period: ptypes.Duration(111 * time.Second),
+					},
+					AskRateLimit: &acme.OnDemandAskRateLimit{
+						Burst:  42,
+						Period: ptypes.Duration(111 * time.Second),
+					},
+				},
+				Renewal: &acme.Renewal{
+					Before:        ptypes.Duration(111 * time.Second),
+					LifetimeRatio: 0.42,
+					CheckInterval: ptypes.Duration(111 * time.Second),
+					Jitter:        ptypes.Duration(111 * time.Second),
+				},
 				DNSChallenge: &acme.DNSChallenge{
 					Provider:                "DNSProvider",
 					DelayBeforeCheck:        42,
@@ -12,6 +12,7 @@ import (
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
 	"github.com/traefik/traefik/v2/pkg/config/static"
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/tls"
 	"github.com/traefik/traefik/v2/pkg/version"
 )
 
@@ -54,12 +55,21 @@ type Handler struct {
 
 	// runtimeConfiguration is the data set used to create all the data representations exposed by the API.
 	runtimeConfiguration *runtime.Configuration
+
+	tlsManager           *tls.Manager
+	certificateResolvers map[string]CertificateResolver
 }
 
-// NewBuilder returns a http.Handler builder based on runtime.Configuration.
-func NewBuilder(staticConfig static.Configuration) func(*runtime.Configuration) http.Handler {
+// NewBuilder returns a http.Handler builder based on runtime.Configuration,
+// also exposing the certificates and options of the TLS manager,
+// and managing the certificates of the given ACME resolvers, by resolver name.
+func NewBuilder(staticConfig static.Configuration, tlsManager *tls.Manager, certificateResolvers map[string]CertificateResolver) func(*runtime.Configuration) http.Handler {
 	return func(configuration *runtime.Configuration) http.Handler {
-		return New(staticConfig, configuration).createRouter()
+		handler := New(staticConfig, configuration)
+		handler.tlsManager = tlsManager
+		handler.certificateResolvers = certificateResolvers
+
+		return handler.createRouter()
 	}
 }
 
@@ -113,6 +123,16 @@ func (h Handler) createRouter() *mux.Router {
 	router.Methods(http.MethodGet).Path("/api/udp/services").HandlerFunc(h.getUDPServices)
 	router.Methods(http.MethodGet).Path("/api/udp/services/{serviceID}").HandlerFunc(h.getUDPService)
 
+	router.Methods(http.MethodGet).Path("/api/log/levels").HandlerFunc(h.getLogLevels)
+	router.Methods(http.MethodPut).Path("/api/log/levels").HandlerFunc(h.putLogLevels)
+
+	router.Methods(http.MethodGet).Path("/api/tls/certificates").HandlerFunc(h.getTLSCertificates)
+	router.Methods(http.MethodGet).Path("/api/tls/options").HandlerFunc(h.getTLSOptions)
+
+	router.Methods(http.MethodGet).Path("/api/acme/certificates").HandlerFunc(h.getACMECertificates)
+	router.Methods(http.MethodPost).Path("/api/acme/resolvers/{resolverID}/revoke").HandlerFunc(h.revokeACMECertificates)
+	router.Methods(http.MethodPost).Path("/api/acme/resolvers/{resolverID}/renew").HandlerFunc(h.renewACMECertificates)
+
 	version.Handler{}.Append(router)
 
 	if h.dashboard {
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/provider/acme"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// CertificateResolver is an ACME certificates resolver managing its certificates through the API.
+type CertificateResolver interface {
+	CertificatesStatus(ctx context.Context) []acme.CertificateStatus
+	RevokeCertificates(ctx context.Context, domains []string, all, renew bool) ([]types.Domain, error)
+	RenewCertificates(ctx context.Context, domains []string) ([]types.Domain, error)
+}
+
+// certificatesRequest selects the certificates of a resolver by domain.
+// When no domain is given, all of them are renewed, but they are only revoked if all is true.
+type certificatesRequest struct {
+	Domains []string `json:"domains,omitempty"`
+	All     bool     `json:"all,omitempty"`
+	Renew   bool     `json:"renew,omitempty"`
+}
+
+type certificatesResponse struct {
+	Domains []types.Domain `json:"domains"`
+	Error   string         `json:"error,omitempty"`
+}
+
+func (h Handler) getACMECertificates(rw http.ResponseWriter, request *http.Request) {
+	results := make([]acme.CertificateStatus, 0)
+
+	criterion := newSearchCriterion(request.URL.Query())
+
+	for _, resolver := range h.certificateResolvers {
+		for _, status := range resolver.CertificatesStatus(request.Context()) {
+			if criterion == nil || criterion.searchIn(append(status.Domain.ToStrArray(), status.Resolver)...) {
+				results = append(results, status)
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Resolver == results[j].Resolver {
+			return results[i].Domain.Main < results[j].Domain.Main
+		}
+		return results[i].Resolver < results[j].Resolver
+	})
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	pageInfo, err := pagination(request, len(results))
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rw.Header().Set(nextPageHeader, strconv.Itoa(pageInfo.nextPage))
+
+	err = json.NewEncoder(rw).Encode(results[pageInfo.startIndex:pageInfo.endIndex])
+	if err != nil {
+		log.FromContext(request.Context()).Error(err)
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// revokeACMECertificates revokes the selected certificates of a resolver, and orders new ones if asked to.
+func (h Handler) revokeACMECertificates(rw http.ResponseWriter, request *http.Request) {
+	resolver, certRequest, ok := h.getCertificatesRequest(rw, request)
+	if !ok {
+		return
+	}
+
+	if len(certRequest.Domains) == 0 && !certRequest.All {
+		writeError(rw, "invalid certificates request: domains, or all set to true, required to revoke certificates", http.StatusBadRequest)
+		return
+	}
+
+	if len(certRequest.Domains) > 0 && certRequest.All {
+		writeError(rw, "invalid certificates request: domains and all are mutually exclusive", http.StatusBadRequest)
+		return
+	}
+
+	domains, err := resolver.RevokeCertificates(request.Context(), certRequest.Domains, certRequest.All, certRequest.Renew)
+	writeCertificatesResponse(rw, request, http.StatusOK, domains, err)
+}
+
+// renewACMECertificates orders new certificates replacing the selected certificates of a resolver.
+func (h Handler) renewACMECertificates(rw http.ResponseWriter, request *http.Request) {
+	resolver, certRequest, ok := h.getCertificatesRequest(rw, request)
+	if !ok {
+		return
+	}
+
+	domains, err := resolver.RenewCertificates(request.Context(), certRequest.Domains)
+	writeCertificatesResponse(rw, request, http.StatusAccepted, domains, err)
+}
+
+func (h Handler) getCertificatesRequest(rw http.ResponseWriter, request *http.Request) (CertificateResolver, certificatesRequest, bool) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	resolverID := mux.Vars(request)["resolverID"]
+
+	resolver, ok := h.certificateResolvers[resolverID]
+	if !ok {
+		writeError(rw, fmt.Sprintf("certificates resolver not found: %s", resolverID), http.StatusNotFound)
+		return nil, certificatesRequest{}, false
+	}
+
+	var certRequest certificatesRequest
+	if err := json.NewDecoder(request.Body).Decode(&certRequest); err != nil && !errors.Is(err, io.EOF) {
+		writeError(rw, fmt.Sprintf("invalid certificates request: %v", err), http.StatusBadRequest)
+		return nil, certificatesRequest{}, false
+	}
+
+	return resolver, certRequest, true
+}
+
+func writeCertificatesResponse(rw http.ResponseWriter, request *http.Request, status int, domains []types.Domain, err error) {
+	if errors.Is(err, acme.ErrCertificateNotFound) {
+		writeError(rw, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, acme.ErrDomainsRequired) {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := certificatesResponse{Domains: domains}
+	if result.Domains == nil {
+		result.Domains = []types.Domain{}
+	}
+
+	if err != nil {
+		log.FromContext(request.Context()).Error(err)
+		result.Error = err.Error()
+		status = http.StatusInternalServerError
+	}
+
+	rw.WriteHeader(status)
+
+	if err := json.NewEncoder(rw).Encode(result); err != nil {
+		log.FromContext(request.Context()).Error(err)
+	}
+}
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/provider/acme"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+type certificateResolverMock struct {
+	statuses []acme.CertificateStatus
+	err      error
+
+	domains []string
+	all     bool
+	renew   bool
+}
+
+func (m *certificateResolverMock) CertificatesStatus(_ context.Context) []acme.CertificateStatus {
+	return m.statuses
+}
+
+func (m *certificateResolverMock) RevokeCertificates(_ context.Context, domains []string, all, renew bool) ([]types.Domain, error) {
+	m.domains = domains
+	m.all = all
+	m.renew = renew
+
+	return m.matching(domains), m.err
+}
+
+func (m *certificateResolverMock) RenewCertificates(_ context.Context, domains []string) ([]types.Domain, error) {
+	m.domains = domains
+
+	return m.matching(domains), m.err
+}
+
+func (m *certificateResolverMock) matching(domains []string) []types.Domain {
+	var matched []types.Domain
+	for _, status := range m.statuses {
+		if len(domains) == 0 || status.Domain.Main == domains[0] {
+			matched = append(matched, status.Domain)
+		}
+	}
+
+	return matched
+}
+
+func TestHandler_ACMECertificates(t *testing.T) {
+	notAfter := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	attempt := time.Date(2021, time.January, 30, 0, 0, 0, 0, time.UTC)
+
+	resolvers := map[string]CertificateResolver{
+		"myresolver": &certificateResolverMock{statuses: []acme.CertificateStatus{
+			{Resolver: "myresolver", Domain: types.Domain{Main: "foo.com"}, Store: "default", NotAfter: &notAfter},
+			{Resolver: "myresolver", Domain: types.Domain{Main: "bar.com", SANs: []string{"www.bar.com"}}, Store: "default", LastRenewalAttempt: &attempt, LastRenewalError: "boom"},
+		}},
+		"another": &certificateResolverMock{statuses: []acme.CertificateStatus{
+			{Resolver: "another", Domain: types.Domain{Main: "baz.com"}, Store: "default"},
+		}},
+	}
+
+	testCases := []struct {
+		desc           string
+		query          string
+		expectedStatus int
+		expected       string
+	}{
+		{
+			desc:           "all the certificates",
+			expectedStatus: http.StatusOK,
+			expected: `[
+				{"resolver":"another","domain":{"main":"baz.com"},"store":"default"},
+				{"resolver":"myresolver","domain":{"main":"bar.com","sans":["www.bar.com"]},"store":"default","lastRenewalAttempt":"2021-01-30T00:00:00Z","lastRenewalError":"boom"},
+				{"resolver":"myresolver","domain":{"main":"foo.com"},"store":"default","notAfter":"2021-03-01T00:00:00Z"}
+			]`,
+		},
+		{
+			desc:           "search",
+			query:          "?search=www.bar",
+			expectedStatus: http.StatusOK,
+			expected: `[
+				{"resolver":"myresolver","domain":{"main":"bar.com","sans":["www.bar.com"]},"store":"default","lastRenewalAttempt":"2021-01-30T00:00:00Z","lastRenewalError":"boom"}
+			]`,
+		},
+		{
+			desc:           "pagination",
+			query:          "?page=2&per_page=2",
+			expectedStatus: http.StatusOK,
+			expected: `[
+				{"resolver":"myresolver","domain":{"main":"foo.com"},"store":"default","notAfter":"2021-03-01T00:00:00Z"}
+			]`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := NewBuilder(static.Configuration{API: &static.API{}, Global: &static.Global{}}, nil, resolvers)(&runtime.Configuration{})
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			resp, err := http.DefaultClient.Get(server.URL + "/api/acme/certificates" + test.query)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, test.expected, string(body))
+		})
+	}
+}
+
+func TestHandler_ACMECertificatesManagement(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		path            string
+		body            string
+		err             error
+		expectedStatus  int
+		expected        string
+		expectedDomains []string
+		expectedAll     bool
+		expectedRenew   bool
+	}{
+		{
+			desc:           "revoke all the certificates",
+			path:           "/api/acme/resolvers/myresolver/revoke",
+			body:           `{"all":true}`,
+			expectedStatus: http.StatusOK,
+			expected:       `{"domains":[{"main":"foo.com"},{"main":"bar.com"}]}`,
+			expectedAll:    true,
+		},
+		{
+			desc:           "revoke without domains",
+			path:           "/api/acme/resolvers/myresolver/revoke",
+			expectedStatus: http.StatusBadRequest,
+			expected:       `{"message":"invalid certificates request: domains, or all set to true, required to revoke certificates"}`,
+		},
+		{
+			desc:           "revoke with empty domains",
+			path:           "/api/acme/resolvers/myresolver/revoke",
+			body:           `{"domains":[]}`,
+			expectedStatus: http.StatusBadRequest,
+			expected:       `{"message":"invalid certificates request: domains, or all set to true, required to revoke certificates"}`,
+		},
+		{
+			desc:           "revoke with domains and all",
+			path:           "/api/acme/resolvers/myresolver/revoke",
+			body:           `{"domains":["bar.com"],"all":true}`,
+			expectedStatus: http.StatusBadRequest,
+			expected:       `{"message":"invalid certificates request: domains and all are mutually exclusive"}`,
+		},
+		{
+			desc:            "revoke and renew a certificate",
+			path:            "/api/acme/resolvers/myresolver/revoke",
+			body:            `{"domains":["bar.com"],"renew":true}`,
+			expectedStatus:  http.StatusOK,
+			expected:        `{"domains":[{"main":"bar.com"}]}`,
+			expectedDomains: []string{"bar.com"},
+			expectedRenew:   true,
+		},
+		{
+			desc:            "revocation error",
+			path:            "/api/acme/resolvers/myresolver/revoke",
+			body:            `{"domains":["bar.com"]}`,
+			err:             errors.New("boom"),
+			expectedStatus:  http.StatusInternalServerError,
+			expected:        `{"domains":[{"main":"bar.com"}],"error":"boom"}`,
+			expectedDomains: []string{"bar.com"},
+		},
+		{
+			desc:            "renew a certificate",
+			path:            "/api/acme/resolvers/myresolver/renew",
+			body:            `{"domains":["foo.com"]}`,
+			expectedStatus:  http.StatusAccepted,
+			expected:        `{"domains":[{"main":"foo.com"}]}`,
+			expectedDomains: []string{"foo.com"},
+		},
+		{
+			desc:            "certificate not found",
+			path:            "/api/acme/resolvers/myresolver/renew",
+			body:            `{"domains":["unknown.com"]}`,
+			err:             acme.ErrCertificateNotFound,
+			expectedStatus:  http.StatusNotFound,
+			expected:        `{"message":"certificate not found"}`,
+			expectedDomains: []string{"unknown.com"},
+		},
+		{
+			desc:           "unknown resolver",
+			path:           "/api/acme/resolvers/unknown/renew",
+			expectedStatus: http.StatusNotFound,
+			expected:       `{"message":"certificates resolver not found: unknown"}`,
+		},
+		{
+			desc:           "invalid request",
+			path:           "/api/acme/resolvers/myresolver/renew",
+			body:           `{"domains":"foo.com"}`,
+			expectedStatus: http.StatusBadRequest,
+			expected:       `{"message":"invalid certificates request: json: cannot unmarshal string into Go struct field certificatesRequest.domains of type []string"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			resolver := &certificateResolverMock{
+				statuses: []acme.CertificateStatus{
+					{Resolver: "myresolver", Domain: types.Domain{Main: "foo.com"}},
+					{Resolver: "myresolver", Domain: types.Domain{Main: "bar.com"}},
+				},
+				err: test.err,
+			}
+
+			handler := NewBuilder(static.Configuration{API: &static.API{}, Global: &static.Global{}}, nil, map[string]CertificateResolver{"myresolver": resolver})(&runtime.Configuration{})
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			resp, err := http.DefaultClient.Post(server.URL+test.path, "application/json", strings.NewReader(test.body))
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, test.expected, string(body))
+			assert.Equal(t, test.expectedDomains, resolver.domains)
+			assert.Equal(t, test.expectedAll, resolver.all)
+			assert.Equal(t, test.expectedRenew, resolver.renew)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/traefik/traefik/v2/pkg/log"
+)
+
+type logLevelsRepresentation struct {
+	Level  string            `json:"level,omitempty"`
+	Levels map[string]string `json:"levels,omitempty"`
+}
+
+func (h Handler) getLogLevels(rw http.ResponseWriter, request *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	writeLogLevels(rw, request)
+}
+
+// putLogLevels replaces the levels of the components,
+// and the level of the Traefik logger if one is given.
+func (h Handler) putLogLevels(rw http.ResponseWriter, request *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	var repr logLevelsRepresentation
+	if err := json.NewDecoder(request.Body).Decode(&repr); err != nil {
+		writeError(rw, fmt.Sprintf("invalid log levels: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	level, _ := log.GetLevels()
+	if repr.Level != "" {
+		var err error
+		level, err = logrus.ParseLevel(strings.ToLower(repr.Level))
+		if err != nil {
+			writeError(rw, fmt.Sprintf("invalid log level: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	components := make(map[string]logrus.Level, len(repr.Levels))
+	for name, lvl := range repr.Levels {
+		componentLevel, err := logrus.ParseLevel(strings.ToLower(lvl))
+		if err != nil {
+			writeError(rw, fmt.Sprintf("invalid log level of %s: %v", name, err), http.StatusBadRequest)
+			return
+		}
+
+		components[name] = componentLevel
+	}
+
+	log.SetLevels(level, components)
+
+	writeLogLevels(rw, request)
+}
+
+func writeLogLevels(rw http.ResponseWriter, request *http.Request) {
+	level, components := log.GetLevels()
+
+	result := logLevelsRepresentation{
+		Level:  strings.ToUpper(level.String()),
+		Levels: make(map[string]string, len(components)),
+	}
+	for name, lvl := range components {
+		result.Levels[name] = strings.ToUpper(lvl.String())
+	}
+
+	err := json.NewEncoder(rw).Encode(result)
+	if err != nil {
+		log.FromContext(request.Context()).Error(err)
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
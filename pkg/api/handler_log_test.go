@@ -0,0 +1,79 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/log"
+)
+
+func TestHandler_LogLevels(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		method         string
+		body           string
+		expectedStatus int
+		expected       string
+	}{
+		{
+			desc:           "get the levels",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusOK,
+			expected:       `{"level":"ERROR","levels":{"docker":"INFO"}}`,
+		},
+		{
+			desc:           "set the levels of the components",
+			method:         http.MethodPut,
+			body:           `{"levels":{"kubernetes":"DEBUG"}}`,
+			expectedStatus: http.StatusOK,
+			expected:       `{"level":"ERROR","levels":{"kubernetes":"DEBUG"}}`,
+		},
+		{
+			desc:           "set all the levels",
+			method:         http.MethodPut,
+			body:           `{"level":"WARN","levels":{"kubernetes":"DEBUG"}}`,
+			expectedStatus: http.StatusOK,
+			expected:       `{"level":"WARNING","levels":{"kubernetes":"DEBUG"}}`,
+		},
+		{
+			desc:           "invalid level",
+			method:         http.MethodPut,
+			body:           `{"levels":{"kubernetes":"VERBOSE"}}`,
+			expectedStatus: http.StatusBadRequest,
+			expected:       `{"message":"invalid log level of kubernetes: not a valid logrus Level: \"verbose\""}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			log.SetLevels(logrus.ErrorLevel, map[string]logrus.Level{"docker": logrus.InfoLevel})
+			defer log.SetLevels(logrus.InfoLevel, nil)
+
+			handler := New(static.Configuration{API: &static.API{}, Global: &static.Global{}}, &runtime.Configuration{})
+			server := httptest.NewServer(handler.createRouter())
+			defer server.Close()
+
+			req, err := http.NewRequest(test.method, server.URL+"/api/log/levels", strings.NewReader(test.body))
+			require.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, test.expected, string(body))
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/rules"
+	"github.com/traefik/traefik/v2/pkg/server/provider"
+	"github.com/traefik/traefik/v2/pkg/tls"
+)
+
+const (
+	defaultTLSStoreName   = "default"
+	defaultTLSOptionsName = "default"
+
+	redactedValue = "xxxx"
+)
+
+type tlsCertificateRepresentation struct {
+	Provider     string    `json:"provider,omitempty"`
+	Resolver     string    `json:"resolver,omitempty"`
+	Stores       []string  `json:"stores,omitempty"`
+	Default      bool      `json:"default,omitempty"`
+	CommonName   string    `json:"commonName,omitempty"`
+	SANs         []string  `json:"sans,omitempty"`
+	Issuer       string    `json:"issuer,omitempty"`
+	SerialNumber string    `json:"serialNumber,omitempty"`
+	NotBefore    time.Time `json:"notBefore"`
+	NotAfter     time.Time `json:"notAfter"`
+	KeyType      string    `json:"keyType,omitempty"`
+	UsedBy       []string  `json:"usedBy,omitempty"`
+}
+
+func newTLSCertificateRepresentation(info tls.CertificateInfo, routers []tlsRouter) tlsCertificateRepresentation {
+	cert := info.Certificate
+
+	var sans []string
+	for _, dnsName := range cert.DNSNames {
+		sans = append(sans, strings.ToLower(dnsName))
+	}
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	repr := tlsCertificateRepresentation{
+		Provider:     info.Provider,
+		Stores:       info.Stores,
+		Default:      info.Default,
+		CommonName:   cert.Subject.CommonName,
+		SANs:         sans,
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: cert.SerialNumber.Text(16),
+		NotBefore:    cert.NotBefore,
+		NotAfter:     cert.NotAfter,
+		KeyType:      getKeyType(cert),
+	}
+
+	if strings.HasSuffix(info.Provider, ".acme") {
+		repr.Resolver = strings.TrimSuffix(info.Provider, ".acme")
+	}
+
+	// The routers only use the certificates of the default store, and the default certificate when no other matches.
+	if info.Default || !contains(info.Stores, defaultTLSStoreName) {
+		return repr
+	}
+
+	certDomains := sans
+	if cert.Subject.CommonName != "" {
+		certDomains = append([]string{strings.ToLower(cert.Subject.CommonName)}, sans...)
+	}
+
+	for _, router := range routers {
+		if router.matchCertificate(certDomains) {
+			repr.UsedBy = append(repr.UsedBy, router.name)
+		}
+	}
+
+	return repr
+}
+
+type tlsOptionsRepresentation struct {
+	tls.Options
+	Name     string   `json:"name,omitempty"`
+	Provider string   `json:"provider,omitempty"`
+	UsedBy   []string `json:"usedBy,omitempty"`
+}
+
+func newTLSOptionsRepresentation(name string, options tls.Options, routers []tlsRouter) tlsOptionsRepresentation {
+	repr := tlsOptionsRepresentation{
+		Options: options,
+		Name:    name,
+	}
+
+	if strings.Contains(name, "@") {
+		repr.Provider = getProviderName(name)
+	}
+
+	// The session ticket secrets are not exposed.
+	if options.SessionTickets != nil && len(options.SessionTickets.KeyFiles) > 0 {
+		sessionTickets := *options.SessionTickets
+		sessionTickets.KeyFiles = make([]tls.FileOrContent, len(options.SessionTickets.KeyFiles))
+		for i := range sessionTickets.KeyFiles {
+			sessionTickets.KeyFiles[i] = redactedValue
+		}
+		repr.SessionTickets = &sessionTickets
+	}
+
+	for _, router := range routers {
+		if router.options == name {
+			repr.UsedBy = append(repr.UsedBy, router.name)
+		}
+	}
+
+	return repr
+}
+
+func (h Handler) getTLSCertificates(rw http.ResponseWriter, request *http.Request) {
+	results := make([]tlsCertificateRepresentation, 0)
+
+	if h.tlsManager != nil {
+		criterion := newSearchCriterion(request.URL.Query())
+		routers := h.getTLSRouters()
+
+		for _, info := range h.tlsManager.GetCertificatesInfo() {
+			repr := newTLSCertificateRepresentation(info, routers)
+			if keepTLSCertificate(repr, criterion) {
+				results = append(results, repr)
+			}
+		}
+	}
+
+	// The certificates expiring first come first.
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].NotAfter.Equal(results[j].NotAfter) {
+			return results[i].CommonName < results[j].CommonName
+		}
+		return results[i].NotAfter.Before(results[j].NotAfter)
+	})
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	pageInfo, err := pagination(request, len(results))
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rw.Header().Set(nextPageHeader, strconv.Itoa(pageInfo.nextPage))
+
+	err = json.NewEncoder(rw).Encode(results[pageInfo.startIndex:pageInfo.endIndex])
+	if err != nil {
+		log.FromContext(request.Context()).Error(err)
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h Handler) getTLSOptions(rw http.ResponseWriter, request *http.Request) {
+	results := make([]tlsOptionsRepresentation, 0)
+
+	if h.tlsManager != nil {
+		criterion := newSearchCriterion(request.URL.Query())
+		routers := h.getTLSRouters()
+
+		for name, options := range h.tlsManager.GetOptions() {
+			if criterion == nil || criterion.searchIn(name) {
+				results = append(results, newTLSOptionsRepresentation(name, options, routers))
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	pageInfo, err := pagination(request, len(results))
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rw.Header().Set(nextPageHeader, strconv.Itoa(pageInfo.nextPage))
+
+	err = json.NewEncoder(rw).Encode(results[pageInfo.startIndex:pageInfo.endIndex])
+	if err != nil {
+		log.FromContext(request.Context()).Error(err)
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func keepTLSCertificate(repr tlsCertificateRepresentation, criterion *searchCriterion) bool {
+	if criterion == nil {
+		return true
+	}
+
+	return criterion.searchIn(append([]string{repr.CommonName, repr.Provider, repr.Issuer}, repr.SANs...)...)
+}
+
+// tlsRouter is a router terminating TLS, with the TLS options it uses and the domains it serves.
+type tlsRouter struct {
+	name    string
+	options string
+	domains []string
+}
+
+// matchCertificate returns whether one of the domains of the router is served by the certificate.
+func (r tlsRouter) matchCertificate(certDomains []string) bool {
+	for _, domain := range r.domains {
+		for _, certDomain := range certDomains {
+			if tls.MatchDomain(domain, certDomain) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getTLSRouters returns the HTTP and TCP routers terminating TLS.
+func (h Handler) getTLSRouters() []tlsRouter {
+	var routers []tlsRouter
+
+	for name, rt := range h.runtimeConfiguration.Routers {
+		if rt.TLS == nil {
+			continue
+		}
+
+		domains, _ := rules.ParseDomains(rt.Rule)
+		for _, domain := range rt.TLS.Domains {
+			domains = append(domains, domain.ToStrArray()...)
+		}
+
+		routers = append(routers, tlsRouter{
+			name:    name,
+			options: getTLSOptionsName(name, rt.TLS.Options),
+			domains: lowerDomains(domains),
+		})
+	}
+
+	for name, rt := range h.runtimeConfiguration.TCPRouters {
+		if rt.TLS == nil || rt.TLS.Passthrough {
+			continue
+		}
+
+		domains, _ := rules.ParseHostSNI(rt.Rule)
+		for _, domain := range rt.TLS.Domains {
+			domains = append(domains, domain.ToStrArray()...)
+		}
+
+		routers = append(routers, tlsRouter{
+			name:    name,
+			options: getTLSOptionsName(name, rt.TLS.Options),
+			domains: lowerDomains(domains),
+		})
+	}
+
+	sort.Slice(routers, func(i, j int) bool {
+		return routers[i].name < routers[j].name
+	})
+
+	return routers
+}
+
+// getTLSOptionsName returns the qualified name of the TLS options used by the router.
+func getTLSOptionsName(routerName, options string) string {
+	if options == "" || options == defaultTLSOptionsName {
+		return defaultTLSOptionsName
+	}
+
+	return provider.GetQualifiedName(provider.AddInContext(context.Background(), routerName), options)
+}
+
+func getKeyType(cert *x509.Certificate) string {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA%d", key.N.BitLen())
+	case *ecdsa.PublicKey:
+		return fmt.Sprintf("EC%d", key.Curve.Params().BitSize)
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return cert.PublicKeyAlgorithm.String()
+	}
+}
+
+func lowerDomains(domains []string) []string {
+	for i, domain := range domains {
+		domains[i] = strings.ToLower(domain)
+	}
+
+	return domains
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/tls"
+	"github.com/traefik/traefik/v2/pkg/tls/generate"
+)
+
+func TestHandler_TLS(t *testing.T) {
+	fooNotAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	fooCert, fooKey, err := generate.KeyPair("foo.com", fooNotAfter)
+	require.NoError(t, err)
+
+	barNotAfter := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	barCert, barKey, err := generate.KeyPair("bar.com", barNotAfter)
+	require.NoError(t, err)
+
+	tlsManager := tls.NewManager()
+	tlsManager.UpdateConfigs(context.Background(),
+		map[string]tls.Store{"default": {}},
+		map[string]tls.Options{
+			"default":      {},
+			"strict@file":  {MinVersion: "VersionTLS13", SniStrict: true},
+			"tickets@file": {SessionTickets: &tls.SessionTickets{KeyFiles: []tls.FileOrContent{"0123456789abcdef0123456789abcdef"}}},
+		},
+		[]*tls.CertAndStores{
+			{
+				Certificate: tls.Certificate{CertFile: tls.FileOrContent(barCert), KeyFile: tls.FileOrContent(barKey)},
+				Provider:    "myresolver.acme",
+			},
+			{
+				Certificate: tls.Certificate{CertFile: tls.FileOrContent(fooCert), KeyFile: tls.FileOrContent(fooKey)},
+				Provider:    "file",
+			},
+		})
+
+	rtConf := &runtime.Configuration{
+		Routers: map[string]*runtime.RouterInfo{
+			"foo@docker": {Router: &dynamic.Router{Rule: "Host(`foo.com`)", TLS: &dynamic.RouterTLSConfig{Options: "strict@file"}}},
+			"bar@file":   {Router: &dynamic.Router{Rule: "Host(`www.example.com`)", TLS: &dynamic.RouterTLSConfig{}}},
+			"baz@file":   {Router: &dynamic.Router{Rule: "Host(`bar.com`)"}},
+		},
+		TCPRouters: map[string]*runtime.TCPRouterInfo{
+			"tcp@file": {TCPRouter: &dynamic.TCPRouter{Rule: "HostSNI(`bar.com`)", TLS: &dynamic.RouterTCPTLSConfig{Options: "strict"}}},
+		},
+	}
+
+	handler := NewBuilder(static.Configuration{API: &static.API{}, Global: &static.Global{}}, tlsManager, nil)(rtConf)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	resp, err := http.DefaultClient.Get(server.URL + "/api/tls/certificates")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var certificates []tlsCertificateRepresentation
+	err = json.NewDecoder(resp.Body).Decode(&certificates)
+	require.NoError(t, err)
+
+	// The certificates expiring first come first, the generated default certificate expires in a year.
+	require.Len(t, certificates, 3)
+
+	assert.Equal(t, "file", certificates[0].Provider)
+	assert.Empty(t, certificates[0].Resolver)
+	assert.Equal(t, []string{"default"}, certificates[0].Stores)
+	assert.Equal(t, []string{"foo.com"}, certificates[0].SANs)
+	assert.Equal(t, "RSA2048", certificates[0].KeyType)
+	assert.NotEmpty(t, certificates[0].SerialNumber)
+	assert.True(t, fooNotAfter.Equal(certificates[0].NotAfter))
+	assert.Equal(t, []string{"foo@docker"}, certificates[0].UsedBy)
+
+	assert.Equal(t, "myresolver.acme", certificates[1].Provider)
+	assert.Equal(t, "myresolver", certificates[1].Resolver)
+	assert.Equal(t, []string{"bar.com"}, certificates[1].SANs)
+	assert.Equal(t, []string{"tcp@file"}, certificates[1].UsedBy)
+
+	assert.True(t, certificates[2].Default)
+	assert.Equal(t, []string{"default"}, certificates[2].Stores)
+	assert.Empty(t, certificates[2].UsedBy)
+
+	resp, err = http.DefaultClient.Get(server.URL + "/api/tls/options")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var options []tlsOptionsRepresentation
+	err = json.NewDecoder(resp.Body).Decode(&options)
+	require.NoError(t, err)
+
+	expected := []tlsOptionsRepresentation{
+		{
+			Name:   "default",
+			UsedBy: []string{"bar@file"},
+		},
+		{
+			Options:  tls.Options{MinVersion: "VersionTLS13", SniStrict: true},
+			Name:     "strict@file",
+			Provider: "file",
+			UsedBy:   []string{"foo@docker", "tcp@file"},
+		},
+		{
+			Options:  tls.Options{SessionTickets: &tls.SessionTickets{KeyFiles: []tls.FileOrContent{"xxxx"}}},
+			Name:     "tickets@file",
+			Provider: "file",
+		},
+	}
+	assert.Equal(t, expected, options)
+}
+
+func TestHandler_TLS_noManager(t *testing.T) {
+	handler := NewBuilder(static.Configuration{API: &static.API{}, Global: &static.Global{}}, nil, nil)(&runtime.Configuration{})
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	for _, path := range []string{"/api/tls/certificates", "/api/tls/options"} {
+		resp, err := http.DefaultClient.Get(server.URL + path)
+		require.NoError(t, err)
+
+		var results []interface{}
+		err = json.NewDecoder(resp.Body).Decode(&results)
+		_ = resp.Body.Close()
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, results)
+	}
+}
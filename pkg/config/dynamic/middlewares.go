@@ -144,6 +144,8 @@ type ForwardAuth struct {
 type Headers struct {
 	CustomRequestHeaders  map[string]string `json:"customRequestHeaders,omitempty" toml:"customRequestHeaders,omitempty" yaml:"customRequestHeaders,omitempty" export:"true"`
 	CustomResponseHeaders map[string]string `json:"customResponseHeaders,omitempty" toml:"customResponseHeaders,omitempty" yaml:"customResponseHeaders,omitempty" export:"true"`
+	// JA3Header is the name of the request header set with the JA3 fingerprint of the TLS ClientHello of the client.
+	JA3Header string `json:"ja3Header,omitempty" toml:"ja3Header,omitempty" yaml:"ja3Header,omitempty" export:"true"`
 
 	// AccessControlAllowCredentials is only valid if true. false is ignored.
 	AccessControlAllowCredentials bool `json:"accessControlAllowCredentials,omitempty" toml:"accessControlAllowCredentials,omitempty" yaml:"accessControlAllowCredentials,omitempty" export:"true"`
@@ -190,7 +192,8 @@ type Headers struct {
 // HasCustomHeadersDefined checks to see if any of the custom header elements have been set.
 func (h *Headers) HasCustomHeadersDefined() bool {
 	return h != nil && (len(h.CustomResponseHeaders) != 0 ||
-		len(h.CustomRequestHeaders) != 0)
+		len(h.CustomRequestHeaders) != 0 ||
+		h.JA3Header != "")
 }
 
 // HasCorsHeadersDefined checks to see if any of the cors header elements have been set.
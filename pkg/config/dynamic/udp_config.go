@@ -2,6 +2,8 @@ package dynamic
 
 import (
 	"reflect"
+
+	"github.com/traefik/traefik/v2/pkg/types"
 )
 
 // +k8s:deepcopy-gen=true
@@ -45,8 +47,9 @@ func (w *UDPWRRService) SetDefaults() {
 
 // UDPRouter defines the configuration for an UDP router.
 type UDPRouter struct {
-	EntryPoints []string `json:"entryPoints,omitempty" toml:"entryPoints,omitempty" yaml:"entryPoints,omitempty" export:"true"`
-	Service     string   `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
+	EntryPoints []string               `json:"entryPoints,omitempty" toml:"entryPoints,omitempty" yaml:"entryPoints,omitempty" export:"true"`
+	Service     string                 `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
+	AccessLog   *types.AccessLogPolicy `json:"accessLog,omitempty" toml:"accessLog,omitempty" yaml:"accessLog,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
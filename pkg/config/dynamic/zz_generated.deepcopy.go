@@ -815,6 +815,11 @@ func (in *Model) DeepCopyInto(out *Model) {
 		*out = new(RouterTLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(types.AccessLogPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1000,6 +1005,11 @@ func (in *Router) DeepCopyInto(out *Router) {
 		*out = new(RouterTLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(types.AccessLogPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1321,6 +1331,11 @@ func (in *TCPRouter) DeepCopyInto(out *TCPRouter) {
 		*out = new(RouterTCPTLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(types.AccessLogPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1588,6 +1603,11 @@ func (in *UDPRouter) DeepCopyInto(out *UDPRouter) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(types.AccessLogPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
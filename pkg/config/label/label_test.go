@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/types"
 )
 
 func TestDecodeConfiguration(t *testing.T) {
@@ -66,6 +67,7 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.http.middlewares.Middleware8.headers.framedeny":                                   "true",
 		"traefik.http.middlewares.Middleware8.headers.hostsproxyheaders":                           "foobar, fiibar",
 		"traefik.http.middlewares.Middleware8.headers.isdevelopment":                               "true",
+		"traefik.http.middlewares.Middleware8.headers.ja3header":                                   "foobar",
 		"traefik.http.middlewares.Middleware8.headers.publickey":                                   "foobar",
 		"traefik.http.middlewares.Middleware8.headers.referrerpolicy":                              "foobar",
 		"traefik.http.middlewares.Middleware8.headers.featurepolicy":                               "foobar",
@@ -134,6 +136,8 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.http.routers.Router0.rule":                                                        "foobar",
 		"traefik.http.routers.Router0.tls":                                                         "true",
 		"traefik.http.routers.Router0.service":                                                     "foobar",
+		"traefik.http.routers.Router1.accesslog.filters.statuscodes":                               "foobar, fiibar",
+		"traefik.http.routers.Router1.accesslog.samplerate":                                        "0.5",
 		"traefik.http.routers.Router1.entrypoints":                                                 "foobar, fiibar",
 		"traefik.http.routers.Router1.middlewares":                                                 "foobar, fiibar",
 		"traefik.http.routers.Router1.priority":                                                    "42",
@@ -317,6 +321,12 @@ func TestDecodeConfiguration(t *testing.T) {
 					Service:  "foobar",
 					Rule:     "foobar",
 					Priority: 42,
+					AccessLog: &types.AccessLogPolicy{
+						SampleRate: 0.5,
+						Filters: &types.AccessLogFilters{
+							StatusCodes: []string{"foobar", "fiibar"},
+						},
+					},
 				},
 			},
 			Middlewares: map[string]*dynamic.Middleware{
@@ -520,6 +530,7 @@ func TestDecodeConfiguration(t *testing.T) {
 							"name0": "foobar",
 							"name1": "foobar",
 						},
+						JA3Header:                     "foobar",
 						AccessControlAllowCredentials: true,
 						AccessControlAllowHeaders: []string{
 							"X-foobar",
@@ -787,6 +798,12 @@ func TestEncodeConfiguration(t *testing.T) {
 					Service:  "foobar",
 					Rule:     "foobar",
 					Priority: 42,
+					AccessLog: &types.AccessLogPolicy{
+						SampleRate: 0.5,
+						Filters: &types.AccessLogFilters{
+							StatusCodes: []string{"foobar", "fiibar"},
+						},
+					},
 				},
 			},
 			Middlewares: map[string]*dynamic.Middleware{
@@ -997,6 +1014,7 @@ func TestEncodeConfiguration(t *testing.T) {
 							"name0": "foobar",
 							"name1": "foobar",
 						},
+						JA3Header:                     "foobar",
 						AccessControlAllowCredentials: true,
 						AccessControlAllowHeaders: []string{
 							"X-foobar",
@@ -1189,6 +1207,7 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Middlewares.Middleware8.Headers.FrameDeny":                                   "true",
 		"traefik.HTTP.Middlewares.Middleware8.Headers.HostsProxyHeaders":                           "foobar, fiibar",
 		"traefik.HTTP.Middlewares.Middleware8.Headers.IsDevelopment":                               "true",
+		"traefik.HTTP.Middlewares.Middleware8.Headers.JA3Header":                                   "foobar",
 		"traefik.HTTP.Middlewares.Middleware8.Headers.PublicKey":                                   "foobar",
 		"traefik.HTTP.Middlewares.Middleware8.Headers.ReferrerPolicy":                              "foobar",
 		"traefik.HTTP.Middlewares.Middleware8.Headers.FeaturePolicy":                               "foobar",
@@ -1253,17 +1272,22 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Middlewares.Middleware20.Plugin.tomato.aaa":                                  "foo1",
 		"traefik.HTTP.Middlewares.Middleware20.Plugin.tomato.bbb":                                  "foo2",
 
-		"traefik.HTTP.Routers.Router0.EntryPoints": "foobar, fiibar",
-		"traefik.HTTP.Routers.Router0.Middlewares": "foobar, fiibar",
-		"traefik.HTTP.Routers.Router0.Priority":    "42",
-		"traefik.HTTP.Routers.Router0.Rule":        "foobar",
-		"traefik.HTTP.Routers.Router0.Service":     "foobar",
-		"traefik.HTTP.Routers.Router0.TLS":         "true",
-		"traefik.HTTP.Routers.Router1.EntryPoints": "foobar, fiibar",
-		"traefik.HTTP.Routers.Router1.Middlewares": "foobar, fiibar",
-		"traefik.HTTP.Routers.Router1.Priority":    "42",
-		"traefik.HTTP.Routers.Router1.Rule":        "foobar",
-		"traefik.HTTP.Routers.Router1.Service":     "foobar",
+		"traefik.HTTP.Routers.Router0.EntryPoints":                     "foobar, fiibar",
+		"traefik.HTTP.Routers.Router0.Middlewares":                     "foobar, fiibar",
+		"traefik.HTTP.Routers.Router0.Priority":                        "42",
+		"traefik.HTTP.Routers.Router0.Rule":                            "foobar",
+		"traefik.HTTP.Routers.Router0.Service":                         "foobar",
+		"traefik.HTTP.Routers.Router0.TLS":                             "true",
+		"traefik.HTTP.Routers.Router1.AccessLog.Disabled":              "false",
+		"traefik.HTTP.Routers.Router1.AccessLog.Filters.MinDuration":   "0",
+		"traefik.HTTP.Routers.Router1.AccessLog.Filters.RetryAttempts": "false",
+		"traefik.HTTP.Routers.Router1.AccessLog.Filters.StatusCodes":   "foobar, fiibar",
+		"traefik.HTTP.Routers.Router1.AccessLog.SampleRate":            "0.500000",
+		"traefik.HTTP.Routers.Router1.EntryPoints":                     "foobar, fiibar",
+		"traefik.HTTP.Routers.Router1.Middlewares":                     "foobar, fiibar",
+		"traefik.HTTP.Routers.Router1.Priority":                        "42",
+		"traefik.HTTP.Routers.Router1.Rule":                            "foobar",
+		"traefik.HTTP.Routers.Router1.Service":                         "foobar",
 
 		"traefik.HTTP.Services.Service0.LoadBalancer.HealthCheck.Headers.name1":        "foobar",
 		"traefik.HTTP.Services.Service0.LoadBalancer.HealthCheck.Hostname":             "foobar",
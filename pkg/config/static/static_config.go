@@ -1,6 +1,7 @@
 package static
 
 import (
+	"errors"
 	"fmt"
 	stdlog "log"
 	"strings"
@@ -34,6 +35,7 @@ import (
 	"github.com/traefik/traefik/v2/pkg/tracing/haystack"
 	"github.com/traefik/traefik/v2/pkg/tracing/instana"
 	"github.com/traefik/traefik/v2/pkg/tracing/jaeger"
+	"github.com/traefik/traefik/v2/pkg/tracing/opentelemetry"
 	"github.com/traefik/traefik/v2/pkg/tracing/zipkin"
 	"github.com/traefik/traefik/v2/pkg/types"
 )
@@ -99,6 +101,7 @@ type ServersTransport struct {
 	RootCAs             []tls.FileOrContent `description:"Add cert file for self-signed certificate." json:"rootCAs,omitempty" toml:"rootCAs,omitempty" yaml:"rootCAs,omitempty"`
 	MaxIdleConnsPerHost int                 `description:"If non-zero, controls the maximum idle (keep-alive) to keep per-host. If zero, DefaultMaxIdleConnsPerHost is used" json:"maxIdleConnsPerHost,omitempty" toml:"maxIdleConnsPerHost,omitempty" yaml:"maxIdleConnsPerHost,omitempty" export:"true"`
 	ForwardingTimeouts  *ForwardingTimeouts `description:"Timeouts for requests forwarded to the backend servers." json:"forwardingTimeouts,omitempty" toml:"forwardingTimeouts,omitempty" yaml:"forwardingTimeouts,omitempty" export:"true"`
+	ClientCertificate   *ClientCertificate  `description:"Client certificate automatically issued and renewed, presented to the backend servers for mTLS." json:"clientCertificate,omitempty" toml:"clientCertificate,omitempty" yaml:"clientCertificate,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
 }
 
 // API holds the API configuration.
@@ -141,6 +144,26 @@ func (f *ForwardingTimeouts) SetDefaults() {
 	f.IdleConnTimeout = ptypes.Duration(90 * time.Second)
 }
 
+// ClientCertificate configures the client certificate automatically issued and renewed,
+// and presented to the backend servers by the servers transports without certificates of their own.
+type ClientCertificate struct {
+	CommonName   string               `description:"Common name, and DNS SAN, of the client certificate (default: traefik with a local certificate authority, required with a certificates resolver)." json:"commonName,omitempty" toml:"commonName,omitempty" yaml:"commonName,omitempty" export:"true"`
+	CertResolver string               `description:"Name of the ACME certificates resolver obtaining the client certificate." json:"certResolver,omitempty" toml:"certResolver,omitempty" yaml:"certResolver,omitempty" export:"true"`
+	CA           *ClientCertificateCA `description:"Local certificate authority issuing the client certificate." json:"ca,omitempty" toml:"ca,omitempty" yaml:"ca,omitempty"`
+	Duration     ptypes.Duration      `description:"Lifetime of the client certificates issued by the local certificate authority." json:"duration,omitempty" toml:"duration,omitempty" yaml:"duration,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (c *ClientCertificate) SetDefaults() {
+	c.Duration = ptypes.Duration(24 * time.Hour)
+}
+
+// ClientCertificateCA is the local certificate authority issuing the client certificates.
+type ClientCertificateCA struct {
+	CertFile tls.FileOrContent `description:"Certificate of the certificate authority." json:"certFile,omitempty" toml:"certFile,omitempty" yaml:"certFile,omitempty"`
+	KeyFile  tls.FileOrContent `description:"Private key of the certificate authority." json:"keyFile,omitempty" toml:"keyFile,omitempty" yaml:"keyFile,omitempty"`
+}
+
 // LifeCycle contains configurations relevant to the lifecycle (such as the shutdown phase) of Traefik.
 type LifeCycle struct {
 	RequestAcceptGraceTimeout ptypes.Duration `description:"Duration to keep accepting requests before Traefik initiates the graceful shutdown procedure." json:"requestAcceptGraceTimeout,omitempty" toml:"requestAcceptGraceTimeout,omitempty" yaml:"requestAcceptGraceTimeout,omitempty" export:"true"`
@@ -154,14 +177,15 @@ func (a *LifeCycle) SetDefaults() {
 
 // Tracing holds the tracing configuration.
 type Tracing struct {
-	ServiceName   string           `description:"Set the name for this service." json:"serviceName,omitempty" toml:"serviceName,omitempty" yaml:"serviceName,omitempty" export:"true"`
-	SpanNameLimit int              `description:"Set the maximum character limit for Span names (default 0 = no limit)." json:"spanNameLimit,omitempty" toml:"spanNameLimit,omitempty" yaml:"spanNameLimit,omitempty" export:"true"`
-	Jaeger        *jaeger.Config   `description:"Settings for Jaeger." json:"jaeger,omitempty" toml:"jaeger,omitempty" yaml:"jaeger,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
-	Zipkin        *zipkin.Config   `description:"Settings for Zipkin." json:"zipkin,omitempty" toml:"zipkin,omitempty" yaml:"zipkin,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
-	Datadog       *datadog.Config  `description:"Settings for Datadog." json:"datadog,omitempty" toml:"datadog,omitempty" yaml:"datadog,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
-	Instana       *instana.Config  `description:"Settings for Instana." json:"instana,omitempty" toml:"instana,omitempty" yaml:"instana,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
-	Haystack      *haystack.Config `description:"Settings for Haystack." json:"haystack,omitempty" toml:"haystack,omitempty" yaml:"haystack,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
-	Elastic       *elastic.Config  `description:"Settings for Elastic." json:"elastic,omitempty" toml:"elastic,omitempty" yaml:"elastic,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
+	ServiceName   string                `description:"Set the name for this service." json:"serviceName,omitempty" toml:"serviceName,omitempty" yaml:"serviceName,omitempty" export:"true"`
+	SpanNameLimit int                   `description:"Set the maximum character limit for Span names (default 0 = no limit)." json:"spanNameLimit,omitempty" toml:"spanNameLimit,omitempty" yaml:"spanNameLimit,omitempty" export:"true"`
+	Jaeger        *jaeger.Config        `description:"Settings for Jaeger." json:"jaeger,omitempty" toml:"jaeger,omitempty" yaml:"jaeger,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
+	Zipkin        *zipkin.Config        `description:"Settings for Zipkin." json:"zipkin,omitempty" toml:"zipkin,omitempty" yaml:"zipkin,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
+	Datadog       *datadog.Config       `description:"Settings for Datadog." json:"datadog,omitempty" toml:"datadog,omitempty" yaml:"datadog,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
+	Instana       *instana.Config       `description:"Settings for Instana." json:"instana,omitempty" toml:"instana,omitempty" yaml:"instana,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
+	Haystack      *haystack.Config      `description:"Settings for Haystack." json:"haystack,omitempty" toml:"haystack,omitempty" yaml:"haystack,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
+	Elastic       *elastic.Config       `description:"Settings for Elastic." json:"elastic,omitempty" toml:"elastic,omitempty" yaml:"elastic,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
+	OpenTelemetry *opentelemetry.Config `description:"Settings for OpenTelemetry." json:"openTelemetry,omitempty" toml:"openTelemetry,omitempty" yaml:"openTelemetry,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
 }
 
 // SetDefaults sets the default values.
@@ -284,7 +308,7 @@ func (c *Configuration) ValidateConfiguration() error {
 			continue
 		}
 
-		if len(resolver.ACME.Storage) == 0 {
+		if len(resolver.ACME.Storage) == 0 && resolver.ACME.KVStorage == nil {
 			return fmt.Errorf("unable to initialize certificates resolver %q with no storage location for the certificates", name)
 		}
 
@@ -294,6 +318,38 @@ func (c *Configuration) ValidateConfiguration() error {
 		acmeEmail = resolver.ACME.Email
 	}
 
+	if c.ServersTransport != nil && c.ServersTransport.ClientCertificate != nil {
+		if err := c.ServersTransport.ClientCertificate.validate(c.CertificatesResolvers); err != nil {
+			return fmt.Errorf("invalid servers transport client certificate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *ClientCertificate) validate(resolvers map[string]CertificateResolver) error {
+	if (c.CA == nil) == (c.CertResolver == "") {
+		return errors.New("either a local certificate authority or a certificates resolver is required")
+	}
+
+	if c.CertResolver != "" {
+		// The common name is the domain validated by the ACME server, which must be a fully qualified domain name.
+		if c.CommonName == "" {
+			return errors.New("common name required with a certificates resolver")
+		}
+		if !strings.Contains(strings.Trim(c.CommonName, "."), ".") {
+			return fmt.Errorf("the common name %q must be a fully qualified domain name with a certificates resolver", c.CommonName)
+		}
+
+		if resolver, ok := resolvers[c.CertResolver]; !ok || resolver.ACME == nil {
+			return fmt.Errorf("unknown ACME certificates resolver %q", c.CertResolver)
+		}
+	}
+
+	if c.CA != nil && c.Duration <= 0 {
+		return errors.New("the duration of the client certificates must be positive")
+	}
+
 	return nil
 }
 
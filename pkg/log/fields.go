@@ -14,4 +14,5 @@ const (
 	ServerName           = "serverName"
 	TLSStoreName         = "tlsStoreName"
 	ServersTransportName = "serversTransport"
+	Suppressed           = "suppressed"
 )
@@ -0,0 +1,88 @@
+package log
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// formatter formats the entries enabled by the levels of the components,
+// adding the configured fields, and limiting the rate of the identical ones.
+type formatter struct {
+	logrus.Formatter
+}
+
+var (
+	formatterMu sync.RWMutex
+	fields      logrus.Fields
+	limiter     *rateLimiter
+)
+
+// Format returns nothing for the entries which must not be logged.
+func (f formatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !levels.enabled(entry) {
+		return nil, nil
+	}
+
+	formatterMu.RLock()
+	extraFields, rl := fields, limiter
+	formatterMu.RUnlock()
+
+	var suppressed int
+	if rl != nil {
+		var allowed bool
+		allowed, suppressed = rl.allow(entry)
+		if !allowed {
+			return nil, nil
+		}
+	}
+
+	if len(extraFields) == 0 && suppressed == 0 {
+		return f.Formatter.Format(entry)
+	}
+
+	// The data of the entry may be shared with other ones, it is copied before being completed.
+	data := make(logrus.Fields, len(entry.Data)+len(extraFields)+1)
+	for name, value := range extraFields {
+		data[name] = value
+	}
+	for name, value := range entry.Data {
+		data[name] = value
+	}
+	if suppressed > 0 {
+		data[Suppressed] = suppressed
+	}
+
+	completed := *entry
+	completed.Data = data
+
+	return f.Formatter.Format(&completed)
+}
+
+// SetFields sets the fields added to all the entries of the standard logger,
+// the fields of the entries taking precedence over them.
+func SetFields(extraFields map[string]string) {
+	formatterMu.Lock()
+	defer formatterMu.Unlock()
+
+	fields = make(logrus.Fields, len(extraFields))
+	for name, value := range extraFields {
+		fields[name] = value
+	}
+}
+
+// SetRateLimit limits the number of identical warning and error entries logged by the standard logger to burst per interval,
+// the number of suppressed ones being reported by the first entry logged after the interval.
+// A burst lower than or equal to 0 disables the limit.
+func SetRateLimit(burst int, interval time.Duration) {
+	formatterMu.Lock()
+	defer formatterMu.Unlock()
+
+	if burst <= 0 || interval <= 0 {
+		limiter = nil
+		return
+	}
+
+	limiter = newRateLimiter(burst, interval)
+}
@@ -0,0 +1,116 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatter_levels(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		level      logrus.Level
+		components map[string]logrus.Level
+		fields     map[string]string
+		expected   bool
+	}{
+		{
+			desc:     "disabled without component levels",
+			level:    logrus.ErrorLevel,
+			fields:   map[string]string{ProviderName: "kubernetes"},
+			expected: false,
+		},
+		{
+			desc:       "enabled by the level of the component",
+			level:      logrus.ErrorLevel,
+			components: map[string]logrus.Level{"kubernetes": logrus.DebugLevel},
+			fields:     map[string]string{ProviderName: "kubernetes"},
+			expected:   true,
+		},
+		{
+			desc:       "disabled for another component",
+			level:      logrus.ErrorLevel,
+			components: map[string]logrus.Level{"kubernetes": logrus.DebugLevel},
+			fields:     map[string]string{ProviderName: "docker"},
+			expected:   false,
+		},
+		{
+			desc:       "disabled by the level of the component",
+			level:      logrus.DebugLevel,
+			components: map[string]logrus.Level{"router@docker": logrus.ErrorLevel},
+			fields:     map[string]string{RouterName: "router@docker"},
+			expected:   false,
+		},
+		{
+			desc:       "enabled by the most verbose level of the components",
+			level:      logrus.ErrorLevel,
+			components: map[string]logrus.Level{"router@docker": logrus.ErrorLevel, "docker": logrus.DebugLevel},
+			fields:     map[string]string{ProviderName: "docker", RouterName: "router@docker"},
+			expected:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var buffer bytes.Buffer
+			SetOutput(&buffer)
+			SetLevels(test.level, test.components)
+			defer SetLevels(logrus.InfoLevel, nil)
+
+			ctx := context.Background()
+			for key, value := range test.fields {
+				ctx = With(ctx, Str(key, value))
+			}
+
+			FromContext(ctx).Debug("message test")
+
+			assert.Equal(t, test.expected, strings.Contains(buffer.String(), "message test"))
+		})
+	}
+}
+
+func TestFormatter_fields(t *testing.T) {
+	var buffer bytes.Buffer
+	SetOutput(&buffer)
+	SetFields(map[string]string{"env": "production", "foo": "bar"})
+	defer SetFields(nil)
+
+	FromContext(With(context.Background(), Str("foo", "baz"))).Error("message test")
+	WithoutContext().Error("message test")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Regexp(t, ` msg="message test" env=production foo=baz$`, lines[0])
+	assert.Regexp(t, ` msg="message test" env=production foo=bar$`, lines[1])
+}
+
+func TestFormatter_rateLimit(t *testing.T) {
+	var buffer bytes.Buffer
+	SetOutput(&buffer)
+	SetRateLimit(2, 50*time.Millisecond)
+	defer SetRateLimit(0, 0)
+
+	logger := FromContext(With(context.Background(), Str(ServiceName, "service")))
+
+	for i := 0; i < 5; i++ {
+		logger.Error("health check failed")
+		logger.Info("health check succeeded")
+	}
+	logger.Error("another error")
+
+	assert.Equal(t, 2, strings.Count(buffer.String(), "health check failed"))
+	assert.Equal(t, 5, strings.Count(buffer.String(), "health check succeeded"))
+	assert.Equal(t, 1, strings.Count(buffer.String(), "another error"))
+
+	time.Sleep(60 * time.Millisecond)
+	buffer.Reset()
+
+	logger.Error("health check failed")
+
+	assert.Regexp(t, ` msg="health check failed" serviceName=service suppressed=3$`, strings.TrimSpace(buffer.String()))
+}
@@ -0,0 +1,93 @@
+package log
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// componentFields are the names of the fields identifying the components an entry relates to.
+var componentFields = []string{ProviderName, EntryPointName, RouterName, ServiceName, MiddlewareName}
+
+// levels holds the level of the standard logger and the levels of the components.
+var levels = &componentLevels{}
+
+type componentLevels struct {
+	mu         sync.RWMutex
+	level      logrus.Level
+	components map[string]logrus.Level
+}
+
+// set sets the levels, and the level of the standard logger to the most verbose one,
+// for the entries of the components to be logged.
+func (l *componentLevels) set(level logrus.Level, components map[string]logrus.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.level = level
+	l.components = components
+
+	enabled := level
+	for _, lvl := range components {
+		if lvl > enabled {
+			enabled = lvl
+		}
+	}
+
+	logrus.SetLevel(enabled)
+}
+
+func (l *componentLevels) get() (logrus.Level, map[string]logrus.Level) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	components := make(map[string]logrus.Level, len(l.components))
+	for name, lvl := range l.components {
+		components[name] = lvl
+	}
+
+	return l.level, components
+}
+
+// enabled returns whether the entry is enabled by the level of one of the components it relates to,
+// or by the level of the standard logger if it does not relate to any component having a level.
+func (l *componentLevels) enabled(entry *logrus.Entry) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if len(l.components) == 0 {
+		return entry.Level <= l.level
+	}
+
+	level, found := l.level, false
+	for _, field := range componentFields {
+		name, ok := entry.Data[field].(string)
+		if !ok {
+			continue
+		}
+
+		lvl, ok := l.components[name]
+		if !ok {
+			continue
+		}
+
+		if !found || lvl > level {
+			level, found = lvl, true
+		}
+	}
+
+	return entry.Level <= level
+}
+
+// SetLevels sets the level of the standard logger, and the levels of the components,
+// by name of provider, entry point, router, service or middleware.
+// The entries relating to a component having a level are logged according to it,
+// the most verbose one being used when they relate to several of them.
+func SetLevels(level logrus.Level, components map[string]logrus.Level) {
+	levels.set(level, components)
+}
+
+// GetLevels returns the level of the standard logger, and the levels of the components.
+func GetLevels() (logrus.Level, map[string]logrus.Level) {
+	return levels.get()
+}
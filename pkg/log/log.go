@@ -30,6 +30,8 @@ var (
 func init() {
 	mainLogger = logrus.StandardLogger()
 	logrus.SetOutput(os.Stdout)
+	SetFormatter(logrus.StandardLogger().Formatter)
+	SetLevel(logrus.GetLevel())
 }
 
 // SetLogger sets the logger.
@@ -43,16 +45,18 @@ func SetOutput(out io.Writer) {
 }
 
 // SetFormatter sets the standard logger formatter.
-func SetFormatter(formatter logrus.Formatter) {
-	logrus.SetFormatter(formatter)
+func SetFormatter(f logrus.Formatter) {
+	logrus.SetFormatter(formatter{Formatter: f})
 }
 
-// SetLevel sets the standard logger level.
+// SetLevel sets the standard logger level, keeping the levels of the components.
 func SetLevel(level logrus.Level) {
-	logrus.SetLevel(level)
+	_, components := levels.get()
+	levels.set(level, components)
 }
 
-// GetLevel returns the standard logger level.
+// GetLevel returns the most verbose level enabled on the standard logger,
+// by its level or by the level of one of the components (see SetLevels).
 func GetLevel() logrus.Level {
 	return logrus.GetLevel()
 }
@@ -0,0 +1,100 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// rateLimiter limits the number of identical warning and error entries logged per interval.
+type rateLimiter struct {
+	burst    int
+	interval time.Duration
+
+	mu        sync.Mutex
+	entries   map[string]*rateLimitedEntry
+	lastSweep time.Time
+}
+
+type rateLimitedEntry struct {
+	start time.Time
+	count int
+}
+
+func newRateLimiter(burst int, interval time.Duration) *rateLimiter {
+	return &rateLimiter{
+		burst:     burst,
+		interval:  interval,
+		entries:   make(map[string]*rateLimitedEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// allow returns whether the entry can be logged,
+// and the number of identical entries which were suppressed during the previous interval, if any.
+func (r *rateLimiter) allow(entry *logrus.Entry) (bool, int) {
+	if entry.Level != logrus.ErrorLevel && entry.Level != logrus.WarnLevel {
+		return true, 0
+	}
+
+	key := entryKey(entry)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.sweep(now)
+
+	e, ok := r.entries[key]
+	if !ok || now.Sub(e.start) >= r.interval {
+		var suppressed int
+		if ok && e.count > r.burst {
+			suppressed = e.count - r.burst
+		}
+
+		r.entries[key] = &rateLimitedEntry{start: now, count: 1}
+		return true, suppressed
+	}
+
+	e.count++
+	return e.count <= r.burst, 0
+}
+
+// sweep removes the entries of the intervals ended for more than one interval,
+// the ones which did not suppress anything not needing to be reported anymore.
+func (r *rateLimiter) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < r.interval {
+		return
+	}
+
+	for key, e := range r.entries {
+		if now.Sub(e.start) >= 2*r.interval || (now.Sub(e.start) >= r.interval && e.count <= r.burst) {
+			delete(r.entries, key)
+		}
+	}
+
+	r.lastSweep = now
+}
+
+// entryKey returns the key identifying the identical entries: same level, message and fields.
+func entryKey(entry *logrus.Entry) string {
+	names := make([]string, 0, len(entry.Data))
+	for name := range entry.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var key strings.Builder
+	key.WriteString(entry.Level.String())
+	key.WriteString("|")
+	key.WriteString(entry.Message)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(&key, "|%s=%v", name, entry.Data[name])
+	}
+
+	return key.String()
+}
@@ -30,9 +30,30 @@ const (
 	ddEntryPointReqsName            = "entrypoint.request.total"
 	ddEntryPointReqDurationName     = "entrypoint.request.duration"
 	ddEntryPointOpenConnsName       = "entrypoint.connections.open"
+	ddRouterReqsName                = "router.request.total"
+	ddRouterReqsTLSName             = "router.request.tls.total"
+	ddRouterReqDurationName         = "router.request.duration"
+	ddRouterOpenConnsName           = "router.connections.open"
 	ddOpenConnsName                 = "service.connections.open"
 	ddServerUpName                  = "service.server.up"
 	ddTLSCertsNotAfterTimestampName = "tls.certs.notAfterTimestamp"
+	ddAccessLogDroppedLinesName     = "accesslog.dropped.lines.total"
+	ddEntryPointConnsName           = "entrypoint.connections.total"
+	ddEntryPointActiveConnsName     = "entrypoint.connections.active"
+	ddEntryPointConnDurationName    = "entrypoint.connection.duration"
+	ddEntryPointBytesReceivedName   = "entrypoint.bytes.received"
+	ddEntryPointBytesSentName       = "entrypoint.bytes.sent"
+	ddRouterConnsName               = "router.connections.total"
+	ddRouterActiveConnsName         = "router.connections.active"
+	ddRouterConnDurationName        = "router.connection.duration"
+	ddRouterBytesReceivedName       = "router.bytes.received"
+	ddRouterBytesSentName           = "router.bytes.sent"
+	ddServiceConnsName              = "service.connections.total"
+	ddServiceActiveConnsName        = "service.connections.active"
+	ddServiceConnDurationName       = "service.connection.duration"
+	ddServiceBytesReceivedName      = "service.bytes.received"
+	ddServiceBytesSentName          = "service.bytes.sent"
+	ddServiceDialFailuresName       = "service.dial.failures.total"
 )
 
 // RegisterDatadog registers the metrics pusher if this didn't happen yet and creates a datadog Registry instance.
@@ -47,6 +68,7 @@ func RegisterDatadog(ctx context.Context, config *types.Datadog) Registry {
 		lastConfigReloadSuccessGauge:   datadogClient.NewGauge(ddLastConfigReloadSuccessName),
 		lastConfigReloadFailureGauge:   datadogClient.NewGauge(ddLastConfigReloadFailureName),
 		tlsCertsNotAfterTimestampGauge: datadogClient.NewGauge(ddTLSCertsNotAfterTimestampName),
+		accessLogDroppedLinesCounter:   datadogClient.NewCounter(ddAccessLogDroppedLinesName, 1.0),
 	}
 
 	if config.AddEntryPointsLabels {
@@ -54,6 +76,24 @@ func RegisterDatadog(ctx context.Context, config *types.Datadog) Registry {
 		registry.entryPointReqsCounter = datadogClient.NewCounter(ddEntryPointReqsName, 1.0)
 		registry.entryPointReqDurationHistogram, _ = NewHistogramWithScale(datadogClient.NewHistogram(ddEntryPointReqDurationName, 1.0), time.Second)
 		registry.entryPointOpenConnsGauge = datadogClient.NewGauge(ddEntryPointOpenConnsName)
+		registry.entryPointConnsCounter = datadogClient.NewCounter(ddEntryPointConnsName, 1.0)
+		registry.entryPointActiveConnsGauge = datadogClient.NewGauge(ddEntryPointActiveConnsName)
+		registry.entryPointConnDurationHistogram, _ = NewHistogramWithScale(datadogClient.NewHistogram(ddEntryPointConnDurationName, 1.0), time.Second)
+		registry.entryPointBytesReceivedCounter = datadogClient.NewCounter(ddEntryPointBytesReceivedName, 1.0)
+		registry.entryPointBytesSentCounter = datadogClient.NewCounter(ddEntryPointBytesSentName, 1.0)
+	}
+
+	if config.AddRoutersLabels {
+		registry.routerEnabled = config.AddRoutersLabels
+		registry.routerReqsCounter = datadogClient.NewCounter(ddRouterReqsName, 1.0)
+		registry.routerReqsTLSCounter = datadogClient.NewCounter(ddRouterReqsTLSName, 1.0)
+		registry.routerReqDurationHistogram, _ = NewHistogramWithScale(datadogClient.NewHistogram(ddRouterReqDurationName, 1.0), time.Second)
+		registry.routerOpenConnsGauge = datadogClient.NewGauge(ddRouterOpenConnsName)
+		registry.routerConnsCounter = datadogClient.NewCounter(ddRouterConnsName, 1.0)
+		registry.routerActiveConnsGauge = datadogClient.NewGauge(ddRouterActiveConnsName)
+		registry.routerConnDurationHistogram, _ = NewHistogramWithScale(datadogClient.NewHistogram(ddRouterConnDurationName, 1.0), time.Second)
+		registry.routerBytesReceivedCounter = datadogClient.NewCounter(ddRouterBytesReceivedName, 1.0)
+		registry.routerBytesSentCounter = datadogClient.NewCounter(ddRouterBytesSentName, 1.0)
 	}
 
 	if config.AddServicesLabels {
@@ -63,6 +103,12 @@ func RegisterDatadog(ctx context.Context, config *types.Datadog) Registry {
 		registry.serviceRetriesCounter = datadogClient.NewCounter(ddRetriesTotalName, 1.0)
 		registry.serviceOpenConnsGauge = datadogClient.NewGauge(ddOpenConnsName)
 		registry.serviceServerUpGauge = datadogClient.NewGauge(ddServerUpName)
+		registry.serviceConnsCounter = datadogClient.NewCounter(ddServiceConnsName, 1.0)
+		registry.serviceActiveConnsGauge = datadogClient.NewGauge(ddServiceActiveConnsName)
+		registry.serviceConnDurationHistogram, _ = NewHistogramWithScale(datadogClient.NewHistogram(ddServiceConnDurationName, 1.0), time.Second)
+		registry.serviceBytesReceivedCounter = datadogClient.NewCounter(ddServiceBytesReceivedName, 1.0)
+		registry.serviceBytesSentCounter = datadogClient.NewCounter(ddServiceBytesSentName, 1.0)
+		registry.serviceDialFailuresCounter = datadogClient.NewCounter(ddServiceDialFailuresName, 1.0)
 	}
 
 	return registry
@@ -17,10 +17,10 @@ func TestDatadog(t *testing.T) {
 	// This is needed to make sure that UDP Listener listens for data a bit longer, otherwise it will quit after a millisecond
 	udp.Timeout = 5 * time.Second
 
-	datadogRegistry := RegisterDatadog(context.Background(), &types.Datadog{Address: ":18125", PushInterval: ptypes.Duration(time.Second), AddEntryPointsLabels: true, AddServicesLabels: true})
+	datadogRegistry := RegisterDatadog(context.Background(), &types.Datadog{Address: ":18125", PushInterval: ptypes.Duration(time.Second), AddEntryPointsLabels: true, AddRoutersLabels: true, AddServicesLabels: true})
 	defer StopDatadog()
 
-	if !datadogRegistry.IsEpEnabled() || !datadogRegistry.IsSvcEnabled() {
+	if !datadogRegistry.IsEpEnabled() || !datadogRegistry.IsRouterEnabled() || !datadogRegistry.IsSvcEnabled() {
 		t.Errorf("DatadogRegistry should return true for IsEnabled()")
 	}
 
@@ -35,8 +35,18 @@ func TestDatadog(t *testing.T) {
 		"traefik.entrypoint.request.total:1.000000|c|#entrypoint:test\n",
 		"traefik.entrypoint.request.duration:10000.000000|h|#entrypoint:test\n",
 		"traefik.entrypoint.connections.open:1.000000|g|#entrypoint:test\n",
+		"traefik.router.request.total:1.000000|c|#router:demo,service:test,code:404,method:GET\n",
+		"traefik.router.request.total:1.000000|c|#router:demo,service:test,code:200,method:GET\n",
+		"traefik.router.request.tls.total:1.000000|c|#router:demo,service:test,tls_version:foo,tls_cipher:bar\n",
+		"traefik.router.request.duration:10000.000000|h|#router:demo,service:test,code:200\n",
+		"traefik.router.connections.open:1.000000|g|#router:demo,service:test\n",
 		"traefik.service.server.up:1.000000|g|#service:test,url:http://127.0.0.1,one:two\n",
 		"traefik.tls.certs.notAfterTimestamp:1.000000|g|#key:value\n",
+		"traefik.entrypoint.connections.total:1.000000|c|#entrypoint:test,protocol:tcp\n",
+		"traefik.entrypoint.bytes.received:512.000000|c|#entrypoint:test,protocol:tcp\n",
+		"traefik.router.connections.active:1.000000|g|#router:demo,service:test,protocol:udp\n",
+		"traefik.service.bytes.sent:256.000000|c|#service:test,protocol:tcp\n",
+		"traefik.service.dial.failures.total:1.000000|c|#service:test,protocol:tcp\n",
 	}
 
 	udp.ShouldReceiveAll(t, expected, func() {
@@ -50,7 +60,17 @@ func TestDatadog(t *testing.T) {
 		datadogRegistry.EntryPointReqsCounter().With("entrypoint", "test").Add(1)
 		datadogRegistry.EntryPointReqDurationHistogram().With("entrypoint", "test").Observe(10000)
 		datadogRegistry.EntryPointOpenConnsGauge().With("entrypoint", "test").Set(1)
+		datadogRegistry.RouterReqsCounter().With("router", "demo", "service", "test", "code", strconv.Itoa(http.StatusNotFound), "method", http.MethodGet).Add(1)
+		datadogRegistry.RouterReqsCounter().With("router", "demo", "service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
+		datadogRegistry.RouterReqsTLSCounter().With("router", "demo", "service", "test", "tls_version", "foo", "tls_cipher", "bar").Add(1)
+		datadogRegistry.RouterReqDurationHistogram().With("router", "demo", "service", "test", "code", strconv.Itoa(http.StatusOK)).Observe(10000)
+		datadogRegistry.RouterOpenConnsGauge().With("router", "demo", "service", "test").Set(1)
 		datadogRegistry.ServiceServerUpGauge().With("service", "test", "url", "http://127.0.0.1", "one", "two").Set(1)
 		datadogRegistry.TLSCertsNotAfterTimestampGauge().With("key", "value").Set(1)
+		datadogRegistry.EntryPointConnsCounter().With("entrypoint", "test", "protocol", "tcp").Add(1)
+		datadogRegistry.EntryPointBytesReceivedCounter().With("entrypoint", "test", "protocol", "tcp").Add(512)
+		datadogRegistry.RouterActiveConnsGauge().With("router", "demo", "service", "test", "protocol", "udp").Set(1)
+		datadogRegistry.ServiceBytesSentCounter().With("service", "test", "protocol", "tcp").Add(256)
+		datadogRegistry.ServiceDialFailuresCounter().With("service", "test", "protocol", "tcp").Add(1)
 	})
 }
@@ -36,9 +36,30 @@ const (
 	influxDBEntryPointReqsName            = "traefik.entrypoint.requests.total"
 	influxDBEntryPointReqDurationName     = "traefik.entrypoint.request.duration"
 	influxDBEntryPointOpenConnsName       = "traefik.entrypoint.connections.open"
+	influxDBRouterReqsName                = "traefik.router.requests.total"
+	influxDBRouterReqsTLSName             = "traefik.router.requests.tls.total"
+	influxDBRouterReqDurationName         = "traefik.router.request.duration"
+	influxDBRouterOpenConnsName           = "traefik.router.connections.open"
 	influxDBOpenConnsName                 = "traefik.service.connections.open"
 	influxDBServerUpName                  = "traefik.service.server.up"
 	influxDBTLSCertsNotAfterTimestampName = "traefik.tls.certs.notAfterTimestamp"
+	influxDBAccessLogDroppedLinesName     = "traefik.accesslog.dropped.lines.total"
+	influxDBEntryPointConnsName           = "traefik.entrypoint.connections.total"
+	influxDBEntryPointActiveConnsName     = "traefik.entrypoint.connections.active"
+	influxDBEntryPointConnDurationName    = "traefik.entrypoint.connection.duration"
+	influxDBEntryPointBytesReceivedName   = "traefik.entrypoint.bytes.received"
+	influxDBEntryPointBytesSentName       = "traefik.entrypoint.bytes.sent"
+	influxDBRouterConnsName               = "traefik.router.connections.total"
+	influxDBRouterActiveConnsName         = "traefik.router.connections.active"
+	influxDBRouterConnDurationName        = "traefik.router.connection.duration"
+	influxDBRouterBytesReceivedName       = "traefik.router.bytes.received"
+	influxDBRouterBytesSentName           = "traefik.router.bytes.sent"
+	influxDBServiceConnsName              = "traefik.service.connections.total"
+	influxDBServiceActiveConnsName        = "traefik.service.connections.active"
+	influxDBServiceConnDurationName       = "traefik.service.connection.duration"
+	influxDBServiceBytesReceivedName      = "traefik.service.bytes.received"
+	influxDBServiceBytesSentName          = "traefik.service.bytes.sent"
+	influxDBServiceDialFailuresName       = "traefik.service.dial.failures.total"
 )
 
 const (
@@ -61,6 +82,7 @@ func RegisterInfluxDB(ctx context.Context, config *types.InfluxDB) Registry {
 		lastConfigReloadSuccessGauge:   influxDBClient.NewGauge(influxDBLastConfigReloadSuccessName),
 		lastConfigReloadFailureGauge:   influxDBClient.NewGauge(influxDBLastConfigReloadFailureName),
 		tlsCertsNotAfterTimestampGauge: influxDBClient.NewGauge(influxDBTLSCertsNotAfterTimestampName),
+		accessLogDroppedLinesCounter:   influxDBClient.NewCounter(influxDBAccessLogDroppedLinesName),
 	}
 
 	if config.AddEntryPointsLabels {
@@ -68,6 +90,24 @@ func RegisterInfluxDB(ctx context.Context, config *types.InfluxDB) Registry {
 		registry.entryPointReqsCounter = influxDBClient.NewCounter(influxDBEntryPointReqsName)
 		registry.entryPointReqDurationHistogram, _ = NewHistogramWithScale(influxDBClient.NewHistogram(influxDBEntryPointReqDurationName), time.Second)
 		registry.entryPointOpenConnsGauge = influxDBClient.NewGauge(influxDBEntryPointOpenConnsName)
+		registry.entryPointConnsCounter = influxDBClient.NewCounter(influxDBEntryPointConnsName)
+		registry.entryPointActiveConnsGauge = influxDBClient.NewGauge(influxDBEntryPointActiveConnsName)
+		registry.entryPointConnDurationHistogram, _ = NewHistogramWithScale(influxDBClient.NewHistogram(influxDBEntryPointConnDurationName), time.Second)
+		registry.entryPointBytesReceivedCounter = influxDBClient.NewCounter(influxDBEntryPointBytesReceivedName)
+		registry.entryPointBytesSentCounter = influxDBClient.NewCounter(influxDBEntryPointBytesSentName)
+	}
+
+	if config.AddRoutersLabels {
+		registry.routerEnabled = config.AddRoutersLabels
+		registry.routerReqsCounter = influxDBClient.NewCounter(influxDBRouterReqsName)
+		registry.routerReqsTLSCounter = influxDBClient.NewCounter(influxDBRouterReqsTLSName)
+		registry.routerReqDurationHistogram, _ = NewHistogramWithScale(influxDBClient.NewHistogram(influxDBRouterReqDurationName), time.Second)
+		registry.routerOpenConnsGauge = influxDBClient.NewGauge(influxDBRouterOpenConnsName)
+		registry.routerConnsCounter = influxDBClient.NewCounter(influxDBRouterConnsName)
+		registry.routerActiveConnsGauge = influxDBClient.NewGauge(influxDBRouterActiveConnsName)
+		registry.routerConnDurationHistogram, _ = NewHistogramWithScale(influxDBClient.NewHistogram(influxDBRouterConnDurationName), time.Second)
+		registry.routerBytesReceivedCounter = influxDBClient.NewCounter(influxDBRouterBytesReceivedName)
+		registry.routerBytesSentCounter = influxDBClient.NewCounter(influxDBRouterBytesSentName)
 	}
 
 	if config.AddServicesLabels {
@@ -77,6 +117,12 @@ func RegisterInfluxDB(ctx context.Context, config *types.InfluxDB) Registry {
 		registry.serviceRetriesCounter = influxDBClient.NewCounter(influxDBRetriesTotalName)
 		registry.serviceOpenConnsGauge = influxDBClient.NewGauge(influxDBOpenConnsName)
 		registry.serviceServerUpGauge = influxDBClient.NewGauge(influxDBServerUpName)
+		registry.serviceConnsCounter = influxDBClient.NewCounter(influxDBServiceConnsName)
+		registry.serviceActiveConnsGauge = influxDBClient.NewGauge(influxDBServiceActiveConnsName)
+		registry.serviceConnDurationHistogram, _ = NewHistogramWithScale(influxDBClient.NewHistogram(influxDBServiceConnDurationName), time.Second)
+		registry.serviceBytesReceivedCounter = influxDBClient.NewCounter(influxDBServiceBytesReceivedName)
+		registry.serviceBytesSentCounter = influxDBClient.NewCounter(influxDBServiceBytesSentName)
+		registry.serviceDialFailuresCounter = influxDBClient.NewCounter(influxDBServiceDialFailuresName)
 	}
 
 	return registry
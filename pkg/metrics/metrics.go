@@ -12,6 +12,8 @@ import (
 type Registry interface {
 	// IsEpEnabled shows whether metrics instrumentation is enabled on entry points.
 	IsEpEnabled() bool
+	// IsRouterEnabled shows whether metrics instrumentation is enabled on routers.
+	IsRouterEnabled() bool
 	// IsSvcEnabled shows whether metrics instrumentation is enabled on services.
 	IsSvcEnabled() bool
 
@@ -24,12 +26,21 @@ type Registry interface {
 	// TLS
 	TLSCertsNotAfterTimestampGauge() metrics.Gauge
 
+	// access log metrics
+	AccessLogDroppedLinesCounter() metrics.Counter
+
 	// entry point metrics
 	EntryPointReqsCounter() metrics.Counter
 	EntryPointReqsTLSCounter() metrics.Counter
 	EntryPointReqDurationHistogram() ScalableHistogram
 	EntryPointOpenConnsGauge() metrics.Gauge
 
+	// router metrics
+	RouterReqsCounter() metrics.Counter
+	RouterReqsTLSCounter() metrics.Counter
+	RouterReqDurationHistogram() ScalableHistogram
+	RouterOpenConnsGauge() metrics.Gauge
+
 	// service metrics
 	ServiceReqsCounter() metrics.Counter
 	ServiceReqsTLSCounter() metrics.Counter
@@ -37,6 +48,28 @@ type Registry interface {
 	ServiceOpenConnsGauge() metrics.Gauge
 	ServiceRetriesCounter() metrics.Counter
 	ServiceServerUpGauge() metrics.Gauge
+
+	// TCP and UDP entry point metrics
+	EntryPointConnsCounter() metrics.Counter
+	EntryPointActiveConnsGauge() metrics.Gauge
+	EntryPointConnDurationHistogram() ScalableHistogram
+	EntryPointBytesReceivedCounter() metrics.Counter
+	EntryPointBytesSentCounter() metrics.Counter
+
+	// TCP and UDP router metrics
+	RouterConnsCounter() metrics.Counter
+	RouterActiveConnsGauge() metrics.Gauge
+	RouterConnDurationHistogram() ScalableHistogram
+	RouterBytesReceivedCounter() metrics.Counter
+	RouterBytesSentCounter() metrics.Counter
+
+	// TCP and UDP service metrics
+	ServiceConnsCounter() metrics.Counter
+	ServiceActiveConnsGauge() metrics.Gauge
+	ServiceConnDurationHistogram() ScalableHistogram
+	ServiceBytesReceivedCounter() metrics.Counter
+	ServiceBytesSentCounter() metrics.Counter
+	ServiceDialFailuresCounter() metrics.Counter
 }
 
 // NewVoidRegistry is a noop implementation of metrics.Registry.
@@ -54,16 +87,37 @@ func NewMultiRegistry(registries []Registry) Registry {
 	var lastConfigReloadSuccessGauge []metrics.Gauge
 	var lastConfigReloadFailureGauge []metrics.Gauge
 	var tlsCertsNotAfterTimestampGauge []metrics.Gauge
+	var accessLogDroppedLinesCounter []metrics.Counter
 	var entryPointReqsCounter []metrics.Counter
 	var entryPointReqsTLSCounter []metrics.Counter
 	var entryPointReqDurationHistogram []ScalableHistogram
 	var entryPointOpenConnsGauge []metrics.Gauge
+	var routerReqsCounter []metrics.Counter
+	var routerReqsTLSCounter []metrics.Counter
+	var routerReqDurationHistogram []ScalableHistogram
+	var routerOpenConnsGauge []metrics.Gauge
 	var serviceReqsCounter []metrics.Counter
 	var serviceReqsTLSCounter []metrics.Counter
 	var serviceReqDurationHistogram []ScalableHistogram
 	var serviceOpenConnsGauge []metrics.Gauge
 	var serviceRetriesCounter []metrics.Counter
 	var serviceServerUpGauge []metrics.Gauge
+	var entryPointConnsCounter []metrics.Counter
+	var entryPointActiveConnsGauge []metrics.Gauge
+	var entryPointConnDurationHistogram []ScalableHistogram
+	var entryPointBytesReceivedCounter []metrics.Counter
+	var entryPointBytesSentCounter []metrics.Counter
+	var routerConnsCounter []metrics.Counter
+	var routerActiveConnsGauge []metrics.Gauge
+	var routerConnDurationHistogram []ScalableHistogram
+	var routerBytesReceivedCounter []metrics.Counter
+	var routerBytesSentCounter []metrics.Counter
+	var serviceConnsCounter []metrics.Counter
+	var serviceActiveConnsGauge []metrics.Gauge
+	var serviceConnDurationHistogram []ScalableHistogram
+	var serviceBytesReceivedCounter []metrics.Counter
+	var serviceBytesSentCounter []metrics.Counter
+	var serviceDialFailuresCounter []metrics.Counter
 
 	for _, r := range registries {
 		if r.ConfigReloadsCounter() != nil {
@@ -81,6 +135,9 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.TLSCertsNotAfterTimestampGauge() != nil {
 			tlsCertsNotAfterTimestampGauge = append(tlsCertsNotAfterTimestampGauge, r.TLSCertsNotAfterTimestampGauge())
 		}
+		if r.AccessLogDroppedLinesCounter() != nil {
+			accessLogDroppedLinesCounter = append(accessLogDroppedLinesCounter, r.AccessLogDroppedLinesCounter())
+		}
 		if r.EntryPointReqsCounter() != nil {
 			entryPointReqsCounter = append(entryPointReqsCounter, r.EntryPointReqsCounter())
 		}
@@ -93,6 +150,18 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.EntryPointOpenConnsGauge() != nil {
 			entryPointOpenConnsGauge = append(entryPointOpenConnsGauge, r.EntryPointOpenConnsGauge())
 		}
+		if r.RouterReqsCounter() != nil {
+			routerReqsCounter = append(routerReqsCounter, r.RouterReqsCounter())
+		}
+		if r.RouterReqsTLSCounter() != nil {
+			routerReqsTLSCounter = append(routerReqsTLSCounter, r.RouterReqsTLSCounter())
+		}
+		if r.RouterReqDurationHistogram() != nil {
+			routerReqDurationHistogram = append(routerReqDurationHistogram, r.RouterReqDurationHistogram())
+		}
+		if r.RouterOpenConnsGauge() != nil {
+			routerOpenConnsGauge = append(routerOpenConnsGauge, r.RouterOpenConnsGauge())
+		}
 		if r.ServiceReqsCounter() != nil {
 			serviceReqsCounter = append(serviceReqsCounter, r.ServiceReqsCounter())
 		}
@@ -111,53 +180,149 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.ServiceServerUpGauge() != nil {
 			serviceServerUpGauge = append(serviceServerUpGauge, r.ServiceServerUpGauge())
 		}
+		if r.EntryPointConnsCounter() != nil {
+			entryPointConnsCounter = append(entryPointConnsCounter, r.EntryPointConnsCounter())
+		}
+		if r.EntryPointActiveConnsGauge() != nil {
+			entryPointActiveConnsGauge = append(entryPointActiveConnsGauge, r.EntryPointActiveConnsGauge())
+		}
+		if r.EntryPointConnDurationHistogram() != nil {
+			entryPointConnDurationHistogram = append(entryPointConnDurationHistogram, r.EntryPointConnDurationHistogram())
+		}
+		if r.EntryPointBytesReceivedCounter() != nil {
+			entryPointBytesReceivedCounter = append(entryPointBytesReceivedCounter, r.EntryPointBytesReceivedCounter())
+		}
+		if r.EntryPointBytesSentCounter() != nil {
+			entryPointBytesSentCounter = append(entryPointBytesSentCounter, r.EntryPointBytesSentCounter())
+		}
+		if r.RouterConnsCounter() != nil {
+			routerConnsCounter = append(routerConnsCounter, r.RouterConnsCounter())
+		}
+		if r.RouterActiveConnsGauge() != nil {
+			routerActiveConnsGauge = append(routerActiveConnsGauge, r.RouterActiveConnsGauge())
+		}
+		if r.RouterConnDurationHistogram() != nil {
+			routerConnDurationHistogram = append(routerConnDurationHistogram, r.RouterConnDurationHistogram())
+		}
+		if r.RouterBytesReceivedCounter() != nil {
+			routerBytesReceivedCounter = append(routerBytesReceivedCounter, r.RouterBytesReceivedCounter())
+		}
+		if r.RouterBytesSentCounter() != nil {
+			routerBytesSentCounter = append(routerBytesSentCounter, r.RouterBytesSentCounter())
+		}
+		if r.ServiceConnsCounter() != nil {
+			serviceConnsCounter = append(serviceConnsCounter, r.ServiceConnsCounter())
+		}
+		if r.ServiceActiveConnsGauge() != nil {
+			serviceActiveConnsGauge = append(serviceActiveConnsGauge, r.ServiceActiveConnsGauge())
+		}
+		if r.ServiceConnDurationHistogram() != nil {
+			serviceConnDurationHistogram = append(serviceConnDurationHistogram, r.ServiceConnDurationHistogram())
+		}
+		if r.ServiceBytesReceivedCounter() != nil {
+			serviceBytesReceivedCounter = append(serviceBytesReceivedCounter, r.ServiceBytesReceivedCounter())
+		}
+		if r.ServiceBytesSentCounter() != nil {
+			serviceBytesSentCounter = append(serviceBytesSentCounter, r.ServiceBytesSentCounter())
+		}
+		if r.ServiceDialFailuresCounter() != nil {
+			serviceDialFailuresCounter = append(serviceDialFailuresCounter, r.ServiceDialFailuresCounter())
+		}
 	}
 
 	return &standardRegistry{
-		epEnabled:                      len(entryPointReqsCounter) > 0 || len(entryPointReqDurationHistogram) > 0 || len(entryPointOpenConnsGauge) > 0,
-		svcEnabled:                     len(serviceReqsCounter) > 0 || len(serviceReqDurationHistogram) > 0 || len(serviceOpenConnsGauge) > 0 || len(serviceRetriesCounter) > 0 || len(serviceServerUpGauge) > 0,
-		configReloadsCounter:           multi.NewCounter(configReloadsCounter...),
-		configReloadsFailureCounter:    multi.NewCounter(configReloadsFailureCounter...),
-		lastConfigReloadSuccessGauge:   multi.NewGauge(lastConfigReloadSuccessGauge...),
-		lastConfigReloadFailureGauge:   multi.NewGauge(lastConfigReloadFailureGauge...),
-		tlsCertsNotAfterTimestampGauge: multi.NewGauge(tlsCertsNotAfterTimestampGauge...),
-		entryPointReqsCounter:          multi.NewCounter(entryPointReqsCounter...),
-		entryPointReqsTLSCounter:       multi.NewCounter(entryPointReqsTLSCounter...),
-		entryPointReqDurationHistogram: NewMultiHistogram(entryPointReqDurationHistogram...),
-		entryPointOpenConnsGauge:       multi.NewGauge(entryPointOpenConnsGauge...),
-		serviceReqsCounter:             multi.NewCounter(serviceReqsCounter...),
-		serviceReqsTLSCounter:          multi.NewCounter(serviceReqsTLSCounter...),
-		serviceReqDurationHistogram:    NewMultiHistogram(serviceReqDurationHistogram...),
-		serviceOpenConnsGauge:          multi.NewGauge(serviceOpenConnsGauge...),
-		serviceRetriesCounter:          multi.NewCounter(serviceRetriesCounter...),
-		serviceServerUpGauge:           multi.NewGauge(serviceServerUpGauge...),
+		epEnabled:                       len(entryPointReqsCounter) > 0 || len(entryPointReqDurationHistogram) > 0 || len(entryPointOpenConnsGauge) > 0,
+		routerEnabled:                   len(routerReqsCounter) > 0 || len(routerReqDurationHistogram) > 0 || len(routerOpenConnsGauge) > 0,
+		svcEnabled:                      len(serviceReqsCounter) > 0 || len(serviceReqDurationHistogram) > 0 || len(serviceOpenConnsGauge) > 0 || len(serviceRetriesCounter) > 0 || len(serviceServerUpGauge) > 0,
+		configReloadsCounter:            multi.NewCounter(configReloadsCounter...),
+		configReloadsFailureCounter:     multi.NewCounter(configReloadsFailureCounter...),
+		lastConfigReloadSuccessGauge:    multi.NewGauge(lastConfigReloadSuccessGauge...),
+		lastConfigReloadFailureGauge:    multi.NewGauge(lastConfigReloadFailureGauge...),
+		tlsCertsNotAfterTimestampGauge:  multi.NewGauge(tlsCertsNotAfterTimestampGauge...),
+		accessLogDroppedLinesCounter:    multi.NewCounter(accessLogDroppedLinesCounter...),
+		entryPointReqsCounter:           multi.NewCounter(entryPointReqsCounter...),
+		entryPointReqsTLSCounter:        multi.NewCounter(entryPointReqsTLSCounter...),
+		entryPointReqDurationHistogram:  NewMultiHistogram(entryPointReqDurationHistogram...),
+		entryPointOpenConnsGauge:        multi.NewGauge(entryPointOpenConnsGauge...),
+		routerReqsCounter:               multi.NewCounter(routerReqsCounter...),
+		routerReqsTLSCounter:            multi.NewCounter(routerReqsTLSCounter...),
+		routerReqDurationHistogram:      NewMultiHistogram(routerReqDurationHistogram...),
+		routerOpenConnsGauge:            multi.NewGauge(routerOpenConnsGauge...),
+		serviceReqsCounter:              multi.NewCounter(serviceReqsCounter...),
+		serviceReqsTLSCounter:           multi.NewCounter(serviceReqsTLSCounter...),
+		serviceReqDurationHistogram:     NewMultiHistogram(serviceReqDurationHistogram...),
+		serviceOpenConnsGauge:           multi.NewGauge(serviceOpenConnsGauge...),
+		serviceRetriesCounter:           multi.NewCounter(serviceRetriesCounter...),
+		serviceServerUpGauge:            multi.NewGauge(serviceServerUpGauge...),
+		entryPointConnsCounter:          multi.NewCounter(entryPointConnsCounter...),
+		entryPointActiveConnsGauge:      multi.NewGauge(entryPointActiveConnsGauge...),
+		entryPointConnDurationHistogram: NewMultiHistogram(entryPointConnDurationHistogram...),
+		entryPointBytesReceivedCounter:  multi.NewCounter(entryPointBytesReceivedCounter...),
+		entryPointBytesSentCounter:      multi.NewCounter(entryPointBytesSentCounter...),
+		routerConnsCounter:              multi.NewCounter(routerConnsCounter...),
+		routerActiveConnsGauge:          multi.NewGauge(routerActiveConnsGauge...),
+		routerConnDurationHistogram:     NewMultiHistogram(routerConnDurationHistogram...),
+		routerBytesReceivedCounter:      multi.NewCounter(routerBytesReceivedCounter...),
+		routerBytesSentCounter:          multi.NewCounter(routerBytesSentCounter...),
+		serviceConnsCounter:             multi.NewCounter(serviceConnsCounter...),
+		serviceActiveConnsGauge:         multi.NewGauge(serviceActiveConnsGauge...),
+		serviceConnDurationHistogram:    NewMultiHistogram(serviceConnDurationHistogram...),
+		serviceBytesReceivedCounter:     multi.NewCounter(serviceBytesReceivedCounter...),
+		serviceBytesSentCounter:         multi.NewCounter(serviceBytesSentCounter...),
+		serviceDialFailuresCounter:      multi.NewCounter(serviceDialFailuresCounter...),
 	}
 }
 
 type standardRegistry struct {
-	epEnabled                      bool
-	svcEnabled                     bool
-	configReloadsCounter           metrics.Counter
-	configReloadsFailureCounter    metrics.Counter
-	lastConfigReloadSuccessGauge   metrics.Gauge
-	lastConfigReloadFailureGauge   metrics.Gauge
-	tlsCertsNotAfterTimestampGauge metrics.Gauge
-	entryPointReqsCounter          metrics.Counter
-	entryPointReqsTLSCounter       metrics.Counter
-	entryPointReqDurationHistogram ScalableHistogram
-	entryPointOpenConnsGauge       metrics.Gauge
-	serviceReqsCounter             metrics.Counter
-	serviceReqsTLSCounter          metrics.Counter
-	serviceReqDurationHistogram    ScalableHistogram
-	serviceOpenConnsGauge          metrics.Gauge
-	serviceRetriesCounter          metrics.Counter
-	serviceServerUpGauge           metrics.Gauge
+	epEnabled                       bool
+	routerEnabled                   bool
+	svcEnabled                      bool
+	configReloadsCounter            metrics.Counter
+	configReloadsFailureCounter     metrics.Counter
+	lastConfigReloadSuccessGauge    metrics.Gauge
+	lastConfigReloadFailureGauge    metrics.Gauge
+	tlsCertsNotAfterTimestampGauge  metrics.Gauge
+	accessLogDroppedLinesCounter    metrics.Counter
+	entryPointReqsCounter           metrics.Counter
+	entryPointReqsTLSCounter        metrics.Counter
+	entryPointReqDurationHistogram  ScalableHistogram
+	entryPointOpenConnsGauge        metrics.Gauge
+	routerReqsCounter               metrics.Counter
+	routerReqsTLSCounter            metrics.Counter
+	routerReqDurationHistogram      ScalableHistogram
+	routerOpenConnsGauge            metrics.Gauge
+	serviceReqsCounter              metrics.Counter
+	serviceReqsTLSCounter           metrics.Counter
+	serviceReqDurationHistogram     ScalableHistogram
+	serviceOpenConnsGauge           metrics.Gauge
+	serviceRetriesCounter           metrics.Counter
+	serviceServerUpGauge            metrics.Gauge
+	entryPointConnsCounter          metrics.Counter
+	entryPointActiveConnsGauge      metrics.Gauge
+	entryPointConnDurationHistogram ScalableHistogram
+	entryPointBytesReceivedCounter  metrics.Counter
+	entryPointBytesSentCounter      metrics.Counter
+	routerConnsCounter              metrics.Counter
+	routerActiveConnsGauge          metrics.Gauge
+	routerConnDurationHistogram     ScalableHistogram
+	routerBytesReceivedCounter      metrics.Counter
+	routerBytesSentCounter          metrics.Counter
+	serviceConnsCounter             metrics.Counter
+	serviceActiveConnsGauge         metrics.Gauge
+	serviceConnDurationHistogram    ScalableHistogram
+	serviceBytesReceivedCounter     metrics.Counter
+	serviceBytesSentCounter         metrics.Counter
+	serviceDialFailuresCounter      metrics.Counter
 }
 
 func (r *standardRegistry) IsEpEnabled() bool {
 	return r.epEnabled
 }
 
+func (r *standardRegistry) IsRouterEnabled() bool {
+	return r.routerEnabled
+}
+
 func (r *standardRegistry) IsSvcEnabled() bool {
 	return r.svcEnabled
 }
@@ -182,6 +347,10 @@ func (r *standardRegistry) TLSCertsNotAfterTimestampGauge() metrics.Gauge {
 	return r.tlsCertsNotAfterTimestampGauge
 }
 
+func (r *standardRegistry) AccessLogDroppedLinesCounter() metrics.Counter {
+	return r.accessLogDroppedLinesCounter
+}
+
 func (r *standardRegistry) EntryPointReqsCounter() metrics.Counter {
 	return r.entryPointReqsCounter
 }
@@ -198,6 +367,22 @@ func (r *standardRegistry) EntryPointOpenConnsGauge() metrics.Gauge {
 	return r.entryPointOpenConnsGauge
 }
 
+func (r *standardRegistry) RouterReqsCounter() metrics.Counter {
+	return r.routerReqsCounter
+}
+
+func (r *standardRegistry) RouterReqsTLSCounter() metrics.Counter {
+	return r.routerReqsTLSCounter
+}
+
+func (r *standardRegistry) RouterReqDurationHistogram() ScalableHistogram {
+	return r.routerReqDurationHistogram
+}
+
+func (r *standardRegistry) RouterOpenConnsGauge() metrics.Gauge {
+	return r.routerOpenConnsGauge
+}
+
 func (r *standardRegistry) ServiceReqsCounter() metrics.Counter {
 	return r.serviceReqsCounter
 }
@@ -222,6 +407,70 @@ func (r *standardRegistry) ServiceServerUpGauge() metrics.Gauge {
 	return r.serviceServerUpGauge
 }
 
+func (r *standardRegistry) EntryPointConnsCounter() metrics.Counter {
+	return r.entryPointConnsCounter
+}
+
+func (r *standardRegistry) EntryPointActiveConnsGauge() metrics.Gauge {
+	return r.entryPointActiveConnsGauge
+}
+
+func (r *standardRegistry) EntryPointConnDurationHistogram() ScalableHistogram {
+	return r.entryPointConnDurationHistogram
+}
+
+func (r *standardRegistry) EntryPointBytesReceivedCounter() metrics.Counter {
+	return r.entryPointBytesReceivedCounter
+}
+
+func (r *standardRegistry) EntryPointBytesSentCounter() metrics.Counter {
+	return r.entryPointBytesSentCounter
+}
+
+func (r *standardRegistry) RouterConnsCounter() metrics.Counter {
+	return r.routerConnsCounter
+}
+
+func (r *standardRegistry) RouterActiveConnsGauge() metrics.Gauge {
+	return r.routerActiveConnsGauge
+}
+
+func (r *standardRegistry) RouterConnDurationHistogram() ScalableHistogram {
+	return r.routerConnDurationHistogram
+}
+
+func (r *standardRegistry) RouterBytesReceivedCounter() metrics.Counter {
+	return r.routerBytesReceivedCounter
+}
+
+func (r *standardRegistry) RouterBytesSentCounter() metrics.Counter {
+	return r.routerBytesSentCounter
+}
+
+func (r *standardRegistry) ServiceConnsCounter() metrics.Counter {
+	return r.serviceConnsCounter
+}
+
+func (r *standardRegistry) ServiceActiveConnsGauge() metrics.Gauge {
+	return r.serviceActiveConnsGauge
+}
+
+func (r *standardRegistry) ServiceConnDurationHistogram() ScalableHistogram {
+	return r.serviceConnDurationHistogram
+}
+
+func (r *standardRegistry) ServiceBytesReceivedCounter() metrics.Counter {
+	return r.serviceBytesReceivedCounter
+}
+
+func (r *standardRegistry) ServiceBytesSentCounter() metrics.Counter {
+	return r.serviceBytesSentCounter
+}
+
+func (r *standardRegistry) ServiceDialFailuresCounter() metrics.Counter {
+	return r.serviceDialFailuresCounter
+}
+
 // ScalableHistogram is a Histogram with a predefined time unit,
 // used when producing observations without explicitly setting the observed value.
 type ScalableHistogram interface {
@@ -0,0 +1,613 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/otlp"
+	"github.com/traefik/traefik/v2/pkg/safe"
+	"github.com/traefik/traefik/v2/pkg/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var (
+	openTelemetryMeter  *otelMeter
+	openTelemetryTicker *time.Ticker
+	openTelemetryCancel context.CancelFunc
+)
+
+// The OTLP aggregation temporalities.
+const (
+	otelTemporalityDelta      = 1
+	otelTemporalityCumulative = 2
+)
+
+const otelExportTimeout = 10 * time.Second
+
+// RegisterOpenTelemetry registers the metrics pusher if this didn't happen yet and creates an OpenTelemetry Registry instance.
+func RegisterOpenTelemetry(ctx context.Context, config *types.OpenTelemetry) Registry {
+	if openTelemetryMeter == nil {
+		var err error
+		if openTelemetryMeter, err = newOtelMeter(ctx, config); err != nil {
+			log.FromContext(ctx).Error(err)
+			return nil
+		}
+	}
+
+	if openTelemetryTicker == nil {
+		openTelemetryTicker, openTelemetryCancel = initOpenTelemetryTicker(ctx, openTelemetryMeter, config)
+	}
+
+	meter := openTelemetryMeter
+
+	registry := &standardRegistry{
+		configReloadsCounter:           meter.newCounter(configReloadsTotalName, "Config reloads"),
+		configReloadsFailureCounter:    meter.newCounter(configReloadsFailuresTotalName, "Config reload failures"),
+		lastConfigReloadSuccessGauge:   meter.newGauge(configLastReloadSuccessName, "Last config reload success"),
+		lastConfigReloadFailureGauge:   meter.newGauge(configLastReloadFailureName, "Last config reload failure"),
+		tlsCertsNotAfterTimestampGauge: meter.newGauge(tlsCertsNotAfterTimestamp, "Certificate expiration timestamp"),
+		accessLogDroppedLinesCounter:   meter.newCounter(accessLogDroppedLinesName, "Access log lines dropped by an output"),
+	}
+
+	if config.AddEntryPointsLabels {
+		registry.epEnabled = config.AddEntryPointsLabels
+		registry.entryPointReqsCounter = meter.newCounter(entryPointReqsTotalName,
+			"How many HTTP requests processed on an entrypoint, partitioned by status code, protocol, and method.")
+		registry.entryPointReqsTLSCounter = meter.newCounter(entryPointReqsTLSTotalName,
+			"How many HTTP requests with TLS processed on an entrypoint, partitioned by TLS Version and TLS cipher Used.")
+		registry.entryPointReqDurationHistogram, _ = NewHistogramWithScale(meter.newHistogram(entryPointReqDurationName,
+			"How long it took to process the request on an entrypoint, partitioned by status code, protocol, and method."), time.Second)
+		registry.entryPointOpenConnsGauge = meter.newGauge(entryPointOpenConnsName,
+			"How many open connections exist on an entrypoint, partitioned by method and protocol.")
+		registry.entryPointConnsCounter = meter.newCounter(entryPointConnsTotalName,
+			"How many TCP connections or UDP sessions are handled on an entrypoint, partitioned by protocol.")
+		registry.entryPointActiveConnsGauge = meter.newGauge(entryPointActiveConnsName,
+			"How many TCP connections or UDP sessions are currently active on an entrypoint, partitioned by protocol.")
+		registry.entryPointConnDurationHistogram, _ = NewHistogramWithScale(meter.newHistogram(entryPointConnDurationName,
+			"How long the TCP connections or UDP sessions lasted on an entrypoint, partitioned by protocol."), time.Second)
+		registry.entryPointBytesReceivedCounter = meter.newCounter(entryPointBytesReceivedName,
+			"How many bytes are received from the clients on an entrypoint, partitioned by protocol.")
+		registry.entryPointBytesSentCounter = meter.newCounter(entryPointBytesSentName,
+			"How many bytes are sent to the clients on an entrypoint, partitioned by protocol.")
+	}
+
+	if config.AddRoutersLabels {
+		registry.routerEnabled = config.AddRoutersLabels
+		registry.routerReqsCounter = meter.newCounter(routerReqsTotalName,
+			"How many HTTP requests are processed on a router, partitioned by service, status code, protocol, and method.")
+		registry.routerReqsTLSCounter = meter.newCounter(routerReqsTLSTotalName,
+			"How many HTTP requests with TLS are processed on a router, partitioned by service, TLS Version, and TLS cipher Used.")
+		registry.routerReqDurationHistogram, _ = NewHistogramWithScale(meter.newHistogram(routerReqDurationName,
+			"How long it took to process the request on a router, partitioned by service, status code, protocol, and method."), time.Second)
+		registry.routerOpenConnsGauge = meter.newGauge(routerOpenConnsName,
+			"How many open connections exist on a router, partitioned by service, method, and protocol.")
+		registry.routerConnsCounter = meter.newCounter(routerConnsTotalName,
+			"How many TCP connections or UDP sessions are handled on a router, partitioned by protocol.")
+		registry.routerActiveConnsGauge = meter.newGauge(routerActiveConnsName,
+			"How many TCP connections or UDP sessions are currently active on a router, partitioned by protocol.")
+		registry.routerConnDurationHistogram, _ = NewHistogramWithScale(meter.newHistogram(routerConnDurationName,
+			"How long the TCP connections or UDP sessions lasted on a router, partitioned by protocol."), time.Second)
+		registry.routerBytesReceivedCounter = meter.newCounter(routerBytesReceivedName,
+			"How many bytes are received from the clients on a router, partitioned by protocol.")
+		registry.routerBytesSentCounter = meter.newCounter(routerBytesSentName,
+			"How many bytes are sent to the clients on a router, partitioned by protocol.")
+	}
+
+	if config.AddServicesLabels {
+		registry.svcEnabled = config.AddServicesLabels
+		registry.serviceReqsCounter = meter.newCounter(serviceReqsTotalName,
+			"How many HTTP requests processed on a service, partitioned by status code, protocol, and method.")
+		registry.serviceReqsTLSCounter = meter.newCounter(serviceReqsTLSTotalName,
+			"How many HTTP requests with TLS processed on a service, partitioned by TLS version and TLS cipher.")
+		registry.serviceReqDurationHistogram, _ = NewHistogramWithScale(meter.newHistogram(serviceReqDurationName,
+			"How long it took to process the request on a service, partitioned by status code, protocol, and method."), time.Second)
+		registry.serviceOpenConnsGauge = meter.newGauge(serviceOpenConnsName,
+			"How many open connections exist on a service, partitioned by method and protocol.")
+		registry.serviceRetriesCounter = meter.newCounter(serviceRetriesTotalName,
+			"How many request retries happened on a service.")
+		registry.serviceServerUpGauge = meter.newGauge(serviceServerUpName,
+			"service server is up, described by gauge value of 0 or 1.")
+		registry.serviceConnsCounter = meter.newCounter(serviceConnsTotalName,
+			"How many TCP connections or UDP sessions are handled on a service, partitioned by protocol.")
+		registry.serviceActiveConnsGauge = meter.newGauge(serviceActiveConnsName,
+			"How many TCP connections or UDP sessions are currently active on a service, partitioned by protocol.")
+		registry.serviceConnDurationHistogram, _ = NewHistogramWithScale(meter.newHistogram(serviceConnDurationName,
+			"How long the TCP connections or UDP sessions lasted on a service, partitioned by protocol."), time.Second)
+		registry.serviceBytesReceivedCounter = meter.newCounter(serviceBytesReceivedName,
+			"How many bytes are received from the clients on a service, partitioned by protocol.")
+		registry.serviceBytesSentCounter = meter.newCounter(serviceBytesSentName,
+			"How many bytes are sent to the clients on a service, partitioned by protocol.")
+		registry.serviceDialFailuresCounter = meter.newCounter(serviceDialFailuresName,
+			"How many times a connection to a server of a TCP or UDP service failed, partitioned by protocol.")
+	}
+
+	return registry
+}
+
+// initOpenTelemetryTicker initializes the metrics pusher,
+// the returned function stopping it.
+func initOpenTelemetryTicker(ctx context.Context, meter *otelMeter, config *types.OpenTelemetry) (*time.Ticker, context.CancelFunc) {
+	report := time.NewTicker(time.Duration(config.PushInterval))
+	ctx, cancel := context.WithCancel(ctx)
+
+	safe.Go(func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-report.C:
+				meter.push(ctx)
+			}
+		}
+	})
+
+	return report, cancel
+}
+
+// StopOpenTelemetry stops the internal openTelemetryTicker which controls the pushing of metrics to the OpenTelemetry collector,
+// pushes the metrics one last time, and resets it to `nil`.
+func StopOpenTelemetry() {
+	if openTelemetryTicker != nil {
+		openTelemetryTicker.Stop()
+		openTelemetryCancel()
+	}
+	openTelemetryTicker = nil
+	openTelemetryCancel = nil
+
+	if openTelemetryMeter == nil {
+		return
+	}
+
+	ctx := log.With(context.Background(), log.Str(log.MetricsProviderName, "opentelemetry"))
+
+	openTelemetryMeter.push(ctx)
+	if err := openTelemetryMeter.exporter.Close(); err != nil {
+		log.FromContext(ctx).Errorf("Error while closing OpenTelemetry exporter: %v", err)
+	}
+	openTelemetryMeter = nil
+}
+
+// otelMeter aggregates the metrics in memory, and exports them with OTLP.
+type otelMeter struct {
+	exporter    otlp.Exporter
+	resource    []otlp.KeyValue
+	temporality int
+	boundaries  []float64
+
+	mu          sync.Mutex
+	instruments []*otelInstrument
+	// lastCollect is the start time of the current delta interval.
+	lastCollect time.Time
+	// dynamicConfig is the current configuration, used to remove the series belonging to an outdated one.
+	// It is nil until the first configuration is received.
+	dynamicConfig *dynamicConfig
+}
+
+func newOtelMeter(ctx context.Context, config *types.OpenTelemetry) (*otelMeter, error) {
+	var temporality int
+	switch config.Temporality {
+	case types.TemporalityCumulative, "":
+		temporality = otelTemporalityCumulative
+	case types.TemporalityDelta:
+		temporality = otelTemporalityDelta
+	default:
+		return nil, fmt.Errorf("unknown OpenTelemetry temporality: %s", config.Temporality)
+	}
+
+	boundaries := append([]float64(nil), config.ExplicitBoundaries...)
+	sort.Float64s(boundaries)
+
+	exporter, err := otlp.NewExporter(ctx, otlp.Endpoint{
+		Address:  config.Address,
+		Path:     config.Path,
+		Insecure: config.Insecure,
+		Headers:  config.Headers,
+		TLS:      config.TLS,
+		GRPC:     config.GRPC != nil,
+	}, otlp.MetricsGRPCMethod)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create OpenTelemetry exporter: %w", err)
+	}
+
+	return &otelMeter{
+		exporter:    exporter,
+		resource:    otlp.ResourceAttributes("traefik", config.ResourceAttributes),
+		temporality: temporality,
+		boundaries:  boundaries,
+		lastCollect: time.Now(),
+	}, nil
+}
+
+func (m *otelMeter) newCounter(name, description string) *otelCounter {
+	return &otelCounter{instrument: m.newInstrument(name, description, otelKindSum)}
+}
+
+func (m *otelMeter) newGauge(name, description string) *otelGauge {
+	return &otelGauge{instrument: m.newInstrument(name, description, otelKindGauge)}
+}
+
+func (m *otelMeter) newHistogram(name, description string) *otelHistogram {
+	return &otelHistogram{instrument: m.newInstrument(name, description, otelKindHistogram)}
+}
+
+// newInstrument returns the instrument with the given name, creating it if needed,
+// as the registry can be created more than once.
+func (m *otelMeter) newInstrument(name, description string, kind otelKind) *otelInstrument {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, instrument := range m.instruments {
+		if instrument.name == name {
+			return instrument
+		}
+	}
+
+	instrument := &otelInstrument{
+		name:        name,
+		description: description,
+		kind:        kind,
+		boundaries:  m.boundaries,
+		series:      make(map[string]*otelSeries),
+	}
+	m.instruments = append(m.instruments, instrument)
+
+	return instrument
+}
+
+// setDynamicConfig sets the current configuration,
+// the series referring to elements which are not part of it being removed once exported.
+func (m *otelMeter) setDynamicConfig(dynamicConfig *dynamicConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.dynamicConfig = dynamicConfig
+}
+
+func (m *otelMeter) push(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, otelExportTimeout)
+	defer cancel()
+
+	if err := m.exporter.Export(ctx, m.collect(time.Now())); err != nil {
+		log.FromContext(ctx).Errorf("Error while pushing metrics to OpenTelemetry collector: %v", err)
+	}
+}
+
+// collect encodes the current checkpoint as an OTLP ExportMetricsServiceRequest,
+// resetting the sums and histograms when using the delta temporality.
+// The series belonging to an outdated configuration are removed once exported, like with Prometheus.
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/collector/metrics/v1/metrics_service.proto
+func (m *otelMeter) collect(now time.Time) []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	scopeMetrics := otlp.AppendMessage(nil, 1, otlp.MarshalScope())
+	for _, instrument := range m.instruments {
+		if metric := instrument.collect(now, m.lastCollect, m.temporality); metric != nil {
+			scopeMetrics = otlp.AppendMessage(scopeMetrics, 2, metric)
+		}
+
+		if m.dynamicConfig != nil {
+			instrument.removeOutdated(m.dynamicConfig)
+		}
+	}
+
+	m.lastCollect = now
+
+	var resourceMetrics []byte
+	resourceMetrics = otlp.AppendMessage(resourceMetrics, 1, otlp.MarshalResource(m.resource))
+	resourceMetrics = otlp.AppendMessage(resourceMetrics, 2, scopeMetrics)
+
+	return otlp.AppendMessage(nil, 1, resourceMetrics)
+}
+
+type otelKind int
+
+const (
+	otelKindSum otelKind = iota
+	otelKindGauge
+	otelKindHistogram
+)
+
+// otelInstrument holds the time series of a metric.
+type otelInstrument struct {
+	name        string
+	description string
+	kind        otelKind
+	boundaries  []float64
+
+	mu     sync.Mutex
+	series map[string]*otelSeries
+}
+
+// otelSeries is the aggregation of a metric for a set of attributes.
+type otelSeries struct {
+	attributes []otlp.KeyValue
+	start      time.Time
+	updated    bool
+
+	// value is the sum of a counter, or the value of a gauge.
+	value float64
+
+	count   uint64
+	sum     float64
+	min     float64
+	max     float64
+	buckets []uint64
+}
+
+// update applies the function to the series of the label values.
+func (i *otelInstrument) update(labelValues []string, fn func(s *otelSeries)) {
+	key, attributes := otelAttributes(labelValues)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	s, ok := i.series[key]
+	if !ok {
+		s = &otelSeries{attributes: attributes, start: time.Now()}
+		if i.kind == otelKindHistogram {
+			s.buckets = make([]uint64, len(i.boundaries)+1)
+		}
+		i.series[key] = s
+	}
+
+	s.updated = true
+	fn(s)
+}
+
+// collect encodes the Metric message of the instrument,
+// nil being returned when no data point has to be reported.
+func (i *otelInstrument) collect(now, intervalStart time.Time, temporality int) []byte {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var dataPoints [][]byte
+
+	keys := make([]string, 0, len(i.series))
+	for key := range i.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := i.series[key]
+
+		if i.kind == otelKindGauge {
+			dataPoints = append(dataPoints, marshalNumberDataPoint(s, time.Time{}, now))
+			continue
+		}
+
+		start := s.start
+		if temporality == otelTemporalityDelta {
+			if !s.updated {
+				continue
+			}
+			if start.Before(intervalStart) {
+				start = intervalStart
+			}
+		}
+
+		if i.kind == otelKindHistogram {
+			dataPoints = append(dataPoints, marshalHistogramDataPoint(s, i.boundaries, start, now))
+		} else {
+			dataPoints = append(dataPoints, marshalNumberDataPoint(s, start, now))
+		}
+
+		if temporality == otelTemporalityDelta {
+			s.reset(now)
+		}
+	}
+
+	if len(dataPoints) == 0 {
+		return nil
+	}
+
+	var data []byte
+	for _, dp := range dataPoints {
+		data = otlp.AppendMessage(data, 1, dp)
+	}
+
+	var metric []byte
+	metric = otlp.AppendString(metric, 1, i.name)
+	metric = otlp.AppendString(metric, 2, i.description)
+
+	switch i.kind {
+	case otelKindGauge:
+		metric = otlp.AppendMessage(metric, 5, data)
+	case otelKindSum:
+		data = otlp.AppendVarint(data, 2, uint64(temporality))
+		data = otlp.AppendVarint(data, 3, protowire.EncodeBool(true))
+		metric = otlp.AppendMessage(metric, 7, data)
+	case otelKindHistogram:
+		data = otlp.AppendVarint(data, 2, uint64(temporality))
+		metric = otlp.AppendMessage(metric, 9, data)
+	}
+
+	return metric
+}
+
+// removeOutdated removes the series whose attributes refer to elements which are not part of the configuration.
+func (i *otelInstrument) removeOutdated(dynamicConfig *dynamicConfig) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for key, s := range i.series {
+		labels := make(map[string]string, len(s.attributes))
+		for _, attr := range s.attributes {
+			labels[attr.Key] = attr.Value.(string)
+		}
+
+		if dynamicConfig.isOutdated(labels) {
+			delete(i.series, key)
+		}
+	}
+}
+
+// reset starts a new delta interval.
+func (s *otelSeries) reset(now time.Time) {
+	s.start = now
+	s.updated = false
+	s.value = 0
+	s.count = 0
+	s.sum = 0
+	s.min = 0
+	s.max = 0
+	for i := range s.buckets {
+		s.buckets[i] = 0
+	}
+}
+
+func marshalNumberDataPoint(s *otelSeries, start, now time.Time) []byte {
+	var b []byte
+	if !start.IsZero() {
+		b = otlp.AppendFixed64(b, 2, uint64(start.UnixNano()))
+	}
+	b = otlp.AppendFixed64(b, 3, uint64(now.UnixNano()))
+	b = otlp.AppendDouble(b, 4, s.value)
+	return otlp.AppendAttributes(b, 7, s.attributes)
+}
+
+func marshalHistogramDataPoint(s *otelSeries, boundaries []float64, start, now time.Time) []byte {
+	var b []byte
+	b = otlp.AppendFixed64(b, 2, uint64(start.UnixNano()))
+	b = otlp.AppendFixed64(b, 3, uint64(now.UnixNano()))
+	b = otlp.AppendFixed64(b, 4, s.count)
+	b = otlp.AppendDouble(b, 5, s.sum)
+
+	var buckets []byte
+	for _, count := range s.buckets {
+		buckets = protowire.AppendFixed64(buckets, count)
+	}
+	b = otlp.AppendMessage(b, 6, buckets)
+
+	if len(boundaries) > 0 {
+		var bounds []byte
+		for _, bound := range boundaries {
+			bounds = protowire.AppendFixed64(bounds, math.Float64bits(bound))
+		}
+		b = otlp.AppendMessage(b, 7, bounds)
+	}
+
+	b = otlp.AppendAttributes(b, 9, s.attributes)
+
+	if s.count > 0 {
+		b = otlp.AppendDouble(b, 11, s.min)
+		b = otlp.AppendDouble(b, 12, s.max)
+	}
+
+	return b
+}
+
+// otelAttributes converts the go-kit label values to attributes sorted by key,
+// the returned key identifying the series.
+func otelAttributes(labelValues []string) (string, []otlp.KeyValue) {
+	if len(labelValues)%2 != 0 {
+		labelValues = append(labelValues, "unknown")
+	}
+
+	attributes := make([]otlp.KeyValue, 0, len(labelValues)/2)
+	for i := 0; i < len(labelValues); i += 2 {
+		attributes = append(attributes, otlp.KeyValue{Key: labelValues[i], Value: labelValues[i+1]})
+	}
+	sort.SliceStable(attributes, func(i, j int) bool {
+		return attributes[i].Key < attributes[j].Key
+	})
+
+	var key strings.Builder
+	for _, attr := range attributes {
+		key.WriteString(attr.Key)
+		key.WriteByte(0)
+		key.WriteString(attr.Value.(string))
+		key.WriteByte(0)
+	}
+
+	return key.String(), attributes
+}
+
+// otelCounter is a go-kit Counter backed by an OpenTelemetry sum.
+type otelCounter struct {
+	instrument  *otelInstrument
+	labelValues []string
+}
+
+// With returns a new counter with the label values applied.
+func (c *otelCounter) With(labelValues ...string) metrics.Counter {
+	return &otelCounter{
+		instrument:  c.instrument,
+		labelValues: append(append([]string(nil), c.labelValues...), labelValues...),
+	}
+}
+
+// Add adds the given delta to the counter.
+func (c *otelCounter) Add(delta float64) {
+	c.instrument.update(c.labelValues, func(s *otelSeries) {
+		s.value += delta
+	})
+}
+
+// otelGauge is a go-kit Gauge backed by an OpenTelemetry gauge.
+type otelGauge struct {
+	instrument  *otelInstrument
+	labelValues []string
+}
+
+// With returns a new gauge with the label values applied.
+func (g *otelGauge) With(labelValues ...string) metrics.Gauge {
+	return &otelGauge{
+		instrument:  g.instrument,
+		labelValues: append(append([]string(nil), g.labelValues...), labelValues...),
+	}
+}
+
+// Set sets the gauge value.
+func (g *otelGauge) Set(value float64) {
+	g.instrument.update(g.labelValues, func(s *otelSeries) {
+		s.value = value
+	})
+}
+
+// Add adds the given delta to the gauge value.
+func (g *otelGauge) Add(delta float64) {
+	g.instrument.update(g.labelValues, func(s *otelSeries) {
+		s.value += delta
+	})
+}
+
+// otelHistogram is a go-kit Histogram backed by an OpenTelemetry explicit bucket histogram.
+type otelHistogram struct {
+	instrument  *otelInstrument
+	labelValues []string
+}
+
+// With returns a new histogram with the label values applied.
+func (h *otelHistogram) With(labelValues ...string) metrics.Histogram {
+	return &otelHistogram{
+		instrument:  h.instrument,
+		labelValues: append(append([]string(nil), h.labelValues...), labelValues...),
+	}
+}
+
+// Observe records the given value.
+func (h *otelHistogram) Observe(value float64) {
+	boundaries := h.instrument.boundaries
+	bucket := sort.SearchFloat64s(boundaries, value)
+
+	h.instrument.update(h.labelValues, func(s *otelSeries) {
+		if s.count == 0 || value < s.min {
+			s.min = value
+		}
+		if s.count == 0 || value > s.max {
+			s.max = value
+		}
+		s.count++
+		s.sum += value
+		s.buckets[bucket]++
+	})
+}
@@ -0,0 +1,301 @@
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestOpenTelemetry(t *testing.T) {
+	payloads := make(chan []byte, 10)
+
+	collector := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/metrics", req.URL.Path)
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		payloads <- body
+	}))
+	t.Cleanup(collector.Close)
+
+	config := &types.OpenTelemetry{}
+	config.SetDefaults()
+	config.Address = strings.TrimPrefix(collector.URL, "http://")
+	config.Insecure = true
+	config.PushInterval = ptypes.Duration(time.Hour)
+	config.ExplicitBoundaries = []float64{1, 5}
+	config.ResourceAttributes = map[string]string{"service.instance.id": "traefik-0"}
+	config.AddRoutersLabels = true
+
+	registry := RegisterOpenTelemetry(context.Background(), config)
+	require.NotNil(t, registry)
+
+	if !registry.IsEpEnabled() || !registry.IsRouterEnabled() || !registry.IsSvcEnabled() {
+		t.Errorf("OpenTelemetry registry should return true for IsEnabled()")
+	}
+
+	registry.ConfigReloadsCounter().Add(1)
+	registry.ServiceReqsCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
+	registry.ServiceReqsCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK)).With("method", http.MethodGet).Add(1)
+	registry.ServiceReqsCounter().With("service", "test", "code", strconv.Itoa(http.StatusNotFound), "method", http.MethodGet).Add(1)
+	registry.EntryPointOpenConnsGauge().With("entrypoint", "test").Set(3)
+	registry.EntryPointOpenConnsGauge().With("entrypoint", "test").Add(-1)
+	registry.RouterReqDurationHistogram().With("router", "demo").Observe(0.5)
+	registry.RouterReqDurationHistogram().With("router", "demo").Observe(5)
+	registry.RouterReqDurationHistogram().With("router", "demo").Observe(10)
+
+	StopOpenTelemetry()
+
+	require.Len(t, payloads, 1)
+
+	resourceMetrics := decodeOTLPMessage(t, decodeSingleField(t, decodeOTLPMessage(t, <-payloads), 1))
+	resource := decodeOTLPMessage(t, decodeSingleField(t, resourceMetrics, 1))
+	assert.Equal(t, map[string]string{
+		"service.name":        "traefik",
+		"service.instance.id": "traefik-0",
+	}, decodeOTLPAttributes(t, resource[1]))
+
+	scopeMetrics := decodeOTLPMessage(t, decodeSingleField(t, resourceMetrics, 2))
+
+	got := make(map[string]map[protowire.Number][][]byte)
+	for _, raw := range scopeMetrics[2] {
+		metric := decodeOTLPMessage(t, raw)
+		got[string(metric[1][0])] = metric
+	}
+	require.Len(t, got, 4)
+
+	configReloads := decodeOTLPMessage(t, decodeSingleField(t, got[configReloadsTotalName], 7))
+	assert.Equal(t, uint64(otelTemporalityCumulative), decodeOTLPVarint(t, configReloads[2][0]))
+	assert.True(t, protowire.DecodeBool(decodeOTLPVarint(t, configReloads[3][0])))
+
+	serviceReqs := decodeOTLPMessage(t, decodeSingleField(t, got[serviceReqsTotalName], 7))
+	require.Len(t, serviceReqs[1], 2)
+
+	dataPoint := decodeOTLPMessage(t, serviceReqs[1][0])
+	assert.Equal(t, map[string]string{"code": "200", "method": http.MethodGet, "service": "test"}, decodeOTLPAttributes(t, dataPoint[7]))
+	assert.Equal(t, float64(2), decodeOTLPDouble(t, dataPoint[4][0]))
+
+	dataPoint = decodeOTLPMessage(t, serviceReqs[1][1])
+	assert.Equal(t, map[string]string{"code": "404", "method": http.MethodGet, "service": "test"}, decodeOTLPAttributes(t, dataPoint[7]))
+	assert.Equal(t, float64(1), decodeOTLPDouble(t, dataPoint[4][0]))
+
+	openConns := decodeOTLPMessage(t, decodeSingleField(t, got[entryPointOpenConnsName], 5))
+	dataPoint = decodeOTLPMessage(t, decodeSingleField(t, openConns, 1))
+	assert.Equal(t, float64(2), decodeOTLPDouble(t, dataPoint[4][0]))
+
+	reqDuration := decodeOTLPMessage(t, decodeSingleField(t, got[routerReqDurationName], 9))
+	dataPoint = decodeOTLPMessage(t, decodeSingleField(t, reqDuration, 1))
+	assert.Equal(t, uint64(3), decodeOTLPFixed64(t, dataPoint[4][0]))
+	assert.Equal(t, 15.5, decodeOTLPDouble(t, dataPoint[5][0]))
+	assert.Equal(t, []uint64{1, 1, 1}, decodeOTLPPackedFixed64(t, dataPoint[6][0]))
+	assert.Equal(t, 0.5, decodeOTLPDouble(t, dataPoint[11][0]))
+	assert.Equal(t, float64(10), decodeOTLPDouble(t, dataPoint[12][0]))
+}
+
+func TestOpenTelemetry_deltaTemporality(t *testing.T) {
+	config := &types.OpenTelemetry{}
+	config.SetDefaults()
+	config.Insecure = true
+	config.Temporality = types.TemporalityDelta
+
+	meter, err := newOtelMeter(context.Background(), config)
+	require.NoError(t, err)
+
+	counter := meter.newCounter("counter", "")
+	histogram := meter.newHistogram("histogram", "")
+	gauge := meter.newGauge("gauge", "")
+
+	counter.With("foo", "bar").Add(2)
+	histogram.Observe(1)
+	gauge.Set(42)
+
+	start := time.Now()
+
+	got := collectOTLPMetrics(t, meter, start)
+	require.Len(t, got, 3)
+
+	sum := decodeOTLPMessage(t, decodeSingleField(t, got["counter"], 7))
+	assert.Equal(t, uint64(otelTemporalityDelta), decodeOTLPVarint(t, sum[2][0]))
+	dataPoint := decodeOTLPMessage(t, decodeSingleField(t, sum, 1))
+	assert.Equal(t, float64(2), decodeOTLPDouble(t, dataPoint[4][0]))
+
+	counter.With("foo", "bar").Add(3)
+
+	// The histogram was not updated during the interval, and the gauge is always reported.
+	got = collectOTLPMetrics(t, meter, start.Add(time.Second))
+	require.Len(t, got, 2)
+
+	sum = decodeOTLPMessage(t, decodeSingleField(t, got["counter"], 7))
+	dataPoint = decodeOTLPMessage(t, decodeSingleField(t, sum, 1))
+	assert.Equal(t, float64(3), decodeOTLPDouble(t, dataPoint[4][0]))
+	assert.Equal(t, uint64(start.UnixNano()), decodeOTLPFixed64(t, dataPoint[2][0]))
+
+	gaugeData := decodeOTLPMessage(t, decodeSingleField(t, got["gauge"], 5))
+	dataPoint = decodeOTLPMessage(t, decodeSingleField(t, gaugeData, 1))
+	assert.Equal(t, float64(42), decodeOTLPDouble(t, dataPoint[4][0]))
+}
+
+func TestOpenTelemetry_removeOutdated(t *testing.T) {
+	config := &types.OpenTelemetry{}
+	config.SetDefaults()
+	config.Insecure = true
+
+	meter, err := newOtelMeter(context.Background(), config)
+	require.NoError(t, err)
+
+	serverUp := meter.newGauge("server_up", "")
+	routerReqs := meter.newCounter("router_requests", "")
+
+	serverUp.With("service", "service1", "url", "http://127.0.0.1:8080").Set(1)
+	serverUp.With("service", "service1", "url", "http://127.0.0.1:9999").Set(1)
+	serverUp.With("service", "service2", "url", "http://127.0.0.1:8080").Set(1)
+	routerReqs.With("router", "router1").Add(1)
+	routerReqs.With("router", "router2").Add(1)
+
+	// All the series are kept until a configuration is received.
+	got := collectOTLPMetrics(t, meter, time.Now())
+	require.Len(t, decodeOTLPMessage(t, decodeSingleField(t, got["server_up"], 5))[1], 3)
+
+	dynamicConfig := newDynamicConfig()
+	dynamicConfig.routers["router1"] = true
+	dynamicConfig.services["service1"] = map[string]bool{"http://127.0.0.1:8080": true}
+	meter.setDynamicConfig(dynamicConfig)
+
+	// The outdated series are exported one last time.
+	got = collectOTLPMetrics(t, meter, time.Now())
+	require.Len(t, decodeOTLPMessage(t, decodeSingleField(t, got["server_up"], 5))[1], 3)
+	require.Len(t, decodeOTLPMessage(t, decodeSingleField(t, got["router_requests"], 7))[1], 2)
+
+	got = collectOTLPMetrics(t, meter, time.Now())
+
+	gauge := decodeOTLPMessage(t, decodeSingleField(t, got["server_up"], 5))
+	dataPoint := decodeOTLPMessage(t, decodeSingleField(t, gauge, 1))
+	assert.Equal(t, map[string]string{"service": "service1", "url": "http://127.0.0.1:8080"}, decodeOTLPAttributes(t, dataPoint[7]))
+
+	sum := decodeOTLPMessage(t, decodeSingleField(t, got["router_requests"], 7))
+	dataPoint = decodeOTLPMessage(t, decodeSingleField(t, sum, 1))
+	assert.Equal(t, map[string]string{"router": "router1"}, decodeOTLPAttributes(t, dataPoint[7]))
+}
+
+func TestOpenTelemetry_unknownTemporality(t *testing.T) {
+	config := &types.OpenTelemetry{}
+	config.SetDefaults()
+	config.Temporality = "foo"
+
+	_, err := newOtelMeter(context.Background(), config)
+	assert.Error(t, err)
+}
+
+func collectOTLPMetrics(t *testing.T, meter *otelMeter, now time.Time) map[string]map[protowire.Number][][]byte {
+	t.Helper()
+
+	resourceMetrics := decodeOTLPMessage(t, decodeSingleField(t, decodeOTLPMessage(t, meter.collect(now)), 1))
+	scopeMetrics := decodeOTLPMessage(t, decodeSingleField(t, resourceMetrics, 2))
+
+	got := make(map[string]map[protowire.Number][][]byte)
+	for _, raw := range scopeMetrics[2] {
+		metric := decodeOTLPMessage(t, raw)
+		got[string(metric[1][0])] = metric
+	}
+
+	return got
+}
+
+// decodeOTLPMessage decodes a protobuf message into its raw field values indexed by field number,
+// the varint and fixed64 values being kept in their wire representation.
+func decodeOTLPMessage(t *testing.T, b []byte) map[protowire.Number][][]byte {
+	t.Helper()
+
+	fields := make(map[protowire.Number][][]byte)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		require.GreaterOrEqual(t, n, 0)
+
+		value := b[:n]
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		fields[num] = append(fields[num], value)
+
+		b = b[n:]
+	}
+
+	return fields
+}
+
+func decodeSingleField(t *testing.T, fields map[protowire.Number][][]byte, num protowire.Number) []byte {
+	t.Helper()
+
+	values := fields[num]
+	require.Len(t, values, 1)
+
+	return values[0]
+}
+
+func decodeOTLPVarint(t *testing.T, b []byte) uint64 {
+	t.Helper()
+
+	v, n := protowire.ConsumeVarint(b)
+	require.GreaterOrEqual(t, n, 0)
+
+	return v
+}
+
+func decodeOTLPFixed64(t *testing.T, b []byte) uint64 {
+	t.Helper()
+
+	v, n := protowire.ConsumeFixed64(b)
+	require.GreaterOrEqual(t, n, 0)
+
+	return v
+}
+
+func decodeOTLPDouble(t *testing.T, b []byte) float64 {
+	t.Helper()
+
+	return math.Float64frombits(decodeOTLPFixed64(t, b))
+}
+
+func decodeOTLPPackedFixed64(t *testing.T, b []byte) []uint64 {
+	t.Helper()
+
+	var values []uint64
+	for len(b) > 0 {
+		values = append(values, decodeOTLPFixed64(t, b))
+		b = b[8:]
+	}
+
+	return values
+}
+
+// decodeOTLPAttributes decodes the KeyValue messages having string values.
+func decodeOTLPAttributes(t *testing.T, kvs [][]byte) map[string]string {
+	t.Helper()
+
+	attrs := make(map[string]string)
+	for _, raw := range kvs {
+		kv := decodeOTLPMessage(t, raw)
+		anyValue := decodeOTLPMessage(t, kv[2][0])
+
+		attrs[string(kv[1][0])] = string(decodeSingleField(t, anyValue, 1))
+	}
+
+	return attrs
+}
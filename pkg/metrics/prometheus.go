@@ -33,6 +33,10 @@ const (
 	metricsTLSPrefix          = MetricNamePrefix + "tls_"
 	tlsCertsNotAfterTimestamp = metricsTLSPrefix + "certs_not_after"
 
+	// access log.
+	metricAccessLogPrefix     = MetricNamePrefix + "accesslog_"
+	accessLogDroppedLinesName = metricAccessLogPrefix + "dropped_lines_total"
+
 	// entry point.
 	metricEntryPointPrefix     = MetricNamePrefix + "entrypoint_"
 	entryPointReqsTotalName    = metricEntryPointPrefix + "requests_total"
@@ -40,6 +44,25 @@ const (
 	entryPointReqDurationName  = metricEntryPointPrefix + "request_duration_seconds"
 	entryPointOpenConnsName    = metricEntryPointPrefix + "open_connections"
 
+	entryPointConnsTotalName    = metricEntryPointPrefix + "connections_total"
+	entryPointActiveConnsName   = metricEntryPointPrefix + "active_connections"
+	entryPointConnDurationName  = metricEntryPointPrefix + "connection_duration_seconds"
+	entryPointBytesReceivedName = metricEntryPointPrefix + "bytes_received_total"
+	entryPointBytesSentName     = metricEntryPointPrefix + "bytes_sent_total"
+
+	// router level.
+	metricRouterPrefix     = MetricNamePrefix + "router_"
+	routerReqsTotalName    = metricRouterPrefix + "requests_total"
+	routerReqsTLSTotalName = metricRouterPrefix + "requests_tls_total"
+	routerReqDurationName  = metricRouterPrefix + "request_duration_seconds"
+	routerOpenConnsName    = metricRouterPrefix + "open_connections"
+
+	routerConnsTotalName    = metricRouterPrefix + "connections_total"
+	routerActiveConnsName   = metricRouterPrefix + "active_connections"
+	routerConnDurationName  = metricRouterPrefix + "connection_duration_seconds"
+	routerBytesReceivedName = metricRouterPrefix + "bytes_received_total"
+	routerBytesSentName     = metricRouterPrefix + "bytes_sent_total"
+
 	// service level.
 
 	// MetricServicePrefix prefix of all service metric names.
@@ -50,6 +73,13 @@ const (
 	serviceOpenConnsName    = MetricServicePrefix + "open_connections"
 	serviceRetriesTotalName = MetricServicePrefix + "retries_total"
 	serviceServerUpName     = MetricServicePrefix + "server_up"
+
+	serviceConnsTotalName    = MetricServicePrefix + "connections_total"
+	serviceActiveConnsName   = MetricServicePrefix + "active_connections"
+	serviceConnDurationName  = MetricServicePrefix + "connection_duration_seconds"
+	serviceBytesReceivedName = MetricServicePrefix + "bytes_received_total"
+	serviceBytesSentName     = MetricServicePrefix + "bytes_sent_total"
+	serviceDialFailuresName  = MetricServicePrefix + "dial_failures_total"
 )
 
 // promState holds all metric state internally and acts as the only Collector we register for Prometheus.
@@ -129,6 +159,10 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		Name: tlsCertsNotAfterTimestamp,
 		Help: "Certificate expiration timestamp",
 	}, []string{"cn", "serial", "sans"})
+	accessLogDroppedLines := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: accessLogDroppedLinesName,
+		Help: "How many access log lines were dropped by an output, partitioned by output.",
+	}, []string{"output"})
 
 	promState.describers = []func(chan<- *stdprometheus.Desc){
 		configReloads.cv.Describe,
@@ -136,16 +170,19 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		lastConfigReloadSuccess.gv.Describe,
 		lastConfigReloadFailure.gv.Describe,
 		tlsCertsNotAfterTimesptamp.gv.Describe,
+		accessLogDroppedLines.cv.Describe,
 	}
 
 	reg := &standardRegistry{
 		epEnabled:                      config.AddEntryPointsLabels,
+		routerEnabled:                  config.AddRoutersLabels,
 		svcEnabled:                     config.AddServicesLabels,
 		configReloadsCounter:           configReloads,
 		configReloadsFailureCounter:    configReloadsFailures,
 		lastConfigReloadSuccessGauge:   lastConfigReloadSuccess,
 		lastConfigReloadFailureGauge:   lastConfigReloadFailure,
 		tlsCertsNotAfterTimestampGauge: tlsCertsNotAfterTimesptamp,
+		accessLogDroppedLinesCounter:   accessLogDroppedLines,
 	}
 
 	if config.AddEntryPointsLabels {
@@ -178,6 +215,110 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		reg.entryPointReqsTLSCounter = entryPointReqsTLS
 		reg.entryPointReqDurationHistogram, _ = NewHistogramWithScale(entryPointReqDurations, time.Second)
 		reg.entryPointOpenConnsGauge = entryPointOpenConns
+
+		entryPointConns := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: entryPointConnsTotalName,
+			Help: "How many TCP connections or UDP sessions are handled on an entrypoint, partitioned by protocol.",
+		}, []string{"protocol", "entrypoint"})
+		entryPointActiveConns := newGaugeFrom(promState.collectors, stdprometheus.GaugeOpts{
+			Name: entryPointActiveConnsName,
+			Help: "How many TCP connections or UDP sessions are currently active on an entrypoint, partitioned by protocol.",
+		}, []string{"protocol", "entrypoint"})
+		entryPointConnDurations := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+			Name:    entryPointConnDurationName,
+			Help:    "How long the TCP connections or UDP sessions lasted on an entrypoint, partitioned by protocol.",
+			Buckets: buckets,
+		}, []string{"protocol", "entrypoint"})
+		entryPointBytesReceived := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: entryPointBytesReceivedName,
+			Help: "How many bytes are received from the clients on an entrypoint, partitioned by protocol.",
+		}, []string{"protocol", "entrypoint"})
+		entryPointBytesSent := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: entryPointBytesSentName,
+			Help: "How many bytes are sent to the clients on an entrypoint, partitioned by protocol.",
+		}, []string{"protocol", "entrypoint"})
+
+		promState.describers = append(promState.describers, []func(chan<- *stdprometheus.Desc){
+			entryPointConns.cv.Describe,
+			entryPointActiveConns.gv.Describe,
+			entryPointConnDurations.hv.Describe,
+			entryPointBytesReceived.cv.Describe,
+			entryPointBytesSent.cv.Describe,
+		}...)
+
+		reg.entryPointConnsCounter = entryPointConns
+		reg.entryPointActiveConnsGauge = entryPointActiveConns
+		reg.entryPointConnDurationHistogram, _ = NewHistogramWithScale(entryPointConnDurations, time.Second)
+		reg.entryPointBytesReceivedCounter = entryPointBytesReceived
+		reg.entryPointBytesSentCounter = entryPointBytesSent
+	}
+
+	if config.AddRoutersLabels {
+		routerReqs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: routerReqsTotalName,
+			Help: "How many HTTP requests are processed on a router, partitioned by service, status code, protocol, and method.",
+		}, []string{"code", "method", "protocol", "router", "service"})
+		routerReqsTLS := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: routerReqsTLSTotalName,
+			Help: "How many HTTP requests with TLS are processed on a router, partitioned by service, TLS Version, and TLS cipher Used.",
+		}, []string{"tls_version", "tls_cipher", "router", "service"})
+		routerReqDurations := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+			Name:    routerReqDurationName,
+			Help:    "How long it took to process the request on a router, partitioned by service, status code, protocol, and method.",
+			Buckets: buckets,
+		}, []string{"code", "method", "protocol", "router", "service"})
+		routerOpenConns := newGaugeFrom(promState.collectors, stdprometheus.GaugeOpts{
+			Name: routerOpenConnsName,
+			Help: "How many open connections exist on a router, partitioned by service, method, and protocol.",
+		}, []string{"method", "protocol", "router", "service"})
+
+		promState.describers = append(promState.describers, []func(chan<- *stdprometheus.Desc){
+			routerReqs.cv.Describe,
+			routerReqsTLS.cv.Describe,
+			routerReqDurations.hv.Describe,
+			routerOpenConns.gv.Describe,
+		}...)
+
+		reg.routerReqsCounter = routerReqs
+		reg.routerReqsTLSCounter = routerReqsTLS
+		reg.routerReqDurationHistogram, _ = NewHistogramWithScale(routerReqDurations, time.Second)
+		reg.routerOpenConnsGauge = routerOpenConns
+
+		routerConns := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: routerConnsTotalName,
+			Help: "How many TCP connections or UDP sessions are handled on a router, partitioned by protocol.",
+		}, []string{"protocol", "router", "service"})
+		routerActiveConns := newGaugeFrom(promState.collectors, stdprometheus.GaugeOpts{
+			Name: routerActiveConnsName,
+			Help: "How many TCP connections or UDP sessions are currently active on a router, partitioned by protocol.",
+		}, []string{"protocol", "router", "service"})
+		routerConnDurations := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+			Name:    routerConnDurationName,
+			Help:    "How long the TCP connections or UDP sessions lasted on a router, partitioned by protocol.",
+			Buckets: buckets,
+		}, []string{"protocol", "router", "service"})
+		routerBytesReceived := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: routerBytesReceivedName,
+			Help: "How many bytes are received from the clients on a router, partitioned by protocol.",
+		}, []string{"protocol", "router", "service"})
+		routerBytesSent := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: routerBytesSentName,
+			Help: "How many bytes are sent to the clients on a router, partitioned by protocol.",
+		}, []string{"protocol", "router", "service"})
+
+		promState.describers = append(promState.describers, []func(chan<- *stdprometheus.Desc){
+			routerConns.cv.Describe,
+			routerActiveConns.gv.Describe,
+			routerConnDurations.hv.Describe,
+			routerBytesReceived.cv.Describe,
+			routerBytesSent.cv.Describe,
+		}...)
+
+		reg.routerConnsCounter = routerConns
+		reg.routerActiveConnsGauge = routerActiveConns
+		reg.routerConnDurationHistogram, _ = NewHistogramWithScale(routerConnDurations, time.Second)
+		reg.routerBytesReceivedCounter = routerBytesReceived
+		reg.routerBytesSentCounter = routerBytesSent
 	}
 
 	if config.AddServicesLabels {
@@ -222,6 +363,48 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		reg.serviceOpenConnsGauge = serviceOpenConns
 		reg.serviceRetriesCounter = serviceRetries
 		reg.serviceServerUpGauge = serviceServerUp
+
+		serviceConns := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: serviceConnsTotalName,
+			Help: "How many TCP connections or UDP sessions are handled on a service, partitioned by protocol.",
+		}, []string{"protocol", "service"})
+		serviceActiveConns := newGaugeFrom(promState.collectors, stdprometheus.GaugeOpts{
+			Name: serviceActiveConnsName,
+			Help: "How many TCP connections or UDP sessions are currently active on a service, partitioned by protocol.",
+		}, []string{"protocol", "service"})
+		serviceConnDurations := newHistogramFrom(promState.collectors, stdprometheus.HistogramOpts{
+			Name:    serviceConnDurationName,
+			Help:    "How long the TCP connections or UDP sessions lasted on a service, partitioned by protocol.",
+			Buckets: buckets,
+		}, []string{"protocol", "service"})
+		serviceBytesReceived := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: serviceBytesReceivedName,
+			Help: "How many bytes are received from the clients on a service, partitioned by protocol.",
+		}, []string{"protocol", "service"})
+		serviceBytesSent := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: serviceBytesSentName,
+			Help: "How many bytes are sent to the clients on a service, partitioned by protocol.",
+		}, []string{"protocol", "service"})
+		serviceDialFailures := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+			Name: serviceDialFailuresName,
+			Help: "How many times a connection to a server of a TCP or UDP service failed, partitioned by protocol.",
+		}, []string{"protocol", "service"})
+
+		promState.describers = append(promState.describers, []func(chan<- *stdprometheus.Desc){
+			serviceConns.cv.Describe,
+			serviceActiveConns.gv.Describe,
+			serviceConnDurations.hv.Describe,
+			serviceBytesReceived.cv.Describe,
+			serviceBytesSent.cv.Describe,
+			serviceDialFailures.cv.Describe,
+		}...)
+
+		reg.serviceConnsCounter = serviceConns
+		reg.serviceActiveConnsGauge = serviceActiveConns
+		reg.serviceConnDurationHistogram, _ = NewHistogramWithScale(serviceConnDurations, time.Second)
+		reg.serviceBytesReceivedCounter = serviceBytesReceived
+		reg.serviceBytesSentCounter = serviceBytesSent
+		reg.serviceDialFailuresCounter = serviceDialFailures
 	}
 
 	return reg
@@ -268,7 +451,35 @@ func OnConfigurationUpdate(conf dynamic.Configuration, entryPoints []string) {
 		}
 	}
 
+	if conf.TCP != nil {
+		for name := range conf.TCP.Routers {
+			dynamicConfig.routers[name] = true
+		}
+
+		for serviceName := range conf.TCP.Services {
+			if _, ok := dynamicConfig.services[serviceName]; !ok {
+				dynamicConfig.services[serviceName] = make(map[string]bool)
+			}
+		}
+	}
+
+	if conf.UDP != nil {
+		for name := range conf.UDP.Routers {
+			dynamicConfig.routers[name] = true
+		}
+
+		for serviceName := range conf.UDP.Services {
+			if _, ok := dynamicConfig.services[serviceName]; !ok {
+				dynamicConfig.services[serviceName] = make(map[string]bool)
+			}
+		}
+	}
+
 	promState.SetDynamicConfig(dynamicConfig)
+
+	if openTelemetryMeter != nil {
+		openTelemetryMeter.setDynamicConfig(dynamicConfig)
+	}
 }
 
 func newPrometheusState() *prometheusState {
@@ -337,22 +548,7 @@ func (ps *prometheusState) Collect(ch chan<- stdprometheus.Metric) {
 // isOutdated checks whether the passed collector has labels that mark
 // it as belonging to an outdated configuration of Traefik.
 func (ps *prometheusState) isOutdated(collector *collector) bool {
-	labels := collector.labels
-
-	if entrypointName, ok := labels["entrypoint"]; ok && !ps.dynamicConfig.hasEntryPoint(entrypointName) {
-		return true
-	}
-
-	if serviceName, ok := labels["service"]; ok {
-		if !ps.dynamicConfig.hasService(serviceName) {
-			return true
-		}
-		if url, ok := labels["url"]; ok && !ps.dynamicConfig.hasServerURL(serviceName, url) {
-			return true
-		}
-	}
-
-	return false
+	return ps.dynamicConfig.isOutdated(collector.labels)
 }
 
 func newDynamicConfig() *dynamicConfig {
@@ -373,11 +569,39 @@ type dynamicConfig struct {
 	services    map[string]map[string]bool
 }
 
+// isOutdated checks whether the labels refer to an entryPoint, a router, a service, or a server URL,
+// which is not part of the configuration.
+func (d *dynamicConfig) isOutdated(labels map[string]string) bool {
+	if entrypointName, ok := labels["entrypoint"]; ok && !d.hasEntryPoint(entrypointName) {
+		return true
+	}
+
+	if routerName, ok := labels["router"]; ok && !d.hasRouter(routerName) {
+		return true
+	}
+
+	if serviceName, ok := labels["service"]; ok {
+		if !d.hasService(serviceName) {
+			return true
+		}
+		if url, ok := labels["url"]; ok && !d.hasServerURL(serviceName, url) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (d *dynamicConfig) hasEntryPoint(entrypointName string) bool {
 	_, ok := d.entryPoints[entrypointName]
 	return ok
 }
 
+func (d *dynamicConfig) hasRouter(routerName string) bool {
+	_, ok := d.routers[routerName]
+	return ok
+}
+
 func (d *dynamicConfig) hasService(serviceName string) bool {
 	_, ok := d.services[serviceName]
 	return ok
@@ -104,10 +104,10 @@ func TestPrometheus(t *testing.T) {
 	// Reset state of global promState.
 	defer promState.reset()
 
-	prometheusRegistry := RegisterPrometheus(context.Background(), &types.Prometheus{AddEntryPointsLabels: true, AddServicesLabels: true})
+	prometheusRegistry := RegisterPrometheus(context.Background(), &types.Prometheus{AddEntryPointsLabels: true, AddRoutersLabels: true, AddServicesLabels: true})
 	defer promRegistry.Unregister(promState)
 
-	if !prometheusRegistry.IsEpEnabled() || !prometheusRegistry.IsSvcEnabled() {
+	if !prometheusRegistry.IsEpEnabled() || !prometheusRegistry.IsRouterEnabled() || !prometheusRegistry.IsSvcEnabled() {
 		t.Errorf("PrometheusRegistry should return true for IsEnabled()")
 	}
 
@@ -134,6 +134,23 @@ func TestPrometheus(t *testing.T) {
 		With("method", http.MethodGet, "protocol", "http", "entrypoint", "http").
 		Set(1)
 
+	prometheusRegistry.
+		RouterReqsCounter().
+		With("router", "demo", "service", "service1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Add(1)
+	prometheusRegistry.
+		RouterReqsTLSCounter().
+		With("router", "demo", "service", "service1", "tls_version", "foo", "tls_cipher", "bar").
+		Add(1)
+	prometheusRegistry.
+		RouterReqDurationHistogram().
+		With("router", "demo", "service", "service1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Observe(10000)
+	prometheusRegistry.
+		RouterOpenConnsGauge().
+		With("router", "demo", "service", "service1", "method", http.MethodGet, "protocol", "http").
+		Set(1)
+
 	prometheusRegistry.
 		ServiceReqsCounter().
 		With("service", "service1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
@@ -155,6 +172,27 @@ func TestPrometheus(t *testing.T) {
 		With("service", "service1", "url", "http://127.0.0.10:80").
 		Set(1)
 
+	prometheusRegistry.
+		EntryPointConnsCounter().
+		With("protocol", "tcp", "entrypoint", "tcp").
+		Add(1)
+	prometheusRegistry.
+		EntryPointBytesReceivedCounter().
+		With("protocol", "tcp", "entrypoint", "tcp").
+		Add(1024)
+	prometheusRegistry.
+		RouterActiveConnsGauge().
+		With("protocol", "udp", "router", "demo", "service", "service1").
+		Add(1)
+	prometheusRegistry.
+		ServiceConnDurationHistogram().
+		With("protocol", "tcp", "service", "service1").
+		Observe(1)
+	prometheusRegistry.
+		ServiceDialFailuresCounter().
+		With("protocol", "tcp", "service", "service1").
+		Add(1)
+
 	delayForTrackingCompletion()
 
 	metricsFamilies := mustScrape()
@@ -218,6 +256,48 @@ func TestPrometheus(t *testing.T) {
 			},
 			assert: buildGaugeAssert(t, entryPointOpenConnsName, 1),
 		},
+		{
+			name: routerReqsTotalName,
+			labels: map[string]string{
+				"code":     "200",
+				"method":   http.MethodGet,
+				"protocol": "http",
+				"service":  "service1",
+				"router":   "demo",
+			},
+			assert: buildCounterAssert(t, routerReqsTotalName, 1),
+		},
+		{
+			name: routerReqsTLSTotalName,
+			labels: map[string]string{
+				"service":     "service1",
+				"router":      "demo",
+				"tls_version": "foo",
+				"tls_cipher":  "bar",
+			},
+			assert: buildCounterAssert(t, routerReqsTLSTotalName, 1),
+		},
+		{
+			name: routerReqDurationName,
+			labels: map[string]string{
+				"code":     "200",
+				"method":   http.MethodGet,
+				"protocol": "http",
+				"service":  "service1",
+				"router":   "demo",
+			},
+			assert: buildHistogramAssert(t, routerReqDurationName, 1),
+		},
+		{
+			name: routerOpenConnsName,
+			labels: map[string]string{
+				"method":   http.MethodGet,
+				"protocol": "http",
+				"service":  "service1",
+				"router":   "demo",
+			},
+			assert: buildGaugeAssert(t, routerOpenConnsName, 1),
+		},
 		{
 			name: serviceReqsTotalName,
 			labels: map[string]string{
@@ -262,6 +342,47 @@ func TestPrometheus(t *testing.T) {
 			},
 			assert: buildGaugeAssert(t, serviceServerUpName, 1),
 		},
+		{
+			name: entryPointConnsTotalName,
+			labels: map[string]string{
+				"protocol":   "tcp",
+				"entrypoint": "tcp",
+			},
+			assert: buildCounterAssert(t, entryPointConnsTotalName, 1),
+		},
+		{
+			name: entryPointBytesReceivedName,
+			labels: map[string]string{
+				"protocol":   "tcp",
+				"entrypoint": "tcp",
+			},
+			assert: buildCounterAssert(t, entryPointBytesReceivedName, 1024),
+		},
+		{
+			name: routerActiveConnsName,
+			labels: map[string]string{
+				"protocol": "udp",
+				"router":   "demo",
+				"service":  "service1",
+			},
+			assert: buildGaugeAssert(t, routerActiveConnsName, 1),
+		},
+		{
+			name: serviceConnDurationName,
+			labels: map[string]string{
+				"protocol": "tcp",
+				"service":  "service1",
+			},
+			assert: buildHistogramAssert(t, serviceConnDurationName, 1),
+		},
+		{
+			name: serviceDialFailuresName,
+			labels: map[string]string{
+				"protocol": "tcp",
+				"service":  "service1",
+			},
+			assert: buildCounterAssert(t, serviceDialFailuresName, 1),
+		},
 	}
 
 	for _, test := range testCases {
@@ -292,7 +413,7 @@ func TestPrometheusMetricRemoval(t *testing.T) {
 	// Reset state of global promState.
 	defer promState.reset()
 
-	prometheusRegistry := RegisterPrometheus(context.Background(), &types.Prometheus{AddEntryPointsLabels: true, AddServicesLabels: true})
+	prometheusRegistry := RegisterPrometheus(context.Background(), &types.Prometheus{AddEntryPointsLabels: true, AddRoutersLabels: true, AddServicesLabels: true})
 	defer promRegistry.Unregister(promState)
 
 	conf := dynamic.Configuration{
@@ -325,6 +446,10 @@ func TestPrometheusMetricRemoval(t *testing.T) {
 		ServiceReqsCounter().
 		With("service", "service2", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
 		Add(1)
+	prometheusRegistry.
+		RouterReqsCounter().
+		With("router", "router2", "service", "bar@providerName", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Add(1)
 	prometheusRegistry.
 		ServiceServerUpGauge().
 		With("service", "service1", "url", "http://localhost:9999").
@@ -332,8 +457,8 @@ func TestPrometheusMetricRemoval(t *testing.T) {
 
 	delayForTrackingCompletion()
 
-	assertMetricsExist(t, mustScrape(), entryPointReqsTotalName, serviceReqsTotalName, serviceServerUpName)
-	assertMetricsAbsent(t, mustScrape(), entryPointReqsTotalName, serviceReqsTotalName, serviceServerUpName)
+	assertMetricsExist(t, mustScrape(), entryPointReqsTotalName, routerReqsTotalName, serviceReqsTotalName, serviceServerUpName)
+	assertMetricsAbsent(t, mustScrape(), entryPointReqsTotalName, routerReqsTotalName, serviceReqsTotalName, serviceServerUpName)
 
 	// To verify that metrics belonging to active configurations are not removed
 	// here the counter examples.
@@ -341,11 +466,15 @@ func TestPrometheusMetricRemoval(t *testing.T) {
 		EntryPointReqsCounter().
 		With("entrypoint", "entrypoint1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
 		Add(1)
+	prometheusRegistry.
+		RouterReqsCounter().
+		With("router", "foo@providerName", "service", "bar@providerName", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
+		Add(1)
 
 	delayForTrackingCompletion()
 
-	assertMetricsExist(t, mustScrape(), entryPointReqsTotalName)
-	assertMetricsExist(t, mustScrape(), entryPointReqsTotalName)
+	assertMetricsExist(t, mustScrape(), entryPointReqsTotalName, routerReqsTotalName)
+	assertMetricsExist(t, mustScrape(), entryPointReqsTotalName, routerReqsTotalName)
 }
 
 func TestPrometheusRemovedMetricsReset(t *testing.T) {
@@ -27,9 +27,30 @@ const (
 	statsdEntryPointReqsName            = "entrypoint.request.total"
 	statsdEntryPointReqDurationName     = "entrypoint.request.duration"
 	statsdEntryPointOpenConnsName       = "entrypoint.connections.open"
+	statsdRouterReqsName                = "router.request.total"
+	statsdRouterReqsTLSName             = "router.request.tls.total"
+	statsdRouterReqDurationName         = "router.request.duration"
+	statsdRouterOpenConnsName           = "router.connections.open"
 	statsdOpenConnsName                 = "service.connections.open"
 	statsdServerUpName                  = "service.server.up"
 	statsdTLSCertsNotAfterTimestampName = "tls.certs.notAfterTimestamp"
+	statsdAccessLogDroppedLinesName     = "accesslog.dropped.lines.total"
+	statsdEntryPointConnsName           = "entrypoint.connections.total"
+	statsdEntryPointActiveConnsName     = "entrypoint.connections.active"
+	statsdEntryPointConnDurationName    = "entrypoint.connection.duration"
+	statsdEntryPointBytesReceivedName   = "entrypoint.bytes.received"
+	statsdEntryPointBytesSentName       = "entrypoint.bytes.sent"
+	statsdRouterConnsName               = "router.connections.total"
+	statsdRouterActiveConnsName         = "router.connections.active"
+	statsdRouterConnDurationName        = "router.connection.duration"
+	statsdRouterBytesReceivedName       = "router.bytes.received"
+	statsdRouterBytesSentName           = "router.bytes.sent"
+	statsdServiceConnsName              = "service.connections.total"
+	statsdServiceActiveConnsName        = "service.connections.active"
+	statsdServiceConnDurationName       = "service.connection.duration"
+	statsdServiceBytesReceivedName      = "service.bytes.received"
+	statsdServiceBytesSentName          = "service.bytes.sent"
+	statsdServiceDialFailuresName       = "service.dial.failures.total"
 )
 
 // RegisterStatsd registers the metrics pusher if this didn't happen yet and creates a statsd Registry instance.
@@ -54,6 +75,7 @@ func RegisterStatsd(ctx context.Context, config *types.Statsd) Registry {
 		lastConfigReloadSuccessGauge:   statsdClient.NewGauge(statsdLastConfigReloadSuccessName),
 		lastConfigReloadFailureGauge:   statsdClient.NewGauge(statsdLastConfigReloadFailureName),
 		tlsCertsNotAfterTimestampGauge: statsdClient.NewGauge(statsdTLSCertsNotAfterTimestampName),
+		accessLogDroppedLinesCounter:   statsdClient.NewCounter(statsdAccessLogDroppedLinesName, 1.0),
 	}
 
 	if config.AddEntryPointsLabels {
@@ -61,6 +83,24 @@ func RegisterStatsd(ctx context.Context, config *types.Statsd) Registry {
 		registry.entryPointReqsCounter = statsdClient.NewCounter(statsdEntryPointReqsName, 1.0)
 		registry.entryPointReqDurationHistogram, _ = NewHistogramWithScale(statsdClient.NewTiming(statsdEntryPointReqDurationName, 1.0), time.Millisecond)
 		registry.entryPointOpenConnsGauge = statsdClient.NewGauge(statsdEntryPointOpenConnsName)
+		registry.entryPointConnsCounter = statsdClient.NewCounter(statsdEntryPointConnsName, 1.0)
+		registry.entryPointActiveConnsGauge = statsdClient.NewGauge(statsdEntryPointActiveConnsName)
+		registry.entryPointConnDurationHistogram, _ = NewHistogramWithScale(statsdClient.NewTiming(statsdEntryPointConnDurationName, 1.0), time.Millisecond)
+		registry.entryPointBytesReceivedCounter = statsdClient.NewCounter(statsdEntryPointBytesReceivedName, 1.0)
+		registry.entryPointBytesSentCounter = statsdClient.NewCounter(statsdEntryPointBytesSentName, 1.0)
+	}
+
+	if config.AddRoutersLabels {
+		registry.routerEnabled = config.AddRoutersLabels
+		registry.routerReqsCounter = statsdClient.NewCounter(statsdRouterReqsName, 1.0)
+		registry.routerReqsTLSCounter = statsdClient.NewCounter(statsdRouterReqsTLSName, 1.0)
+		registry.routerReqDurationHistogram, _ = NewHistogramWithScale(statsdClient.NewTiming(statsdRouterReqDurationName, 1.0), time.Millisecond)
+		registry.routerOpenConnsGauge = statsdClient.NewGauge(statsdRouterOpenConnsName)
+		registry.routerConnsCounter = statsdClient.NewCounter(statsdRouterConnsName, 1.0)
+		registry.routerActiveConnsGauge = statsdClient.NewGauge(statsdRouterActiveConnsName)
+		registry.routerConnDurationHistogram, _ = NewHistogramWithScale(statsdClient.NewTiming(statsdRouterConnDurationName, 1.0), time.Millisecond)
+		registry.routerBytesReceivedCounter = statsdClient.NewCounter(statsdRouterBytesReceivedName, 1.0)
+		registry.routerBytesSentCounter = statsdClient.NewCounter(statsdRouterBytesSentName, 1.0)
 	}
 
 	if config.AddServicesLabels {
@@ -70,6 +110,12 @@ func RegisterStatsd(ctx context.Context, config *types.Statsd) Registry {
 		registry.serviceRetriesCounter = statsdClient.NewCounter(statsdRetriesTotalName, 1.0)
 		registry.serviceOpenConnsGauge = statsdClient.NewGauge(statsdOpenConnsName)
 		registry.serviceServerUpGauge = statsdClient.NewGauge(statsdServerUpName)
+		registry.serviceConnsCounter = statsdClient.NewCounter(statsdServiceConnsName, 1.0)
+		registry.serviceActiveConnsGauge = statsdClient.NewGauge(statsdServiceActiveConnsName)
+		registry.serviceConnDurationHistogram, _ = NewHistogramWithScale(statsdClient.NewTiming(statsdServiceConnDurationName, 1.0), time.Millisecond)
+		registry.serviceBytesReceivedCounter = statsdClient.NewCounter(statsdServiceBytesReceivedName, 1.0)
+		registry.serviceBytesSentCounter = statsdClient.NewCounter(statsdServiceBytesSentName, 1.0)
+		registry.serviceDialFailuresCounter = statsdClient.NewCounter(statsdServiceDialFailuresName, 1.0)
 	}
 
 	return registry
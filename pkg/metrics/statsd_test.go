@@ -17,10 +17,10 @@ func TestStatsD(t *testing.T) {
 	// This is needed to make sure that UDP Listener listens for data a bit longer, otherwise it will quit after a millisecond
 	udp.Timeout = 5 * time.Second
 
-	statsdRegistry := RegisterStatsd(context.Background(), &types.Statsd{Address: ":18125", PushInterval: ptypes.Duration(time.Second), AddEntryPointsLabels: true, AddServicesLabels: true})
+	statsdRegistry := RegisterStatsd(context.Background(), &types.Statsd{Address: ":18125", PushInterval: ptypes.Duration(time.Second), AddEntryPointsLabels: true, AddRoutersLabels: true, AddServicesLabels: true})
 	defer StopStatsd()
 
-	if !statsdRegistry.IsEpEnabled() || !statsdRegistry.IsSvcEnabled() {
+	if !statsdRegistry.IsEpEnabled() || !statsdRegistry.IsRouterEnabled() || !statsdRegistry.IsSvcEnabled() {
 		t.Errorf("Statsd registry should return true for IsEnabled()")
 	}
 
@@ -34,6 +34,10 @@ func TestStatsD(t *testing.T) {
 		"traefik.entrypoint.request.total:1.000000|c\n",
 		"traefik.entrypoint.request.duration:10000.000000|ms",
 		"traefik.entrypoint.connections.open:1.000000|g\n",
+		"traefik.router.request.total:2.000000|c\n",
+		"traefik.router.request.tls.total:1.000000|c\n",
+		"traefik.router.request.duration:10000.000000|ms",
+		"traefik.router.connections.open:1.000000|g\n",
 		"traefik.service.server.up:1.000000|g\n",
 		"tls.certs.notAfterTimestamp:1.000000|g\n",
 	}
@@ -49,6 +53,11 @@ func TestStatsD(t *testing.T) {
 		statsdRegistry.EntryPointReqsCounter().With("entrypoint", "test").Add(1)
 		statsdRegistry.EntryPointReqDurationHistogram().With("entrypoint", "test").Observe(10000)
 		statsdRegistry.EntryPointOpenConnsGauge().With("entrypoint", "test").Set(1)
+		statsdRegistry.RouterReqsCounter().With("router", "demo", "service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
+		statsdRegistry.RouterReqsCounter().With("router", "demo", "service", "test", "code", strconv.Itoa(http.StatusNotFound), "method", http.MethodGet).Add(1)
+		statsdRegistry.RouterReqsTLSCounter().With("router", "demo", "service", "test", "tls_version", "foo", "tls_cipher", "bar").Add(1)
+		statsdRegistry.RouterReqDurationHistogram().With("router", "demo", "service", "test", "code", strconv.Itoa(http.StatusOK)).Observe(10000)
+		statsdRegistry.RouterOpenConnsGauge().With("router", "demo", "service", "test").Set(1)
 		statsdRegistry.ServiceServerUpGauge().With("service:test", "url", "http://127.0.0.1").Set(1)
 		statsdRegistry.TLSCertsNotAfterTimestampGauge().With("key", "value").Set(1)
 	})
@@ -59,10 +68,10 @@ func TestStatsDWithPrefix(t *testing.T) {
 	// This is needed to make sure that UDP Listener listens for data a bit longer, otherwise it will quit after a millisecond
 	udp.Timeout = 5 * time.Second
 
-	statsdRegistry := RegisterStatsd(context.Background(), &types.Statsd{Address: ":18125", PushInterval: ptypes.Duration(time.Second), AddEntryPointsLabels: true, AddServicesLabels: true, Prefix: "testPrefix"})
+	statsdRegistry := RegisterStatsd(context.Background(), &types.Statsd{Address: ":18125", PushInterval: ptypes.Duration(time.Second), AddEntryPointsLabels: true, AddRoutersLabels: true, AddServicesLabels: true, Prefix: "testPrefix"})
 	defer StopStatsd()
 
-	if !statsdRegistry.IsEpEnabled() || !statsdRegistry.IsSvcEnabled() {
+	if !statsdRegistry.IsEpEnabled() || !statsdRegistry.IsRouterEnabled() || !statsdRegistry.IsSvcEnabled() {
 		t.Errorf("Statsd registry should return true for IsEnabled()")
 	}
 
@@ -76,6 +85,10 @@ func TestStatsDWithPrefix(t *testing.T) {
 		"testPrefix.entrypoint.request.total:1.000000|c\n",
 		"testPrefix.entrypoint.request.duration:10000.000000|ms",
 		"testPrefix.entrypoint.connections.open:1.000000|g\n",
+		"testPrefix.router.request.total:2.000000|c\n",
+		"testPrefix.router.request.tls.total:1.000000|c\n",
+		"testPrefix.router.request.duration:10000.000000|ms",
+		"testPrefix.router.connections.open:1.000000|g\n",
 		"testPrefix.service.server.up:1.000000|g\n",
 		"tls.certs.notAfterTimestamp:1.000000|g\n",
 	}
@@ -91,6 +104,11 @@ func TestStatsDWithPrefix(t *testing.T) {
 		statsdRegistry.EntryPointReqsCounter().With("entrypoint", "test").Add(1)
 		statsdRegistry.EntryPointReqDurationHistogram().With("entrypoint", "test").Observe(10000)
 		statsdRegistry.EntryPointOpenConnsGauge().With("entrypoint", "test").Set(1)
+		statsdRegistry.RouterReqsCounter().With("router", "demo", "service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
+		statsdRegistry.RouterReqsCounter().With("router", "demo", "service", "test", "code", strconv.Itoa(http.StatusNotFound), "method", http.MethodGet).Add(1)
+		statsdRegistry.RouterReqsTLSCounter().With("router", "demo", "service", "test", "tls_version", "foo", "tls_cipher", "bar").Add(1)
+		statsdRegistry.RouterReqDurationHistogram().With("router", "demo", "service", "test", "code", strconv.Itoa(http.StatusOK)).Observe(10000)
+		statsdRegistry.RouterOpenConnsGauge().With("router", "demo", "service", "test").Set(1)
 		statsdRegistry.ServiceServerUpGauge().With("service:test", "url", "http://127.0.0.1").Set(1)
 		statsdRegistry.TLSCertsNotAfterTimestampGauge().With("key", "value").Set(1)
 	})
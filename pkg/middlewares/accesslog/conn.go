@@ -0,0 +1,147 @@
+package accesslog
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	protoTCP = "TCP"
+	protoUDP = "UDP"
+)
+
+// closeReasonClosed is the close reason of the connections which ended without error.
+const closeReasonClosed = "closed"
+
+// connLog records the data of a TCP connection or a UDP session,
+// the access log entry being written once it ends.
+type connLog struct {
+	handler *Handler
+	policy  *policy
+	core    CoreLogData
+
+	received int64
+	sent     int64
+
+	mu          sync.Mutex
+	backendAddr string
+	err         error
+}
+
+func (h *Handler) newConnLog(protocol string, clientAddr net.Addr, routerName, serviceName string, p *policy) *connLog {
+	now := time.Now().UTC()
+
+	core := CoreLogData{
+		StartUTC:        now,
+		StartLocal:      now.Local(),
+		RequestCount:    nextRequestCount(),
+		RequestProtocol: protocol,
+		RouterName:      routerName,
+		ServiceName:     serviceName,
+	}
+
+	if clientAddr != nil {
+		core[ClientAddr] = clientAddr.String()
+		core[ClientHost], core[ClientPort] = silentSplitHostPort(clientAddr.String())
+	}
+
+	return &connLog{
+		handler: h,
+		policy:  p,
+		core:    core,
+	}
+}
+
+// Received records the bytes received from the client.
+func (l *connLog) Received(n int) {
+	if n > 0 {
+		atomic.AddInt64(&l.received, int64(n))
+	}
+}
+
+// Sent records the bytes sent to the client.
+func (l *connLog) Sent(n int) {
+	if n > 0 {
+		atomic.AddInt64(&l.sent, int64(n))
+	}
+}
+
+// BackendConnected records the address of the backend the connection is forwarded to.
+func (l *connLog) BackendConnected(addr net.Addr) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.backendAddr = addr.String()
+}
+
+// Done records the error which ended the forwarding of the connection, only the first one being kept.
+func (l *connLog) Done(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.err == nil {
+		l.err = err
+	}
+}
+
+// end completes the log data and hands it over to the handler.
+func (l *connLog) end() {
+	core := l.core
+
+	core[RequestContentSize] = atomic.LoadInt64(&l.received)
+	core[DownstreamContentSize] = atomic.LoadInt64(&l.sent)
+
+	l.mu.Lock()
+	if l.backendAddr != "" {
+		core[ServiceAddr] = l.backendAddr
+	}
+	core[CloseReason] = closeReasonClosed
+	if l.err != nil {
+		core[CloseReason] = l.err.Error()
+	}
+	l.mu.Unlock()
+
+	logDataTable := &LogData{Core: core, policy: l.policy}
+
+	if l.handler.config.BufferingSize > 0 {
+		l.handler.logHandlerChan <- handlerParams{
+			logDataTable: logDataTable,
+			connection:   true,
+		}
+	} else {
+		l.handler.logTheConnection(logDataTable)
+	}
+}
+
+// logTheConnection writes the access log entry of a TCP connection or a UDP session.
+func (h *Handler) logTheConnection(logDataTable *LogData) {
+	core := logDataTable.Core
+
+	// n.b. take care to perform time arithmetic using UTC to avoid errors at DST boundaries.
+	totalDuration := time.Now().UTC().Sub(core[StartUTC].(time.Time))
+	core[Duration] = totalDuration
+
+	p := h.policy
+	if logDataTable.policy != nil {
+		p = logDataTable.policy
+	}
+
+	if !p.keepConn(totalDuration) {
+		return
+	}
+
+	fields := logrus.Fields{}
+	for k, v := range core {
+		if p.fields.Keep(k) {
+			fields[k] = v
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.connLogger.WithFields(fields).Println()
+}
@@ -75,6 +75,18 @@ const (
 	TLSVersion = "TLSVersion"
 	// TLSCipher is the cipher used in the request.
 	TLSCipher = "TLSCipher"
+	// TLSServerName is the server name indicated by the client in the TLS handshake (SNI) of a TCP connection.
+	TLSServerName = "TLSServerName"
+	// TLSClientJA3 is the JA3 fingerprint of the TLS ClientHello of the client.
+	TLSClientJA3 = "TLSClientJA3"
+
+	// CloseReason is the map key used for the reason why a TCP connection or a UDP session ended.
+	CloseReason = "CloseReason"
+
+	// RequestBody is the map key used for the beginning of the request body, when its capture is enabled.
+	RequestBody = "RequestBody"
+	// ResponseBody is the map key used for the beginning of the response body, when its capture is enabled.
+	ResponseBody = "ResponseBody"
 )
 
 // These are written out in the default case when no config is provided to specify keys of interest.
@@ -118,6 +130,11 @@ func init() {
 	allCoreKeys[RetryAttempts] = struct{}{}
 	allCoreKeys[TLSVersion] = struct{}{}
 	allCoreKeys[TLSCipher] = struct{}{}
+	allCoreKeys[TLSServerName] = struct{}{}
+	allCoreKeys[TLSClientJA3] = struct{}{}
+	allCoreKeys[CloseReason] = struct{}{}
+	allCoreKeys[RequestBody] = struct{}{}
+	allCoreKeys[ResponseBody] = struct{}{}
 }
 
 // CoreLogData holds the fields computed from the request/response.
@@ -129,6 +146,9 @@ type LogData struct {
 	Request            request
 	OriginResponse     http.Header
 	DownstreamResponse downstreamResponse
+
+	// policy is the access log policy of the router which handled the request, if it overrides the global one.
+	policy *policy
 }
 
 type downstreamResponse struct {
@@ -6,7 +6,6 @@ import (
 	"io"
 	"net"
 	"net/http"
-	"net/textproto"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -15,10 +14,11 @@ import (
 	"time"
 
 	"github.com/containous/alice"
+	gokitmetrics "github.com/go-kit/kit/metrics"
 	"github.com/sirupsen/logrus"
-	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v2/pkg/log"
 	traefiktls "github.com/traefik/traefik/v2/pkg/tls"
+	"github.com/traefik/traefik/v2/pkg/tls/ja3"
 	"github.com/traefik/traefik/v2/pkg/types"
 )
 
@@ -33,6 +33,15 @@ const (
 
 	// JSONFormat is the JSON logging format.
 	JSONFormat string = "json"
+
+	// LogfmtFormat is the logfmt logging format.
+	LogfmtFormat string = "logfmt"
+
+	// CEFFormat is the ArcSight Common Event Format (CEF) logging format.
+	CEFFormat string = "cef"
+
+	// TemplateFormat is the user-defined template logging format.
+	TemplateFormat string = "template"
 )
 
 type noopCloser struct {
@@ -50,15 +59,19 @@ func (n noopCloser) Close() error {
 
 type handlerParams struct {
 	logDataTable *LogData
+	// connection is true when the log data is the one of a TCP connection or a UDP session.
+	connection bool
 }
 
 // Handler will write each request and its response to the access log.
 type Handler struct {
 	config         *types.AccessLog
 	logger         *logrus.Logger
+	connLogger     *logrus.Logger
 	file           io.WriteCloser
+	sinks          []*bufferedSink
 	mu             sync.Mutex
-	httpCodeRanges types.HTTPCodeRanges
+	policy         *policy
 	logHandlerChan chan handlerParams
 	wg             sync.WaitGroup
 }
@@ -74,58 +87,93 @@ func WrapHandler(handler *Handler) alice.Constructor {
 
 // NewHandler creates a new Handler.
 func NewHandler(config *types.AccessLog) (*Handler, error) {
-	var file io.WriteCloser = noopCloser{os.Stdout}
-	if len(config.FilePath) > 0 {
-		f, err := openAccessLogFile(config.FilePath)
-		if err != nil {
-			return nil, fmt.Errorf("error opening access log file: %w", err)
-		}
-		file = f
+	if err := checkSampleRate(config.SampleRate); err != nil {
+		return nil, err
 	}
-	logHandlerChan := make(chan handlerParams, config.BufferingSize)
 
-	var formatter logrus.Formatter
+	var formatter, connFormatter logrus.Formatter
 
 	switch config.Format {
 	case CommonFormat:
 		formatter = new(CommonLogFormatter)
+		connFormatter = new(CommonConnLogFormatter)
 	case JSONFormat:
 		formatter = new(logrus.JSONFormatter)
+		connFormatter = formatter
+	case LogfmtFormat:
+		formatter = new(LogfmtFormatter)
+		connFormatter = formatter
+	case CEFFormat:
+		formatter = new(CEFFormatter)
+		connFormatter = formatter
+	case TemplateFormat:
+		templateFormatter, err := NewTemplateFormatter(config.Template)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing access log template: %w", err)
+		}
+		formatter = templateFormatter
+		connFormatter = formatter
 	default:
 		log.WithoutContext().Errorf("unsupported access log format: %q, defaulting to common format instead.", config.Format)
 		formatter = new(CommonLogFormatter)
+		connFormatter = new(CommonConnLogFormatter)
+	}
+
+	sinks, err := newSinks(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var file io.WriteCloser
+	switch {
+	case len(config.FilePath) > 0:
+		f, err := openAccessLogFile(config.FilePath)
+		if err != nil {
+			closeSinks(sinks)
+			return nil, fmt.Errorf("error opening access log file: %w", err)
+		}
+		file = f
+	case len(sinks) == 0:
+		file = noopCloser{os.Stdout}
 	}
 
+	logHandlerChan := make(chan handlerParams, config.BufferingSize)
+
 	logger := &logrus.Logger{
-		Out:       file,
 		Formatter: formatter,
 		Hooks:     make(logrus.LevelHooks),
 		Level:     logrus.InfoLevel,
 	}
 
-	// Transform headers names in config to a canonical form, to be used as is without further transformations.
-	if config.Fields != nil && config.Fields.Headers != nil && len(config.Fields.Headers.Names) > 0 {
-		fields := map[string]string{}
-
-		for h, v := range config.Fields.Headers.Names {
-			fields[textproto.CanonicalMIMEHeaderKey(h)] = v
-		}
-
-		config.Fields.Headers.Names = fields
+	connLogger := &logrus.Logger{
+		Formatter: connFormatter,
+		Hooks:     make(logrus.LevelHooks),
+		Level:     logrus.InfoLevel,
 	}
 
 	logHandler := &Handler{
 		config:         config,
 		logger:         logger,
+		connLogger:     connLogger,
 		file:           file,
+		sinks:          sinks,
 		logHandlerChan: logHandlerChan,
 	}
 
+	logHandler.logger.Out = logHandler.output()
+	logHandler.connLogger.Out = logHandler.output()
+
+	logHandler.policy = &policy{
+		sampleRate: config.SampleRate,
+		filters:    config.Filters,
+		fields:     canonicalHeaderNames(config.Fields),
+	}
+
 	if config.Filters != nil {
 		if httpCodeRanges, err := types.NewHTTPCodeRanges(config.Filters.StatusCodes); err != nil {
 			log.WithoutContext().Errorf("Failed to create new HTTP code ranges: %s", err)
 		} else {
-			logHandler.httpCodeRanges = httpCodeRanges
+			logHandler.policy.httpCodeRanges = httpCodeRanges
 		}
 	}
 
@@ -134,6 +182,10 @@ func NewHandler(config *types.AccessLog) (*Handler, error) {
 		go func() {
 			defer logHandler.wg.Done()
 			for handlerParams := range logHandler.logHandlerChan {
+				if handlerParams.connection {
+					logHandler.logTheConnection(handlerParams.logDataTable)
+					continue
+				}
 				logHandler.logTheRoundTrip(handlerParams.logDataTable)
 			}
 		}()
@@ -142,6 +194,61 @@ func NewHandler(config *types.AccessLog) (*Handler, error) {
 	return logHandler, nil
 }
 
+// newSinks creates the additional outputs of the access logs.
+func newSinks(config *types.AccessLog) ([]*bufferedSink, error) {
+	var sinks []*bufferedSink
+
+	if config.Syslog != nil {
+		sink, err := newSyslogSink(config.Syslog)
+		if err != nil {
+			return nil, fmt.Errorf("error creating access log syslog output: %w", err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if config.HTTP != nil {
+		sink, err := newHTTPSink(config.HTTP, config.Format)
+		if err != nil {
+			closeSinks(sinks)
+			return nil, fmt.Errorf("error creating access log HTTP output: %w", err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return sinks, nil
+}
+
+// SetDroppedLinesCounter sets the counter exporting the number of lines dropped by the additional outputs.
+func (h *Handler) SetDroppedLinesCounter(counter gokitmetrics.Counter) {
+	for _, sink := range h.sinks {
+		sink.SetDroppedCounter(counter)
+	}
+}
+
+func closeSinks(sinks []*bufferedSink) {
+	for _, sink := range sinks {
+		if err := sink.Close(); err != nil {
+			log.WithoutContext().Errorf("Error while closing access log %s output: %v", sink.name, err)
+		}
+	}
+}
+
+// output returns the writer of the access log lines, writing them to the file and to the additional outputs.
+func (h *Handler) output() io.Writer {
+	var writers []io.Writer
+	if h.file != nil {
+		writers = append(writers, h.file)
+	}
+	for _, sink := range h.sinks {
+		writers = append(writers, sink)
+	}
+
+	if len(writers) == 1 {
+		return writers[0]
+	}
+	return io.MultiWriter(writers...)
+}
+
 func openAccessLogFile(filePath string) (*os.File, error) {
 	dir := filepath.Dir(filePath)
 
@@ -212,6 +319,9 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http
 		core[RequestScheme] = "https"
 		core[TLSVersion] = traefiktls.GetVersion(req.TLS)
 		core[TLSCipher] = traefiktls.GetCipherName(req.TLS)
+		if fingerprint := ja3.FromContext(req.Context()); fingerprint != "" {
+			core[TLSClientJA3] = fingerprint
+		}
 	}
 
 	core[ClientAddr] = req.RemoteAddr
@@ -251,6 +361,12 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http
 func (h *Handler) Close() error {
 	close(h.logHandlerChan)
 	h.wg.Wait()
+
+	closeSinks(h.sinks)
+
+	if h.file == nil {
+		return nil
+	}
 	return h.file.Close()
 }
 
@@ -271,7 +387,8 @@ func (h *Handler) Rotate() error {
 	}
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	h.logger.Out = h.file
+	h.logger.Out = h.output()
+	h.connLogger.Out = h.output()
 	return nil
 }
 
@@ -310,7 +427,12 @@ func (h *Handler) logTheRoundTrip(logDataTable *LogData) {
 	totalDuration := time.Now().UTC().Sub(core[StartUTC].(time.Time))
 	core[Duration] = totalDuration
 
-	if h.keepAccessLog(status, retryAttempts, totalDuration) {
+	p := h.policy
+	if logDataTable.policy != nil {
+		p = logDataTable.policy
+	}
+
+	if p.keep(status, retryAttempts, totalDuration) {
 		size := logDataTable.DownstreamResponse.size
 		core[DownstreamContentSize] = size
 		if original, ok := core[OriginContentSize]; ok {
@@ -328,14 +450,14 @@ func (h *Handler) logTheRoundTrip(logDataTable *LogData) {
 		fields := logrus.Fields{}
 
 		for k, v := range logDataTable.Core {
-			if h.config.Fields.Keep(k) {
+			if p.fields.Keep(k) {
 				fields[k] = v
 			}
 		}
 
-		h.redactHeaders(logDataTable.Request.headers, fields, "request_")
-		h.redactHeaders(logDataTable.OriginResponse, fields, "origin_")
-		h.redactHeaders(logDataTable.DownstreamResponse.headers, fields, "downstream_")
+		redactHeaders(p.fields, logDataTable.Request.headers, fields, "request_")
+		redactHeaders(p.fields, logDataTable.OriginResponse, fields, "origin_")
+		redactHeaders(p.fields, logDataTable.DownstreamResponse.headers, fields, "downstream_")
 
 		h.mu.Lock()
 		defer h.mu.Unlock()
@@ -343,9 +465,9 @@ func (h *Handler) logTheRoundTrip(logDataTable *LogData) {
 	}
 }
 
-func (h *Handler) redactHeaders(headers http.Header, fields logrus.Fields, prefix string) {
+func redactHeaders(config *types.AccessLogFields, headers http.Header, fields logrus.Fields, prefix string) {
 	for k := range headers {
-		v := h.config.Fields.KeepHeader(k)
+		v := config.KeepHeader(k)
 		if v == types.AccessLogKeep {
 			fields[prefix+k] = headers.Get(k)
 		} else if v == types.AccessLogRedact {
@@ -354,32 +476,6 @@ func (h *Handler) redactHeaders(headers http.Header, fields logrus.Fields, prefi
 	}
 }
 
-func (h *Handler) keepAccessLog(statusCode, retryAttempts int, duration time.Duration) bool {
-	if h.config.Filters == nil {
-		// no filters were specified
-		return true
-	}
-
-	if len(h.httpCodeRanges) == 0 && !h.config.Filters.RetryAttempts && h.config.Filters.MinDuration == 0 {
-		// empty filters were specified, e.g. by passing --accessLog.filters only (without other filter options)
-		return true
-	}
-
-	if h.httpCodeRanges.Contains(statusCode) {
-		return true
-	}
-
-	if h.config.Filters.RetryAttempts && retryAttempts > 0 {
-		return true
-	}
-
-	if h.config.Filters.MinDuration > 0 && (ptypes.Duration(duration) > h.config.Filters.MinDuration) {
-		return true
-	}
-
-	return false
-}
-
 var requestCounter uint64 // Request ID
 
 func nextRequestCount() uint64 {
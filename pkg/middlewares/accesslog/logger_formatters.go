@@ -3,9 +3,13 @@ package accesslog
 import (
 	"bytes"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/traefik/traefik/v2/pkg/version"
 )
 
 // default format for time presentation.
@@ -52,6 +56,211 @@ func (f *CommonLogFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	return b.Bytes(), err
 }
 
+// CommonConnLogFormatter provides formatting of the TCP connections and UDP sessions in a format close to the Traefik common log format.
+type CommonConnLogFormatter struct{}
+
+// Format formats the log entry of a TCP connection or a UDP session.
+func (f *CommonConnLogFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	b := &bytes.Buffer{}
+
+	timestamp := defaultValue
+	if v, ok := entry.Data[StartUTC]; ok {
+		timestamp = v.(time.Time).Format(commonLogTimeFormat)
+	} else if v, ok := entry.Data[StartLocal]; ok {
+		timestamp = v.(time.Time).Local().Format(commonLogTimeFormat)
+	}
+
+	var elapsedMillis int64
+	if v, ok := entry.Data[Duration]; ok {
+		elapsedMillis = v.(time.Duration).Nanoseconds() / 1000000
+	}
+
+	_, err := fmt.Fprintf(b, "%s - - [%s] \"%s %s\" %v %v %v %s %s %s %dms\n",
+		toLog(entry.Data, ClientHost, defaultValue, false),
+		timestamp,
+		toLog(entry.Data, RequestProtocol, defaultValue, false),
+		toLog(entry.Data, TLSServerName, defaultValue, false),
+		toLog(entry.Data, RequestContentSize, defaultValue, true),
+		toLog(entry.Data, DownstreamContentSize, defaultValue, true),
+		toLog(entry.Data, RequestCount, defaultValue, true),
+		toLog(entry.Data, RouterName, `"-"`, true),
+		toLog(entry.Data, ServiceAddr, `"-"`, true),
+		toLog(entry.Data, CloseReason, `"-"`, true),
+		elapsedMillis)
+
+	return b.Bytes(), err
+}
+
+// LogfmtFormatter provides formatting in the logfmt format, i.e. a line of space separated key=value pairs.
+type LogfmtFormatter struct{}
+
+// Format formats the log entry in the logfmt format.
+func (f *LogfmtFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	b := &bytes.Buffer{}
+
+	keys := make([]string, 0, len(entry.Data))
+	for k := range entry.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+
+		b.WriteString(k)
+		b.WriteByte('=')
+
+		value := formatValue(entry.Data[k], time.RFC3339Nano)
+		if needsLogfmtQuoting(value) {
+			value = strconv.Quote(value)
+		}
+		b.WriteString(value)
+	}
+
+	b.WriteByte('\n')
+
+	return b.Bytes(), nil
+}
+
+func needsLogfmtQuoting(value string) bool {
+	if value == "" || strings.ContainsAny(value, " =\"\\") {
+		return true
+	}
+
+	return strings.IndexFunc(value, func(r rune) bool { return !strconv.IsPrint(r) }) >= 0
+}
+
+// cefExtension maps a log field to a CEF extension key.
+type cefExtension struct {
+	key   string
+	field string
+	// label is the label of the custom extension keys (csN and cnN).
+	label string
+}
+
+var cefExtensions = []cefExtension{
+	{key: "rt", field: StartUTC},
+	{key: "src", field: ClientHost},
+	{key: "spt", field: ClientPort},
+	{key: "suser", field: ClientUsername},
+	{key: "dhost", field: RequestHost},
+	{key: "dpt", field: RequestPort},
+	{key: "app", field: RequestProtocol},
+	{key: "requestMethod", field: RequestMethod},
+	{key: "request", field: RequestPath},
+	{key: "requestClientApplication", field: RequestUserAgentHeader},
+	{key: "in", field: RequestContentSize},
+	{key: "out", field: DownstreamContentSize},
+	{key: "cn1", field: DownstreamStatus, label: "DownstreamStatus"},
+	{key: "cn2", field: Duration, label: "DurationMs"},
+	{key: "cs1", field: RouterName, label: "RouterName"},
+	{key: "cs2", field: ServiceName, label: "ServiceName"},
+	{key: "cs3", field: ServiceAddr, label: "ServiceAddr"},
+	{key: "cs4", field: CloseReason, label: "CloseReason"},
+}
+
+// CEFFormatter provides formatting in the ArcSight Common Event Format (CEF).
+type CEFFormatter struct{}
+
+// Format formats the log entry in the Common Event Format.
+func (f *CEFFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	b := &bytes.Buffer{}
+
+	signatureID, name := "http", "HTTP request"
+	switch entry.Data[RequestProtocol] {
+	case protoTCP:
+		signatureID, name = "tcp", "TCP connection"
+	case protoUDP:
+		signatureID, name = "udp", "UDP session"
+	}
+
+	severity := 3
+	status, _ := entry.Data[DownstreamStatus].(int)
+	switch {
+	case status >= 500:
+		severity = 7
+	case status >= 400:
+		severity = 5
+	}
+	if reason, ok := entry.Data[CloseReason].(string); ok && reason != closeReasonClosed {
+		severity = 5
+	}
+
+	_, err := fmt.Fprintf(b, "CEF:0|Traefik|Traefik|%s|%s|%s|%d|",
+		cefHeaderEscaper.Replace(version.Version), signatureID, name, severity)
+	if err != nil {
+		return nil, err
+	}
+
+	first := true
+	for _, ext := range cefExtensions {
+		v, ok := entry.Data[ext.field]
+		if !ok {
+			continue
+		}
+
+		var value string
+		switch typed := v.(type) {
+		case time.Time:
+			value = strconv.FormatInt(typed.UnixNano()/int64(time.Millisecond), 10)
+		case time.Duration:
+			value = strconv.FormatInt(typed.Milliseconds(), 10)
+		default:
+			value = formatValue(v, time.RFC3339Nano)
+		}
+
+		if value == "" || value == defaultValue {
+			continue
+		}
+
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+
+		b.WriteString(ext.key)
+		b.WriteByte('=')
+		b.WriteString(cefExtensionEscaper.Replace(value))
+
+		if ext.label != "" {
+			b.WriteByte(' ')
+			b.WriteString(ext.key)
+			b.WriteString("Label=")
+			b.WriteString(ext.label)
+		}
+	}
+
+	b.WriteByte('\n')
+
+	return b.Bytes(), nil
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+// formatValue renders a field value as text, the times being formatted with the given layout,
+// and the durations as a number of nanoseconds (as in the JSON format).
+func formatValue(v interface{}, timeLayout string) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case time.Time:
+		return value.Format(timeLayout)
+	case time.Duration:
+		return strconv.FormatInt(int64(value), 10)
+	case fmt.Stringer:
+		return value.String()
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
 func toLog(fields logrus.Fields, key, defaultValue string, quoted bool) interface{} {
 	if v, ok := fields[key]; ok {
 		if v == nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCommonLogFormatter_Format(t *testing.T) {
@@ -101,6 +102,140 @@ func TestCommonLogFormatter_Format(t *testing.T) {
 	}
 }
 
+func TestCommonConnLogFormatter_Format(t *testing.T) {
+	clf := CommonConnLogFormatter{}
+
+	testCases := []struct {
+		name        string
+		data        map[string]interface{}
+		expectedLog string
+	}{
+		{
+			name: "TCP connection without SNI",
+			data: map[string]interface{}{
+				StartUTC:              time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+				Duration:              123 * time.Second,
+				ClientHost:            "10.0.0.1",
+				RequestProtocol:       "TCP",
+				RequestContentSize:    int64(12),
+				DownstreamContentSize: int64(34),
+				RequestCount:          1,
+				RouterName:            "foo",
+				CloseReason:           "closed",
+			},
+			expectedLog: `10.0.0.1 - - [10/Nov/2009:23:00:00 +0000] "TCP -" 12 34 1 "foo" "-" "closed" 123000ms
+`,
+		},
+		{
+			name: "all data",
+			data: map[string]interface{}{
+				StartUTC:              time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+				Duration:              123 * time.Second,
+				ClientHost:            "10.0.0.1",
+				RequestProtocol:       "TCP",
+				TLSServerName:         "example.com",
+				RequestContentSize:    int64(12),
+				DownstreamContentSize: int64(34),
+				RequestCount:          1,
+				RouterName:            "foo",
+				ServiceAddr:           "10.0.0.2:8080",
+				CloseReason:           "closed",
+			},
+			expectedLog: `10.0.0.1 - - [10/Nov/2009:23:00:00 +0000] "TCP example.com" 12 34 1 "foo" "10.0.0.2:8080" "closed" 123000ms
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry := &logrus.Entry{Data: test.data}
+
+			raw, err := clf.Format(entry)
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.expectedLog, string(raw))
+		})
+	}
+}
+
+func TestLogfmtFormatter_Format(t *testing.T) {
+	formatter := LogfmtFormatter{}
+
+	entry := &logrus.Entry{Data: map[string]interface{}{
+		StartUTC:         time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+		Duration:         123 * time.Millisecond,
+		ClientHost:       "10.0.0.1",
+		RequestMethod:    http.MethodGet,
+		RequestPath:      "/foo bar",
+		RouterName:       "",
+		DownstreamStatus: 200,
+		"request_Quote":  `say "hello"`,
+	}}
+
+	raw, err := formatter.Format(entry)
+	require.NoError(t, err)
+
+	expected := `ClientHost=10.0.0.1 DownstreamStatus=200 Duration=123000000 RequestMethod=GET RequestPath="/foo bar" RouterName="" StartUTC=2009-11-10T23:00:00Z request_Quote="say \"hello\""
+`
+	assert.Equal(t, expected, string(raw))
+}
+
+func TestCEFFormatter_Format(t *testing.T) {
+	formatter := CEFFormatter{}
+
+	testCases := []struct {
+		desc        string
+		data        map[string]interface{}
+		expectedLog string
+	}{
+		{
+			desc: "HTTP request",
+			data: map[string]interface{}{
+				StartUTC:           time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+				Duration:           123 * time.Millisecond,
+				ClientHost:         "10.0.0.1",
+				ClientPort:         "4242",
+				ClientUsername:     "-",
+				RequestMethod:      http.MethodGet,
+				RequestPath:        "/foo?bar=baz",
+				RequestProtocol:    "HTTP/1.1",
+				RequestContentSize: int64(0),
+				DownstreamStatus:   503,
+				RouterName:         "foo@file",
+			},
+			expectedLog: `CEF:0|Traefik|Traefik|dev|http|HTTP request|7|rt=1257894000000 src=10.0.0.1 spt=4242 app=HTTP/1.1 requestMethod=GET request=/foo?bar\=baz in=0 cn1=503 cn1Label=DownstreamStatus cn2=123 cn2Label=DurationMs cs1=foo@file cs1Label=RouterName
+`,
+		},
+		{
+			desc: "TCP connection",
+			data: map[string]interface{}{
+				ClientHost:            "10.0.0.1",
+				RequestProtocol:       "TCP",
+				DownstreamContentSize: int64(42),
+				ServiceAddr:           "10.0.0.2:8080",
+				CloseReason:           "closed",
+			},
+			expectedLog: `CEF:0|Traefik|Traefik|dev|tcp|TCP connection|3|src=10.0.0.1 app=TCP out=42 cs3=10.0.0.2:8080 cs3Label=ServiceAddr cs4=closed cs4Label=CloseReason
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			raw, err := formatter.Format(&logrus.Entry{Data: test.data})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedLog, string(raw))
+		})
+	}
+}
+
 func Test_toLog(t *testing.T) {
 	testCases := []struct {
 		desc         string
@@ -0,0 +1,112 @@
+package accesslog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TemplateFormatter provides formatting with a user-defined template,
+// in which the $Field and ${Field} placeholders are replaced by the values of the fields.
+type TemplateFormatter struct {
+	segments []templateSegment
+}
+
+// templateSegment is either a literal part of the template, or a field placeholder.
+type templateSegment struct {
+	literal string
+	field   string
+}
+
+// NewTemplateFormatter parses the template and creates a new TemplateFormatter.
+func NewTemplateFormatter(template string) (*TemplateFormatter, error) {
+	if template == "" {
+		return nil, errors.New("empty access log template")
+	}
+
+	var segments []templateSegment
+	var literal strings.Builder
+
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			segments = append(segments, templateSegment{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(template); i++ {
+		if template[i] != '$' || i == len(template)-1 {
+			literal.WriteByte(template[i])
+			continue
+		}
+
+		switch next := template[i+1]; {
+		case next == '$':
+			// $$ is an escaped $.
+			literal.WriteByte('$')
+			i++
+
+		case next == '{':
+			end := strings.IndexByte(template[i+2:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed placeholder at position %d in access log template %q", i, template)
+			}
+
+			field := template[i+2 : i+2+end]
+			if field == "" {
+				return nil, fmt.Errorf("empty placeholder at position %d in access log template %q", i, template)
+			}
+
+			flushLiteral()
+			segments = append(segments, templateSegment{field: field})
+			i += end + 2
+
+		case isFieldNameChar(next):
+			end := i + 1
+			for end < len(template) && isFieldNameChar(template[end]) {
+				end++
+			}
+
+			flushLiteral()
+			segments = append(segments, templateSegment{field: template[i+1 : end]})
+			i = end - 1
+
+		default:
+			literal.WriteByte('$')
+		}
+	}
+
+	flushLiteral()
+
+	return &TemplateFormatter{segments: segments}, nil
+}
+
+func isFieldNameChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// Format formats the log entry with the template,
+// the fields which are absent or empty being rendered as "-".
+func (f *TemplateFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	b := &bytes.Buffer{}
+
+	for _, segment := range f.segments {
+		if segment.field == "" {
+			b.WriteString(segment.literal)
+			continue
+		}
+
+		value := formatValue(entry.Data[segment.field], commonLogTimeFormat)
+		if value == "" {
+			value = defaultValue
+		}
+		b.WriteString(value)
+	}
+
+	b.WriteByte('\n')
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,85 @@
+package accesslog
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFormatter_Format(t *testing.T) {
+	data := map[string]interface{}{
+		StartUTC:               time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+		Duration:               123 * time.Millisecond,
+		ClientHost:             "10.0.0.1",
+		RequestMethod:          http.MethodGet,
+		RequestPath:            "/foo",
+		DownstreamStatus:       200,
+		RequestUserAgentHeader: "agent",
+		RouterName:             "",
+	}
+
+	testCases := []struct {
+		desc        string
+		template    string
+		expectedLog string
+		expectedErr bool
+	}{
+		{
+			desc:        "placeholders",
+			template:    `$ClientHost [$StartUTC] "$RequestMethod $RequestPath" $DownstreamStatus $Duration`,
+			expectedLog: `10.0.0.1 [10/Nov/2009:23:00:00 +0000] "GET /foo" 200 123000000` + "\n",
+		},
+		{
+			desc:        "braced placeholders",
+			template:    `${ClientHost}:${RequestMethod} "${request_User-Agent}"`,
+			expectedLog: `10.0.0.1:GET "agent"` + "\n",
+		},
+		{
+			desc:        "absent and empty fields",
+			template:    `$RouterName $ServiceName`,
+			expectedLog: "- -\n",
+		},
+		{
+			desc:        "escaped and lone dollars",
+			template:    `$$ClientHost costs $ 5$`,
+			expectedLog: "$ClientHost costs $ 5$\n",
+		},
+		{
+			desc:        "unclosed placeholder",
+			template:    `${ClientHost`,
+			expectedErr: true,
+		},
+		{
+			desc:        "empty placeholder",
+			template:    `${}`,
+			expectedErr: true,
+		},
+		{
+			desc:        "empty template",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			formatter, err := NewTemplateFormatter(test.template)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			raw, err := formatter.Format(&logrus.Entry{Data: data})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedLog, string(raw))
+		})
+	}
+}
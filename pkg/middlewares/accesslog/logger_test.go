@@ -1,6 +1,7 @@
 package accesslog
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/tls/ja3"
 	"github.com/traefik/traefik/v2/pkg/types"
 )
 
@@ -37,6 +39,7 @@ var (
 	testReferer             = "testReferer"
 	testUserAgent           = "testUserAgent"
 	testRetryAttempts       = 2
+	testJA3                 = "851235d5e9d490f3e2b43db94ac71961"
 	testStart               = time.Now()
 )
 
@@ -348,6 +351,7 @@ func TestLoggerJSON(t *testing.T) {
 				RetryAttempts:             assertFloat64(float64(testRetryAttempts)),
 				TLSVersion:                assertString("1.3"),
 				TLSCipher:                 assertString("TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"),
+				TLSClientJA3:              assertString(testJA3),
 				"time":                    assertNotEmpty(),
 				StartLocal:                assertNotEmpty(),
 				StartUTC:                  assertNotEmpty(),
@@ -756,6 +760,7 @@ func doLoggingTLSOpt(t *testing.T, config *types.AccessLog, enableTLS bool) {
 			Version:     tls.VersionTLS13,
 			CipherSuite: tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
 		}
+		req = req.WithContext(ja3.WithFingerprint(context.Background(), testJA3))
 	}
 
 	logger.ServeHTTP(httptest.NewRecorder(), req, http.HandlerFunc(logWriterTestHandlerFunc))
@@ -795,3 +800,56 @@ func logWriterTestHandlerFunc(rw http.ResponseWriter, r *http.Request) {
 
 	rw.WriteHeader(testStatus)
 }
+
+func TestKeepConnLog(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		filters  *types.AccessLogFilters
+		duration time.Duration
+		expected bool
+	}{
+		{
+			desc:     "no filters",
+			expected: true,
+		},
+		{
+			desc:     "empty filters",
+			filters:  &types.AccessLogFilters{},
+			expected: true,
+		},
+		{
+			desc:     "status codes filter",
+			filters:  &types.AccessLogFilters{StatusCodes: []string{"400-599"}},
+			expected: true,
+		},
+		{
+			desc:     "retry attempts filter",
+			filters:  &types.AccessLogFilters{RetryAttempts: true},
+			expected: true,
+		},
+		{
+			desc:     "shorter than the min duration",
+			filters:  &types.AccessLogFilters{MinDuration: ptypes.Duration(time.Second)},
+			duration: time.Millisecond,
+			expected: false,
+		},
+		{
+			desc:     "longer than the min duration",
+			filters:  &types.AccessLogFilters{StatusCodes: []string{"200"}, MinDuration: ptypes.Duration(time.Second)},
+			duration: time.Minute,
+			expected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler, err := NewHandler(&types.AccessLog{Format: CommonFormat, Filters: test.filters})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, handler.policy.keepConn(test.duration))
+		})
+	}
+}
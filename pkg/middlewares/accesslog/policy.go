@@ -0,0 +1,211 @@
+package accesslog
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/textproto"
+	"time"
+
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// policy holds the rules deciding which access logs are written, and with which fields:
+// either the global ones, or the ones of a router overriding them.
+type policy struct {
+	disabled       bool
+	sampleRate     float64
+	filters        *types.AccessLogFilters
+	httpCodeRanges types.HTTPCodeRanges
+	fields         *types.AccessLogFields
+	bodies         *bodyCapture
+}
+
+// override returns the policy resulting from the given configuration overriding the receiver.
+func (p *policy) override(config *types.AccessLogPolicy) (*policy, error) {
+	if config.Disabled {
+		return &policy{disabled: true}, nil
+	}
+
+	if err := checkSampleRate(config.SampleRate); err != nil {
+		return nil, err
+	}
+
+	result := *p
+
+	if config.SampleRate != 0 {
+		result.sampleRate = config.SampleRate
+	}
+
+	if config.Filters != nil {
+		httpCodeRanges, err := types.NewHTTPCodeRanges(config.Filters.StatusCodes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new HTTP code ranges: %w", err)
+		}
+
+		result.filters = config.Filters
+		result.httpCodeRanges = httpCodeRanges
+	}
+
+	if config.Fields != nil {
+		fields := config.Fields.DeepCopy()
+		if fields.Headers == nil && p.fields != nil {
+			// the global configuration of the headers applies, they must not be kept by default.
+			fields.Headers = p.fields.Headers
+		}
+
+		result.fields = canonicalHeaderNames(fields)
+	}
+
+	if config.Bodies != nil {
+		bodies, err := newBodyCapture(config.Bodies)
+		if err != nil {
+			return nil, err
+		}
+
+		result.bodies = bodies
+	}
+
+	return &result, nil
+}
+
+func checkSampleRate(sampleRate float64) error {
+	if sampleRate < 0 || sampleRate > 1 {
+		return fmt.Errorf("invalid access log sample rate %v: must be between 0 and 1", sampleRate)
+	}
+	return nil
+}
+
+// keep checks the filters and the sampling against a request.
+func (p *policy) keep(statusCode, retryAttempts int, duration time.Duration) bool {
+	if p.disabled {
+		return false
+	}
+
+	return p.keepFiltered(statusCode, retryAttempts, duration) && p.sample()
+}
+
+func (p *policy) keepFiltered(statusCode, retryAttempts int, duration time.Duration) bool {
+	if p.filters == nil {
+		// no filters were specified
+		return true
+	}
+
+	if len(p.httpCodeRanges) == 0 && !p.filters.RetryAttempts && p.filters.MinDuration == 0 {
+		// empty filters were specified, e.g. by passing --accessLog.filters only (without other filter options)
+		return true
+	}
+
+	if p.httpCodeRanges.Contains(statusCode) {
+		return true
+	}
+
+	if p.filters.RetryAttempts && retryAttempts > 0 {
+		return true
+	}
+
+	if p.filters.MinDuration > 0 && (ptypes.Duration(duration) > p.filters.MinDuration) {
+		return true
+	}
+
+	return false
+}
+
+// keepConn checks the filters and the sampling against a TCP connection or a UDP session,
+// only the minDuration filter being relevant to them.
+func (p *policy) keepConn(duration time.Duration) bool {
+	if p.disabled {
+		return false
+	}
+
+	return p.keepConnFiltered(duration) && p.sample()
+}
+
+// keepConnFiltered ignores the statusCodes and retryAttempts filters, which only apply to the HTTP requests,
+// so that they do not drop all the connections and sessions.
+func (p *policy) keepConnFiltered(duration time.Duration) bool {
+	if p.filters == nil || p.filters.MinDuration == 0 {
+		return true
+	}
+
+	return ptypes.Duration(duration) > p.filters.MinDuration
+}
+
+func (p *policy) sample() bool {
+	if p.sampleRate == 0 || p.sampleRate == 1 {
+		return true
+	}
+
+	return rand.Float64() < p.sampleRate
+}
+
+// canonicalHeaderNames returns the fields configuration with the headers names in a canonical form,
+// to be used as is without further transformations.
+func canonicalHeaderNames(fields *types.AccessLogFields) *types.AccessLogFields {
+	if fields == nil || fields.Headers == nil || len(fields.Headers.Names) == 0 {
+		return fields
+	}
+
+	result := fields.DeepCopy()
+	result.Headers.Names = map[string]string{}
+
+	for h, v := range fields.Headers.Names {
+		result.Headers.Names[textproto.CanonicalMIMEHeaderKey(h)] = v
+	}
+
+	return result
+}
+
+// PolicyHandler overrides the access log policy for the requests it handles.
+type PolicyHandler struct {
+	next   http.Handler
+	policy *policy
+}
+
+// NewPolicyHandler creates a handler overriding the access log policy of the given handler with the given configuration.
+func NewPolicyHandler(handler *Handler, next http.Handler, config *types.AccessLogPolicy) (http.Handler, error) {
+	if handler == nil || config == nil {
+		return next, nil
+	}
+
+	p, err := handler.policy.override(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PolicyHandler{next: next, policy: p}, nil
+}
+
+func (p *PolicyHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	table := GetLogData(req)
+	if table == nil {
+		p.next.ServeHTTP(rw, req)
+		return
+	}
+
+	table.policy = p.policy
+
+	if p.policy.bodies == nil {
+		p.next.ServeHTTP(rw, req)
+		return
+	}
+
+	var cbr *captureBodyReader
+	if req.Body != nil && req.Body != http.NoBody && p.policy.bodies.captures(req.Header) {
+		cbr = &captureBodyReader{source: req.Body, maxSize: p.policy.bodies.captureSize()}
+		req.Body = cbr
+	}
+
+	crw := newCaptureBodyResponseWriter(rw, p.policy.bodies)
+
+	p.next.ServeHTTP(crw, req)
+
+	if cbr != nil {
+		table.Core[RequestBody] = p.policy.bodies.redact(cbr.body.Bytes())
+	}
+
+	if body, ok := crw.Body(); ok {
+		table.Core[ResponseBody] = p.policy.bodies.redact(body)
+	}
+}
@@ -0,0 +1,264 @@
+package accesslog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+const redactedBody = "REDACTED"
+
+// bodyRedactionMargin is the number of bytes captured past the maximum size of a body when it has redactions,
+// so that the secrets crossing the limit are still matched before the body is truncated.
+const bodyRedactionMargin = 4096
+
+// bodyCapture holds the configuration of the capture of the request and response bodies, ready to be used.
+type bodyCapture struct {
+	maxSize      int
+	contentTypes []string
+	redactions   []*regexp.Regexp
+}
+
+func newBodyCapture(config *types.AccessLogBodies) (*bodyCapture, error) {
+	capture := &bodyCapture{maxSize: config.MaxSize}
+	if capture.maxSize <= 0 {
+		defaults := &types.AccessLogBodies{}
+		defaults.SetDefaults()
+		capture.maxSize = defaults.MaxSize
+	}
+
+	for _, contentType := range config.ContentTypes {
+		capture.contentTypes = append(capture.contentTypes, strings.ToLower(strings.TrimSpace(contentType)))
+	}
+
+	for _, redaction := range config.Redactions {
+		exp, err := regexp.Compile(redaction)
+		if err != nil {
+			return nil, fmt.Errorf("invalid body redaction %q: %w", redaction, err)
+		}
+
+		capture.redactions = append(capture.redactions, exp)
+	}
+
+	return capture, nil
+}
+
+// captureSize returns the number of bytes to capture from a body.
+func (c *bodyCapture) captureSize() int {
+	if len(c.redactions) == 0 {
+		return c.maxSize
+	}
+	return c.maxSize + bodyRedactionMargin
+}
+
+// captures checks whether a body with the given headers has to be captured.
+// Encoded (e.g. compressed) bodies are never captured, as they would not be readable.
+func (c *bodyCapture) captures(header http.Header) bool {
+	if encoding := header.Get("Content-Encoding"); encoding != "" && !strings.EqualFold(encoding, "identity") {
+		return false
+	}
+
+	if len(c.contentTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	for _, contentType := range c.contentTypes {
+		if contentType == mediaType {
+			return true
+		}
+
+		if strings.HasSuffix(contentType, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(contentType, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redact returns the captured body with the parts matching the redactions replaced,
+// truncated to the maximum size once redacted.
+func (c *bodyCapture) redact(body []byte) string {
+	limit := c.maxSize
+	if len(body) < limit {
+		limit = len(body)
+	}
+
+	for _, exp := range c.redactions {
+		body, limit = redactMatches(exp, body, limit)
+	}
+
+	return string(body[:limit])
+}
+
+// redactMatches replaces the capturing groups of the matches of the expression,
+// or the whole matches if the expression has no capturing group.
+// It also returns the position of the limit in the redacted body,
+// a replacement crossing the limit being kept whole.
+func redactMatches(exp *regexp.Regexp, body []byte, limit int) ([]byte, int) {
+	matches := exp.FindAllSubmatchIndex(body, -1)
+	if len(matches) == 0 {
+		return body, limit
+	}
+
+	var result []byte
+	last := 0
+	newLimit := -1
+
+	for _, match := range matches {
+		groups := [][2]int{{match[0], match[1]}}
+		if exp.NumSubexp() > 0 {
+			groups = groups[:0]
+			for i := 2; i < len(match); i += 2 {
+				if match[i] >= last {
+					groups = append(groups, [2]int{match[i], match[i+1]})
+				}
+			}
+		}
+
+		for _, group := range groups {
+			if newLimit < 0 && limit <= group[0] {
+				newLimit = len(result) + limit - last
+			}
+
+			result = append(result, body[last:group[0]]...)
+			result = append(result, redactedBody...)
+			last = group[1]
+
+			if newLimit < 0 && limit <= last {
+				newLimit = len(result)
+			}
+		}
+	}
+
+	if newLimit < 0 {
+		newLimit = len(result) + limit - last
+	}
+
+	return append(result, body[last:]...), newLimit
+}
+
+// captureBodyReader is a wrapper of the request body keeping its first bytes.
+type captureBodyReader struct {
+	source  io.ReadCloser
+	maxSize int
+	body    bytes.Buffer
+}
+
+func (r *captureBodyReader) Read(p []byte) (int, error) {
+	n, err := r.source.Read(p)
+
+	if remaining := r.maxSize - r.body.Len(); remaining > 0 {
+		if remaining > n {
+			remaining = n
+		}
+		r.body.Write(p[:remaining])
+	}
+
+	return n, err
+}
+
+func (r *captureBodyReader) Close() error {
+	return r.source.Close()
+}
+
+type bodyCapturer interface {
+	http.ResponseWriter
+	// Body returns the beginning of the response body, and whether it was captured.
+	Body() ([]byte, bool)
+}
+
+func newCaptureBodyResponseWriter(rw http.ResponseWriter, capture *bodyCapture) bodyCapturer {
+	capt := &captureBodyResponseWriter{rw: rw, capture: capture}
+	if _, ok := rw.(http.CloseNotifier); !ok {
+		return capt
+	}
+	return &captureBodyResponseWriterWithCloseNotify{capt}
+}
+
+// captureBodyResponseWriter is a wrapper of type http.ResponseWriter
+// that keeps the first bytes of the response body.
+type captureBodyResponseWriter struct {
+	rw      http.ResponseWriter
+	capture *bodyCapture
+	// checked is set once the headers are written, captured being then known.
+	checked  bool
+	captured bool
+	body     bytes.Buffer
+}
+
+type captureBodyResponseWriterWithCloseNotify struct {
+	*captureBodyResponseWriter
+}
+
+// CloseNotify returns a channel that receives at most a
+// single value (true) when the client connection has gone away.
+func (r *captureBodyResponseWriterWithCloseNotify) CloseNotify() <-chan bool {
+	return r.rw.(http.CloseNotifier).CloseNotify()
+}
+
+func (crw *captureBodyResponseWriter) Header() http.Header {
+	return crw.rw.Header()
+}
+
+func (crw *captureBodyResponseWriter) Write(b []byte) (int, error) {
+	crw.check()
+
+	if crw.captured {
+		if remaining := crw.capture.captureSize() - crw.body.Len(); remaining > 0 {
+			if remaining > len(b) {
+				remaining = len(b)
+			}
+			crw.body.Write(b[:remaining])
+		}
+	}
+
+	return crw.rw.Write(b)
+}
+
+func (crw *captureBodyResponseWriter) WriteHeader(s int) {
+	crw.check()
+	crw.rw.WriteHeader(s)
+}
+
+// check decides, once the headers are final, whether the body is captured.
+func (crw *captureBodyResponseWriter) check() {
+	if crw.checked {
+		return
+	}
+
+	crw.checked = true
+	crw.captured = crw.capture.captures(crw.rw.Header())
+}
+
+func (crw *captureBodyResponseWriter) Flush() {
+	if f, ok := crw.rw.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (crw *captureBodyResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := crw.rw.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, fmt.Errorf("not a hijacker: %T", crw.rw)
+}
+
+func (crw *captureBodyResponseWriter) Body() ([]byte, bool) {
+	if !crw.captured {
+		return nil, false
+	}
+	return crw.body.Bytes(), true
+}
@@ -0,0 +1,229 @@
+package accesslog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestPolicyHandler_bodies(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		bodies              *types.AccessLogBodies
+		requestContentType  string
+		responseContentType string
+		responseEncoding    string
+		expectedRequest     interface{}
+		expectedResponse    interface{}
+	}{
+		{
+			desc:                "all content types",
+			bodies:              &types.AccessLogBodies{MaxSize: 1024},
+			requestContentType:  "application/json",
+			responseContentType: "text/plain; charset=utf-8",
+			expectedRequest:     `{"user":"foo","password":"secret"}`,
+			expectedResponse:    "hello foo",
+		},
+		{
+			desc:                "truncated bodies",
+			bodies:              &types.AccessLogBodies{MaxSize: 5},
+			requestContentType:  "application/json",
+			responseContentType: "text/plain",
+			expectedRequest:     `{"use`,
+			expectedResponse:    "hello",
+		},
+		{
+			desc:                "filtered content types",
+			bodies:              &types.AccessLogBodies{MaxSize: 1024, ContentTypes: []string{"application/json"}},
+			requestContentType:  "application/json",
+			responseContentType: "text/plain",
+			expectedRequest:     `{"user":"foo","password":"secret"}`,
+		},
+		{
+			desc:                "wildcard content types",
+			bodies:              &types.AccessLogBodies{MaxSize: 1024, ContentTypes: []string{"Text/*"}},
+			requestContentType:  "application/json",
+			responseContentType: "text/plain",
+			expectedResponse:    "hello foo",
+		},
+		{
+			desc:                "encoded response",
+			bodies:              &types.AccessLogBodies{MaxSize: 1024},
+			requestContentType:  "application/json",
+			responseContentType: "text/plain",
+			responseEncoding:    "gzip",
+			expectedRequest:     `{"user":"foo","password":"secret"}`,
+		},
+		{
+			desc: "redactions",
+			bodies: &types.AccessLogBodies{
+				MaxSize:    1024,
+				Redactions: []string{`"password":"([^"]*)"`, `foo`},
+			},
+			requestContentType:  "application/json",
+			responseContentType: "text/plain",
+			expectedRequest:     `{"user":"REDACTED","password":"REDACTED"}`,
+			expectedResponse:    "hello REDACTED",
+		},
+		{
+			desc: "redactions crossing the limit",
+			bodies: &types.AccessLogBodies{
+				MaxSize:    29,
+				Redactions: []string{`"password":"([^"]*)"`, `foo`},
+			},
+			requestContentType:  "application/json",
+			responseContentType: "text/plain",
+			expectedRequest:     `{"user":"REDACTED","password":"REDACTED`,
+			expectedResponse:    "hello REDACTED",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			logFile, err := os.CreateTemp(t.TempDir(), "*.log")
+			require.NoError(t, err)
+
+			config := &types.AccessLog{FilePath: logFile.Name(), Format: JSONFormat}
+			config.Fields = &types.AccessLogFields{}
+			config.Fields.SetDefaults()
+
+			handler, err := NewHandler(config)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = handler.Close() })
+
+			next, err := NewPolicyHandler(handler, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := ioutil.ReadAll(req.Body)
+				require.NoError(t, err)
+				assert.Equal(t, `{"user":"foo","password":"secret"}`, string(body))
+
+				rw.Header().Set("Content-Type", test.responseContentType)
+				if test.responseEncoding != "" {
+					rw.Header().Set("Content-Encoding", test.responseEncoding)
+				}
+				rw.WriteHeader(http.StatusOK)
+				_, _ = rw.Write([]byte("hello "))
+				_, _ = rw.Write([]byte("foo"))
+			}), &types.AccessLogPolicy{Bodies: test.bodies})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost/foo", strings.NewReader(`{"user":"foo","password":"secret"}`))
+			req.Header.Set("Content-Type", test.requestContentType)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req, next)
+
+			assert.Equal(t, "hello foo", rw.Body.String())
+
+			logData, err := os.ReadFile(logFile.Name())
+			require.NoError(t, err)
+
+			entry := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(logData, &entry))
+
+			assert.Equal(t, test.expectedRequest, entry[RequestBody])
+			assert.Equal(t, test.expectedResponse, entry[ResponseBody])
+		})
+	}
+}
+
+func TestNewPolicyHandler_invalidRedaction(t *testing.T) {
+	handler, err := NewHandler(&types.AccessLog{Format: CommonFormat})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = handler.Close() })
+
+	_, err = NewPolicyHandler(handler, http.NotFoundHandler(), &types.AccessLogPolicy{
+		Bodies: &types.AccessLogBodies{Redactions: []string{"("}},
+	})
+	assert.Error(t, err)
+}
+
+func TestRedactMatches(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		exp           string
+		body          string
+		limit         int
+		expected      string
+		expectedLimit int
+	}{
+		{
+			desc:          "no match",
+			exp:           `secret`,
+			body:          `foo=bar`,
+			limit:         5,
+			expected:      `foo=bar`,
+			expectedLimit: 5,
+		},
+		{
+			desc:          "whole matches",
+			exp:           `\d{4}`,
+			body:          `card=1234 5678`,
+			limit:         14,
+			expected:      `card=REDACTED REDACTED`,
+			expectedLimit: 22,
+		},
+		{
+			desc:          "capturing groups",
+			exp:           `token=(\w+)&key=(\w+)`,
+			body:          `token=foo&key=bar&token=baz&key=qux`,
+			limit:         35,
+			expected:      `token=REDACTED&key=REDACTED&token=REDACTED&key=REDACTED`,
+			expectedLimit: 55,
+		},
+		{
+			desc:          "unmatched optional group",
+			exp:           `pin=(\d+)?;`,
+			body:          `pin=;pin=42;`,
+			limit:         12,
+			expected:      `pin=;pin=REDACTED;`,
+			expectedLimit: 18,
+		},
+		{
+			desc:          "limit before a match",
+			exp:           `\d{4}`,
+			body:          `card=1234 5678`,
+			limit:         3,
+			expected:      `card=REDACTED REDACTED`,
+			expectedLimit: 3,
+		},
+		{
+			desc:          "limit between matches",
+			exp:           `\d{4}`,
+			body:          `card=1234 5678`,
+			limit:         10,
+			expected:      `card=REDACTED REDACTED`,
+			expectedLimit: 14,
+		},
+		{
+			desc:          "limit crossing a match",
+			exp:           `\d{4}`,
+			body:          `card=1234 5678`,
+			limit:         12,
+			expected:      `card=REDACTED REDACTED`,
+			expectedLimit: 22,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			body, limit := redactMatches(regexp.MustCompile(test.exp), []byte(test.body), test.limit)
+			assert.Equal(t, test.expected, string(body))
+			assert.Equal(t, test.expectedLimit, limit)
+		})
+	}
+}
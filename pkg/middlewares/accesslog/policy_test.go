@@ -0,0 +1,163 @@
+package accesslog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestPolicyHandler(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		policy   *types.AccessLogPolicy
+		status   int
+		expected map[string]interface{}
+	}{
+		{
+			desc:     "no policy",
+			status:   http.StatusOK,
+			expected: map[string]interface{}{DownstreamStatus: float64(http.StatusOK), "request_X-Foo": nil},
+		},
+		{
+			desc:   "disabled",
+			policy: &types.AccessLogPolicy{Disabled: true},
+			status: http.StatusInternalServerError,
+		},
+		{
+			desc:   "overridden filters",
+			policy: &types.AccessLogPolicy{Filters: &types.AccessLogFilters{StatusCodes: []string{"500-599"}}},
+			status: http.StatusNotFound,
+		},
+		{
+			desc:     "overridden filters keeping the request",
+			policy:   &types.AccessLogPolicy{Filters: &types.AccessLogFilters{StatusCodes: []string{"500-599"}}},
+			status:   http.StatusServiceUnavailable,
+			expected: map[string]interface{}{DownstreamStatus: float64(http.StatusServiceUnavailable)},
+		},
+		{
+			desc: "overridden fields",
+			policy: &types.AccessLogPolicy{
+				Fields: &types.AccessLogFields{
+					DefaultMode: types.AccessLogDrop,
+					Names:       map[string]string{RequestPath: types.AccessLogKeep},
+				},
+			},
+			status:   http.StatusOK,
+			expected: map[string]interface{}{RequestPath: "/foo", DownstreamStatus: nil, "request_X-Foo": nil},
+		},
+		{
+			desc: "overridden headers",
+			policy: &types.AccessLogPolicy{
+				Fields: &types.AccessLogFields{
+					Headers: &types.FieldHeaders{
+						DefaultMode: types.AccessLogDrop,
+						Names:       map[string]string{"x-foo": types.AccessLogRedact},
+					},
+				},
+			},
+			status:   http.StatusOK,
+			expected: map[string]interface{}{RequestPath: "/foo", "request_X-Foo": "REDACTED"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			logFile, err := os.CreateTemp(t.TempDir(), "*.log")
+			require.NoError(t, err)
+
+			config := &types.AccessLog{
+				FilePath: logFile.Name(),
+				Format:   JSONFormat,
+				Filters: &types.AccessLogFilters{
+					MinDuration: ptypes.Duration(time.Nanosecond),
+				},
+			}
+			config.Fields = &types.AccessLogFields{}
+			config.Fields.SetDefaults()
+
+			handler, err := NewHandler(config)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = handler.Close() })
+
+			next, err := NewPolicyHandler(handler, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(test.status)
+			}), test.policy)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/foo", nil)
+			req.Header.Set("X-Foo", "bar")
+
+			handler.ServeHTTP(httptest.NewRecorder(), req, next)
+
+			logData, err := os.ReadFile(logFile.Name())
+			require.NoError(t, err)
+
+			if test.expected == nil {
+				assert.Empty(t, logData)
+				return
+			}
+
+			entry := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(logData, &entry))
+
+			for key, value := range test.expected {
+				assert.Equal(t, value, entry[key], key)
+			}
+		})
+	}
+}
+
+func TestNewPolicyHandler_invalid(t *testing.T) {
+	handler, err := NewHandler(&types.AccessLog{Format: CommonFormat})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = handler.Close() })
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	_, err = NewPolicyHandler(handler, next, &types.AccessLogPolicy{SampleRate: 2})
+	assert.Error(t, err)
+
+	_, err = NewPolicyHandler(handler, next, &types.AccessLogPolicy{Filters: &types.AccessLogFilters{StatusCodes: []string{"foo"}}})
+	assert.Error(t, err)
+
+	_, err = NewHandler(&types.AccessLog{Format: CommonFormat, SampleRate: -1})
+	assert.Error(t, err)
+}
+
+func TestPolicy_sample(t *testing.T) {
+	logFile, err := os.CreateTemp(t.TempDir(), "*.log")
+	require.NoError(t, err)
+
+	handler, err := NewHandler(&types.AccessLog{
+		FilePath:   logFile.Name(),
+		Format:     CommonFormat,
+		SampleRate: 0.5,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = handler.Close() })
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	for i := 0; i < 1000; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://localhost/foo", nil), next)
+	}
+
+	logData, err := os.ReadFile(logFile.Name())
+	require.NoError(t, err)
+
+	count := strings.Count(string(logData), "\n")
+	assert.Greater(t, count, 350)
+	assert.Less(t, count, 650)
+}
@@ -0,0 +1,203 @@
+package accesslog
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/traefik/traefik/v2/pkg/log"
+)
+
+// dropReportInterval is the interval at which the number of access log lines dropped by a sink is reported.
+const dropReportInterval = 10 * time.Second
+
+// sinkCloseTimeout is how long closing a sink waits for the buffered lines to be sent.
+const sinkCloseTimeout = 10 * time.Second
+
+// sender sends batches of access log lines to a sink.
+type sender interface {
+	send(lines [][]byte) error
+	close() error
+}
+
+// bufferedSink is an output of the access logs which never blocks the writers:
+// the lines are kept in a bounded buffer while waiting to be sent,
+// and are dropped (and counted) when the buffer is full, i.e. when the sink is too slow.
+type bufferedSink struct {
+	name          string
+	sender        sender
+	batchSize     int
+	flushInterval time.Duration
+
+	lines   chan []byte
+	dropped uint64
+	// droppedCounter holds the gokitmetrics.Counter, if any, exporting the number of dropped lines.
+	droppedCounter atomic.Value
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+	// abort is closed when the buffered lines could not be sent in time on close, the remaining ones being dropped.
+	abort        chan struct{}
+	closeTimeout time.Duration
+}
+
+func newBufferedSink(name string, sender sender, bufferSize, batchSize int, flushInterval time.Duration) *bufferedSink {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+
+	s := &bufferedSink{
+		name:          name,
+		sender:        sender,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		lines:         make(chan []byte, bufferSize),
+		done:          make(chan struct{}),
+		abort:         make(chan struct{}),
+		closeTimeout:  sinkCloseTimeout,
+	}
+
+	go s.loop()
+
+	return s
+}
+
+// Write buffers the line, or drops it if the buffer is full.
+func (s *bufferedSink) Write(p []byte) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return len(p), nil
+	}
+
+	// The buffer of the formatted line may be reused once Write returns.
+	line := make([]byte, len(p))
+	copy(line, p)
+
+	select {
+	case s.lines <- line:
+	default:
+		s.drop(1)
+	}
+
+	return len(p), nil
+}
+
+// SetDroppedCounter sets the counter exporting the number of lines dropped by the sink.
+func (s *bufferedSink) SetDroppedCounter(counter gokitmetrics.Counter) {
+	if counter != nil {
+		s.droppedCounter.Store(counter.With("output", s.name))
+	}
+}
+
+func (s *bufferedSink) drop(n int) {
+	atomic.AddUint64(&s.dropped, uint64(n))
+
+	if counter, ok := s.droppedCounter.Load().(gokitmetrics.Counter); ok {
+		counter.Add(float64(n))
+	}
+}
+
+// Dropped returns the number of lines dropped since the creation of the sink.
+func (s *bufferedSink) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close sends the buffered lines and closes the sink,
+// the lines which are not sent before the close timeout being dropped.
+func (s *bufferedSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.lines)
+	s.mu.Unlock()
+
+	timer := time.NewTimer(s.closeTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-timer.C:
+		close(s.abort)
+		return fmt.Errorf("timeout while sending the buffered access logs to the %s output", s.name)
+	}
+}
+
+// loop sends the buffered lines until the sink is closed, and then closes the sender.
+func (s *bufferedSink) loop() {
+	defer close(s.done)
+
+	defer func() {
+		if err := s.sender.close(); err != nil {
+			log.WithoutContext().Debugf("Error while closing the access logs %s output: %v", s.name, err)
+		}
+	}()
+
+	flushTicker := time.NewTicker(s.flushInterval)
+	defer flushTicker.Stop()
+
+	reportTicker := time.NewTicker(dropReportInterval)
+	defer reportTicker.Stop()
+
+	var reported uint64
+	var batch [][]byte
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		select {
+		case <-s.abort:
+			s.drop(len(batch))
+			batch = nil
+			return
+		default:
+		}
+
+		if err := s.sender.send(batch); err != nil {
+			s.drop(len(batch))
+			log.WithoutContext().Debugf("Error while sending access logs to the %s output: %v", s.name, err)
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case line, ok := <-s.lines:
+			if !ok {
+				flush()
+				return
+			}
+
+			batch = append(batch, line)
+			if len(batch) >= s.batchSize {
+				flush()
+			}
+
+		case <-flushTicker.C:
+			flush()
+
+		case <-reportTicker.C:
+			dropped := s.Dropped()
+			if dropped > reported {
+				log.WithoutContext().Warnf("%d access log lines dropped by the %s output (%d in total)", dropped-reported, s.name, dropped)
+				reported = dropped
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package accesslog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// httpSinkTimeout is the timeout of the requests sending the batches of access log lines.
+const httpSinkTimeout = 10 * time.Second
+
+// httpSender POSTs the batches of access log lines to an HTTP endpoint, one line per access log entry.
+type httpSender struct {
+	client      *http.Client
+	url         string
+	headers     map[string]string
+	contentType string
+}
+
+func newHTTPSink(config *types.AccessLogHTTP, format string) (*bufferedSink, error) {
+	if config.URL == "" {
+		return nil, errors.New("the URL of the HTTP output is missing")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.TLS != nil {
+		tlsConfig, err := config.TLS.CreateTLSConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("creating TLS configuration: %w", err)
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	contentType := "text/plain; charset=utf-8"
+	if format == JSONFormat {
+		contentType = "application/x-ndjson"
+	}
+
+	sender := &httpSender{
+		client:      &http.Client{Transport: transport, Timeout: httpSinkTimeout},
+		url:         config.URL,
+		headers:     config.Headers,
+		contentType: contentType,
+	}
+
+	return newBufferedSink("HTTP", sender, config.BufferSize, config.BatchSize, time.Duration(config.FlushInterval)), nil
+}
+
+func (s *httpSender) send(lines [][]byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(bytes.Join(lines, nil)))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", s.contentType)
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (s *httpSender) close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,146 @@
+package accesslog
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// syslogSeverityInfo is the severity of the access log syslog messages.
+const syslogSeverityInfo = 6
+
+// syslogSinkTimeout is the timeout of the connection to the syslog server, and of the writes of the messages.
+const syslogSinkTimeout = 5 * time.Second
+
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// syslogSender sends the access log lines as syslog messages (RFC 3164).
+type syslogSender struct {
+	network  string
+	address  string
+	tag      string
+	priority int
+	hostname string
+	pid      int
+	timeout  time.Duration
+
+	conn net.Conn
+}
+
+func newSyslogSink(config *types.AccessLogSyslog) (*bufferedSink, error) {
+	switch config.Network {
+	case "udp", "tcp", "unix", "unixgram":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network: %q", config.Network)
+	}
+
+	facility, ok := syslogFacilities[strings.ToLower(config.Facility)]
+	if !ok {
+		return nil, fmt.Errorf("unknown syslog facility: %q", config.Facility)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	sender := &syslogSender{
+		network:  config.Network,
+		address:  config.Address,
+		tag:      config.Tag,
+		priority: facility*8 + syslogSeverityInfo,
+		hostname: hostname,
+		pid:      os.Getpid(),
+		timeout:  syslogSinkTimeout,
+	}
+
+	return newBufferedSink("syslog", sender, config.BufferSize, 1, time.Second), nil
+}
+
+func (s *syslogSender) send(lines [][]byte) error {
+	for _, line := range lines {
+		msg := s.message(line)
+
+		// The connection is (re)established lazily, a failed or timed out write being retried once on a new connection.
+		var err error
+		for attempt := 0; attempt < 2; attempt++ {
+			if err = s.write(msg); err == nil {
+				break
+			}
+			_ = s.close()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *syslogSender) message(line []byte) []byte {
+	b := &bytes.Buffer{}
+
+	_, _ = fmt.Fprintf(b, "<%d>%s %s %s[%d]: ", s.priority, time.Now().Format(time.RFC3339), s.hostname, s.tag, s.pid)
+	b.Write(bytes.TrimRight(line, "\n"))
+
+	// The stream transports need a delimiter between the messages.
+	if s.network == "tcp" || s.network == "unix" {
+		b.WriteByte('\n')
+	}
+
+	return b.Bytes()
+}
+
+func (s *syslogSender) write(msg []byte) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.address, s.timeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	// The deadline prevents a stalled server from blocking the sink forever.
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+
+	_, err := s.conn.Write(msg)
+	return err
+}
+
+func (s *syslogSender) close() error {
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
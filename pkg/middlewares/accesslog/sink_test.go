@@ -0,0 +1,255 @@
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/testhelpers"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestHandler_httpOutput(t *testing.T) {
+	bodies := make(chan string, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/x-ndjson", req.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", req.Header.Get("X-Api-Key"))
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		bodies <- string(body)
+	}))
+	t.Cleanup(server.Close)
+
+	config := &types.AccessLog{
+		Format: JSONFormat,
+		HTTP: &types.AccessLogHTTP{
+			URL:           server.URL,
+			Headers:       map[string]string{"X-Api-Key": "secret"},
+			BatchSize:     2,
+			FlushInterval: ptypes.Duration(time.Hour),
+			BufferSize:    10,
+		},
+	}
+
+	handler, err := NewHandler(config)
+	require.NoError(t, err)
+
+	// No output is written to stdout when another output is configured.
+	assert.Nil(t, handler.file)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/foo", nil)
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+	})
+
+	for i := 0; i < 3; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), req, next)
+	}
+
+	// The batch size is reached.
+	select {
+	case body := <-bodies:
+		lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+		require.Len(t, lines, 2)
+
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+		assert.Equal(t, float64(http.StatusTeapot), entry[DownstreamStatus])
+	case <-time.After(5 * time.Second):
+		t.Fatal("the batch has not been sent")
+	}
+
+	// The last line is sent on close.
+	require.NoError(t, handler.Close())
+	require.Len(t, bodies, 1)
+	assert.Equal(t, 1, strings.Count(<-bodies, "\n"))
+}
+
+func TestHandler_syslogOutput(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	config := &types.AccessLog{
+		Format:   TemplateFormat,
+		Template: "$RequestMethod $RequestPath $DownstreamStatus",
+		Syslog:   &types.AccessLogSyslog{},
+	}
+	config.Syslog.SetDefaults()
+	config.Syslog.Address = conn.LocalAddr().String()
+	config.Syslog.Facility = "local0"
+
+	handler, err := NewHandler(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = handler.Close() })
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/foo", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	msg := string(buf[:n])
+	// local0 (16) * 8 + info (6)
+	assert.True(t, strings.HasPrefix(msg, "<134>"), msg)
+	assert.Contains(t, msg, " traefik[")
+	assert.True(t, strings.HasSuffix(msg, "]: GET /foo 200"), msg)
+}
+
+func TestNewHandler_invalidOutputs(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		config *types.AccessLog
+	}{
+		{
+			desc:   "unknown syslog network",
+			config: &types.AccessLog{Syslog: &types.AccessLogSyslog{Network: "foo", Facility: "user"}},
+		},
+		{
+			desc:   "unknown syslog facility",
+			config: &types.AccessLog{Syslog: &types.AccessLogSyslog{Network: "udp", Facility: "foo"}},
+		},
+		{
+			desc:   "missing HTTP URL",
+			config: &types.AccessLog{HTTP: &types.AccessLogHTTP{}},
+		},
+		{
+			desc:   "invalid template",
+			config: &types.AccessLog{Format: TemplateFormat, Template: "${ClientHost"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewHandler(test.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBufferedSink_drop(t *testing.T) {
+	sender := &blockingSender{unblock: make(chan struct{})}
+
+	sink := newBufferedSink("test", sender, 1, 1, time.Hour)
+
+	counter := &testhelpers.CollectingCounter{}
+	sink.SetDroppedCounter(counter)
+
+	// The first line is taken by the loop, which blocks on sending it,
+	// the second one fills the buffer and the next ones are dropped.
+	_, err := sink.Write([]byte("foo\n"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return len(sink.lines) == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	for i := 0; i < 4; i++ {
+		n, err := sink.Write([]byte("foo\n"))
+		require.NoError(t, err)
+		assert.Equal(t, 4, n)
+	}
+
+	assert.Equal(t, uint64(3), sink.Dropped())
+	assert.Equal(t, float64(3), counter.CounterValue)
+	assert.Equal(t, []string{"output", "test"}, counter.LastLabelValues)
+
+	close(sender.unblock)
+	require.NoError(t, sink.Close())
+	assert.Equal(t, 2, sender.sent)
+}
+
+func TestBufferedSink_closeTimeout(t *testing.T) {
+	sender := &blockingSender{unblock: make(chan struct{})}
+	t.Cleanup(func() { close(sender.unblock) })
+
+	sink := newBufferedSink("test", sender, 10, 1, time.Hour)
+	sink.closeTimeout = 100 * time.Millisecond
+
+	for i := 0; i < 3; i++ {
+		_, err := sink.Write([]byte("foo\n"))
+		require.NoError(t, err)
+	}
+
+	start := time.Now()
+	require.Error(t, sink.Close())
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+}
+
+func TestSyslogSender_stalledServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	// The server accepts the connections, but never reads from them.
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				_ = conn.Close()
+			}
+		}()
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+
+	sender := &syslogSender{network: "tcp", address: listener.Addr().String(), tag: "traefik", timeout: 100 * time.Millisecond}
+	t.Cleanup(func() { _ = sender.close() })
+
+	// The lines are bigger than the socket buffers, so that the writes time out,
+	// the timed out writes being retried on a new connection.
+	line := bytes.Repeat([]byte("a"), 16<<20)
+	lines := [][]byte{line, line, line}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = sender.send(lines)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("the sender is blocked by the stalled server")
+	}
+}
+
+type blockingSender struct {
+	unblock chan struct{}
+	sent    int
+}
+
+func (s *blockingSender) send(lines [][]byte) error {
+	<-s.unblock
+	s.sent += len(lines)
+	return nil
+}
+
+func (s *blockingSender) close() error {
+	return nil
+}
@@ -0,0 +1,93 @@
+package accesslog
+
+import (
+	"crypto/tls"
+
+	"github.com/traefik/traefik/v2/pkg/tcp"
+	traefiktls "github.com/traefik/traefik/v2/pkg/tls"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+type tcpHandler struct {
+	handler     *Handler
+	next        tcp.Handler
+	routerName  string
+	serviceName string
+	policy      *policy
+}
+
+// NewTCPHandler creates a handler writing an access log entry for each TCP connection forwarded to the next handler.
+// The given configuration, if any, overrides the access log policy for these connections.
+func NewTCPHandler(handler *Handler, next tcp.Handler, routerName, serviceName string, config *types.AccessLogPolicy) (tcp.Handler, error) {
+	var p *policy
+	if config != nil {
+		var err error
+		p, err = handler.policy.override(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &tcpHandler{
+		handler:     handler,
+		next:        next,
+		routerName:  routerName,
+		serviceName: serviceName,
+		policy:      p,
+	}, nil
+}
+
+// ServeTCP forwards the connection to the next handler,
+// the access log entry being written once the next handler returns, i.e. once the connection is closed.
+func (h *tcpHandler) ServeTCP(conn tcp.WriteCloser) {
+	connLog := h.handler.newConnLog(protoTCP, conn.RemoteAddr(), h.routerName, h.serviceName, h.policy)
+	defer connLog.end()
+
+	if fingerprint := tcp.GetJA3(conn); fingerprint != "" {
+		connLog.core[TLSClientJA3] = fingerprint
+	}
+
+	h.next.ServeTCP(&loggedConn{WriteCloser: conn, connLog: connLog})
+
+	switch c := conn.(type) {
+	case *tcp.Conn:
+		if c.ServerName != "" {
+			connLog.core[TLSServerName] = c.ServerName
+		}
+	case *tls.Conn:
+		state := c.ConnectionState()
+		if !state.HandshakeComplete {
+			break
+		}
+
+		if state.ServerName != "" {
+			connLog.core[TLSServerName] = state.ServerName
+		}
+		connLog.core[TLSVersion] = traefiktls.GetVersion(&state)
+		connLog.core[TLSCipher] = traefiktls.GetCipherName(&state)
+	}
+}
+
+// loggedConn records the traffic of a TCP connection,
+// and is notified of its forwarding to a backend.
+type loggedConn struct {
+	tcp.WriteCloser
+	*connLog
+}
+
+func (c *loggedConn) Read(p []byte) (int, error) {
+	n, err := c.WriteCloser.Read(p)
+	c.Received(n)
+	return n, err
+}
+
+func (c *loggedConn) Write(p []byte) (int, error) {
+	n, err := c.WriteCloser.Write(p)
+	c.Sent(n)
+	return n, err
+}
+
+// Unwrap returns the logged connection.
+func (c *loggedConn) Unwrap() tcp.WriteCloser {
+	return c.WriteCloser
+}
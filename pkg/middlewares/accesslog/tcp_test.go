@@ -0,0 +1,126 @@
+package accesslog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/tcp"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestTCPHandler(t *testing.T) {
+	backendListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backendListener.Close() })
+
+	go func() {
+		conn, err := backendListener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		buf := make([]byte, 5)
+		if _, err := conn.Read(buf); err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("bye"))
+	}()
+
+	// A listener closed right away, to get an address refusing the connections.
+	unavailableListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unavailableAddr := unavailableListener.Addr().String()
+	require.NoError(t, unavailableListener.Close())
+
+	testCases := []struct {
+		desc                string
+		backendAddr         string
+		expectedSent        float64
+		expectedServiceAddr interface{}
+		expectedCloseReason string
+	}{
+		{
+			desc:                "forwarded connection",
+			backendAddr:         backendListener.Addr().String(),
+			expectedSent:        3,
+			expectedServiceAddr: backendListener.Addr().String(),
+			expectedCloseReason: closeReasonClosed,
+		},
+		{
+			desc:                "unavailable backend",
+			backendAddr:         unavailableAddr,
+			expectedCloseReason: "error while connecting to backend",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			logFilePath := filepath.Join(t.TempDir(), "access.log")
+
+			handler, err := NewHandler(&types.AccessLog{FilePath: logFilePath, Format: JSONFormat})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = handler.Close() })
+
+			proxy, err := tcp.NewProxy(test.backendAddr, 0, nil)
+			require.NoError(t, err)
+
+			frontendListener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = frontendListener.Close() })
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+
+				conn, err := frontendListener.Accept()
+				if err != nil {
+					return
+				}
+
+				tcpHandler, err := NewTCPHandler(handler, proxy, "foo@file", "bar@file", nil)
+				require.NoError(t, err)
+
+				tcpHandler.ServeTCP(conn.(*net.TCPConn))
+			}()
+
+			conn, err := net.Dial("tcp", frontendListener.Addr().String())
+			require.NoError(t, err)
+
+			_, err = conn.Write([]byte("hello"))
+			require.NoError(t, err)
+
+			_, _ = ioutil.ReadAll(conn)
+			require.NoError(t, conn.Close())
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the connection has not been handled")
+			}
+
+			logData, err := ioutil.ReadFile(logFilePath)
+			require.NoError(t, err)
+
+			entry := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(logData, &entry))
+
+			assert.Equal(t, protoTCP, entry[RequestProtocol])
+			assert.Equal(t, "foo@file", entry[RouterName])
+			assert.Equal(t, "bar@file", entry[ServiceName])
+			assert.Equal(t, "127.0.0.1", entry[ClientHost])
+			assert.Equal(t, test.expectedServiceAddr, entry[ServiceAddr])
+			assert.Equal(t, test.expectedSent, entry[DownstreamContentSize])
+			assert.True(t, strings.HasPrefix(entry[CloseReason].(string), test.expectedCloseReason))
+			assert.NotNil(t, entry[Duration])
+		})
+	}
+}
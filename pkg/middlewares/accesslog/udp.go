@@ -0,0 +1,46 @@
+package accesslog
+
+import (
+	"github.com/traefik/traefik/v2/pkg/types"
+	"github.com/traefik/traefik/v2/pkg/udp"
+)
+
+type udpHandler struct {
+	handler     *Handler
+	next        udp.Handler
+	routerName  string
+	serviceName string
+	policy      *policy
+}
+
+// NewUDPHandler creates a handler writing an access log entry for each UDP session forwarded to the next handler.
+// The given configuration, if any, overrides the access log policy for these sessions.
+func NewUDPHandler(handler *Handler, next udp.Handler, routerName, serviceName string, config *types.AccessLogPolicy) (udp.Handler, error) {
+	var p *policy
+	if config != nil {
+		var err error
+		p, err = handler.policy.override(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &udpHandler{
+		handler:     handler,
+		next:        next,
+		routerName:  routerName,
+		serviceName: serviceName,
+		policy:      p,
+	}, nil
+}
+
+// ServeUDP forwards the session to the next handler,
+// the access log entry being written once the next handler returns, i.e. once the session ends.
+func (h *udpHandler) ServeUDP(conn *udp.Conn) {
+	connLog := h.handler.newConnLog(protoUDP, conn.RemoteAddr(), h.routerName, h.serviceName, h.policy)
+	defer connLog.end()
+
+	conn.AddObserver(connLog)
+
+	h.next.ServeUDP(conn)
+}
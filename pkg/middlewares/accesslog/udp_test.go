@@ -0,0 +1,94 @@
+package accesslog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/types"
+	"github.com/traefik/traefik/v2/pkg/udp"
+)
+
+func TestUDPHandler(t *testing.T) {
+	backendConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backendConn.Close() })
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := backendConn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = backendConn.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	logFilePath := filepath.Join(t.TempDir(), "access.log")
+
+	handler, err := NewHandler(&types.AccessLog{FilePath: logFilePath, Format: JSONFormat})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = handler.Close() })
+
+	proxy, err := udp.NewProxy(backendConn.LocalAddr().String())
+	require.NoError(t, err)
+
+	listener, err := udp.Listen("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")}, 100*time.Millisecond)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		udpHandler, err := NewUDPHandler(handler, proxy, "foo@file", "bar@file", nil)
+		require.NoError(t, err)
+
+		udpHandler.ServeUDP(conn)
+	}()
+
+	conn, err := net.Dial("udp", listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf[:n]))
+
+	// The session ends once it has been idle for longer than the listener timeout.
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the session has not ended")
+	}
+
+	logData, err := ioutil.ReadFile(logFilePath)
+	require.NoError(t, err)
+
+	entry := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(logData, &entry))
+
+	assert.Equal(t, protoUDP, entry[RequestProtocol])
+	assert.Equal(t, "foo@file", entry[RouterName])
+	assert.Equal(t, "bar@file", entry[ServiceName])
+	assert.Equal(t, conn.LocalAddr().String(), entry[ClientAddr])
+	assert.Equal(t, backendConn.LocalAddr().String(), entry[ServiceAddr])
+	assert.Equal(t, float64(5), entry[RequestContentSize])
+	assert.Equal(t, float64(5), entry[DownstreamContentSize])
+	assert.Equal(t, closeReasonClosed, entry[CloseReason])
+}
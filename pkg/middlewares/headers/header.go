@@ -10,6 +10,7 @@ import (
 
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/tls/ja3"
 )
 
 // Header is a middleware that helps setup a few basic security features.
@@ -80,6 +81,15 @@ func (s *Header) modifyCustomRequestHeaders(req *http.Request) {
 			req.Header.Set(header, value)
 		}
 	}
+
+	if s.headers.JA3Header != "" {
+		// The header is removed when there is no fingerprint, so that it cannot be forged by the client.
+		if fingerprint := ja3.FromContext(req.Context()); fingerprint != "" {
+			req.Header.Set(s.headers.JA3Header, fingerprint)
+		} else {
+			req.Header.Del(s.headers.JA3Header)
+		}
+	}
 }
 
 // PostRequestModifyResponseHeaders set or delete response headers.
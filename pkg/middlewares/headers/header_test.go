@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/tls/ja3"
 )
 
 func TestNewHeader_customRequestHeader(t *testing.T) {
@@ -69,6 +70,58 @@ func TestNewHeader_customRequestHeader(t *testing.T) {
 	}
 }
 
+func TestNewHeader_ja3Header(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		fingerprint string
+		header      http.Header
+		expected    http.Header
+	}{
+		{
+			desc:        "adds the fingerprint",
+			fingerprint: "851235d5e9d490f3e2b43db94ac71961",
+			header:      http.Header{"Foo": []string{"bar"}},
+			expected:    http.Header{"Foo": []string{"bar"}, "X-Ja3": []string{"851235d5e9d490f3e2b43db94ac71961"}},
+		},
+		{
+			desc:        "overrides the header sent by the client",
+			fingerprint: "851235d5e9d490f3e2b43db94ac71961",
+			header:      http.Header{"X-Ja3": []string{"forged"}},
+			expected:    http.Header{"X-Ja3": []string{"851235d5e9d490f3e2b43db94ac71961"}},
+		},
+		{
+			desc:     "removes the header sent by the client without fingerprint",
+			header:   http.Header{"Foo": []string{"bar"}, "X-Ja3": []string{"forged"}},
+			expected: http.Header{"Foo": []string{"bar"}},
+		},
+	}
+
+	emptyHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			mid, err := NewHeader(emptyHandler, dynamic.Headers{JA3Header: "X-JA3"})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+			req.Header = test.header
+			if test.fingerprint != "" {
+				req = req.WithContext(ja3.WithFingerprint(req.Context(), test.fingerprint))
+			}
+
+			rw := httptest.NewRecorder()
+
+			mid.ServeHTTP(rw, req)
+
+			assert.Equal(t, http.StatusOK, rw.Code)
+			assert.Equal(t, test.expected, req.Header)
+		})
+	}
+}
+
 func TestNewHeader_customRequestHeader_Host(t *testing.T) {
 	testCases := []struct {
 		desc            string
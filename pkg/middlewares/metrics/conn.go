@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/traefik/traefik/v2/pkg/metrics"
+)
+
+const (
+	protoTCP = "tcp"
+	protoUDP = "udp"
+)
+
+// connMetrics holds the metrics of the TCP connections and UDP sessions.
+type connMetrics struct {
+	connsCounter          gokitmetrics.Counter
+	activeConnsGauge      gokitmetrics.Gauge
+	connDurationHistogram metrics.ScalableHistogram
+	bytesReceivedCounter  gokitmetrics.Counter
+	bytesSentCounter      gokitmetrics.Counter
+	baseLabels            []string
+}
+
+func newEntryPointConnMetrics(registry metrics.Registry, entryPointName string) connMetrics {
+	return connMetrics{
+		connsCounter:          registry.EntryPointConnsCounter(),
+		activeConnsGauge:      registry.EntryPointActiveConnsGauge(),
+		connDurationHistogram: registry.EntryPointConnDurationHistogram(),
+		bytesReceivedCounter:  registry.EntryPointBytesReceivedCounter(),
+		bytesSentCounter:      registry.EntryPointBytesSentCounter(),
+		baseLabels:            []string{"entrypoint", entryPointName},
+	}
+}
+
+func newRouterConnMetrics(registry metrics.Registry, routerName, serviceName string) connMetrics {
+	return connMetrics{
+		connsCounter:          registry.RouterConnsCounter(),
+		activeConnsGauge:      registry.RouterActiveConnsGauge(),
+		connDurationHistogram: registry.RouterConnDurationHistogram(),
+		bytesReceivedCounter:  registry.RouterBytesReceivedCounter(),
+		bytesSentCounter:      registry.RouterBytesSentCounter(),
+		baseLabels:            []string{"router", routerName, "service", serviceName},
+	}
+}
+
+func newServiceConnMetrics(registry metrics.Registry, serviceName string) connMetrics {
+	return connMetrics{
+		connsCounter:          registry.ServiceConnsCounter(),
+		activeConnsGauge:      registry.ServiceActiveConnsGauge(),
+		connDurationHistogram: registry.ServiceConnDurationHistogram(),
+		bytesReceivedCounter:  registry.ServiceBytesReceivedCounter(),
+		bytesSentCounter:      registry.ServiceBytesSentCounter(),
+		baseLabels:            []string{"service", serviceName},
+	}
+}
+
+// connTracker records the metrics of a single TCP connection or UDP session.
+type connTracker struct {
+	activeConnsGauge      gokitmetrics.Gauge
+	connDurationHistogram metrics.ScalableHistogram
+	bytesReceivedCounter  gokitmetrics.Counter
+	bytesSentCounter      gokitmetrics.Counter
+
+	start     time.Time
+	closeOnce sync.Once
+}
+
+// track starts tracking a new connection using the given protocol.
+func (m connMetrics) track(protocol string) *connTracker {
+	var labels []string
+	labels = append(labels, m.baseLabels...)
+	labels = append(labels, "protocol", protocol)
+
+	m.connsCounter.With(labels...).Add(1)
+
+	tracker := &connTracker{
+		activeConnsGauge:      m.activeConnsGauge.With(labels...),
+		connDurationHistogram: m.connDurationHistogram.With(labels...),
+		bytesReceivedCounter:  m.bytesReceivedCounter.With(labels...),
+		bytesSentCounter:      m.bytesSentCounter.With(labels...),
+		start:                 time.Now(),
+	}
+
+	tracker.activeConnsGauge.Add(1)
+
+	return tracker
+}
+
+// Received records the bytes received from the client.
+func (t *connTracker) Received(n int) {
+	if n > 0 {
+		t.bytesReceivedCounter.Add(float64(n))
+	}
+}
+
+// Sent records the bytes sent to the client.
+func (t *connTracker) Sent(n int) {
+	if n > 0 {
+		t.bytesSentCounter.Add(float64(n))
+	}
+}
+
+// close records the end of the connection, only the first call being taken into account.
+func (t *connTracker) close() {
+	t.closeOnce.Do(func() {
+		t.activeConnsGauge.Add(-1)
+		t.connDurationHistogram.ObserveFromStart(t.start)
+	})
+}
@@ -23,6 +23,7 @@ const (
 	protoWebsocket = "websocket"
 	typeName       = "Metrics"
 	nameEntrypoint = "metrics-entrypoint"
+	nameRouter     = "metrics-router"
 	nameService    = "metrics-service"
 )
 
@@ -49,6 +50,20 @@ func NewEntryPointMiddleware(ctx context.Context, next http.Handler, registry me
 	}
 }
 
+// NewRouterMiddleware creates a new metrics middleware for a Router.
+func NewRouterMiddleware(ctx context.Context, next http.Handler, registry metrics.Registry, routerName, serviceName string) http.Handler {
+	log.FromContext(middlewares.GetLoggerCtx(ctx, nameRouter, typeName)).Debug("Creating middleware")
+
+	return &metricsMiddleware{
+		next:                 next,
+		reqsCounter:          registry.RouterReqsCounter(),
+		reqsTLSCounter:       registry.RouterReqsTLSCounter(),
+		reqDurationHistogram: registry.RouterReqDurationHistogram(),
+		openConnsGauge:       registry.RouterOpenConnsGauge(),
+		baseLabels:           []string{"router", routerName, "service", serviceName},
+	}
+}
+
 // NewServiceMiddleware creates a new metrics middleware for a Service.
 func NewServiceMiddleware(ctx context.Context, next http.Handler, registry metrics.Registry, serviceName string) http.Handler {
 	log.FromContext(middlewares.GetLoggerCtx(ctx, nameService, typeName)).Debug("Creating middleware")
@@ -70,6 +85,13 @@ func WrapEntryPointHandler(ctx context.Context, registry metrics.Registry, entry
 	}
 }
 
+// WrapRouterHandler Wraps metrics router to alice.Constructor.
+func WrapRouterHandler(ctx context.Context, registry metrics.Registry, routerName, serviceName string) alice.Constructor {
+	return func(next http.Handler) (http.Handler, error) {
+		return NewRouterMiddleware(ctx, next, registry, routerName, serviceName), nil
+	}
+}
+
 // WrapServiceHandler Wraps metrics service to alice.Constructor.
 func WrapServiceHandler(ctx context.Context, registry metrics.Registry, serviceName string) alice.Constructor {
 	return func(next http.Handler) (http.Handler, error) {
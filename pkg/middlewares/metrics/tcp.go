@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/metrics"
+	"github.com/traefik/traefik/v2/pkg/middlewares"
+	"github.com/traefik/traefik/v2/pkg/tcp"
+)
+
+type tcpMetricsHandler struct {
+	next    tcp.Handler
+	metrics connMetrics
+}
+
+// NewTCPEntryPointHandler creates a new metrics handler for the TCP connections of an Entrypoint.
+func NewTCPEntryPointHandler(ctx context.Context, next tcp.Handler, registry metrics.Registry, entryPointName string) tcp.Handler {
+	log.FromContext(middlewares.GetLoggerCtx(ctx, nameEntrypoint, typeName)).Debug("Creating TCP middleware")
+
+	return &tcpMetricsHandler{
+		next:    next,
+		metrics: newEntryPointConnMetrics(registry, entryPointName),
+	}
+}
+
+// NewTCPRouterHandler creates a new metrics handler for the TCP connections of a Router.
+func NewTCPRouterHandler(ctx context.Context, next tcp.Handler, registry metrics.Registry, routerName, serviceName string) tcp.Handler {
+	log.FromContext(middlewares.GetLoggerCtx(ctx, nameRouter, typeName)).Debug("Creating TCP middleware")
+
+	return &tcpMetricsHandler{
+		next:    next,
+		metrics: newRouterConnMetrics(registry, routerName, serviceName),
+	}
+}
+
+// NewTCPServiceHandler creates a new metrics handler for the TCP connections of a Service.
+func NewTCPServiceHandler(ctx context.Context, next tcp.Handler, registry metrics.Registry, serviceName string) tcp.Handler {
+	log.FromContext(middlewares.GetLoggerCtx(ctx, nameService, typeName)).Debug("Creating TCP middleware")
+
+	return &tcpMetricsHandler{
+		next:    next,
+		metrics: newServiceConnMetrics(registry, serviceName),
+	}
+}
+
+// ServeTCP forwards the connection to the next handler,
+// the metrics being recorded until the connection is closed.
+func (h *tcpMetricsHandler) ServeTCP(conn tcp.WriteCloser) {
+	h.next.ServeTCP(&trackedConn{
+		WriteCloser: conn,
+		tracker:     h.metrics.track(protoTCP),
+	})
+}
+
+// trackedConn records the traffic of a TCP connection.
+type trackedConn struct {
+	tcp.WriteCloser
+	tracker *connTracker
+}
+
+func (c *trackedConn) Read(p []byte) (int, error) {
+	n, err := c.WriteCloser.Read(p)
+	c.tracker.Received(n)
+	return n, err
+}
+
+func (c *trackedConn) Write(p []byte) (int, error) {
+	n, err := c.WriteCloser.Write(p)
+	c.tracker.Sent(n)
+	return n, err
+}
+
+// Unwrap returns the tracked connection.
+func (c *trackedConn) Unwrap() tcp.WriteCloser {
+	return c.WriteCloser
+}
+
+func (c *trackedConn) Close() error {
+	c.tracker.close()
+	return c.WriteCloser.Close()
+}
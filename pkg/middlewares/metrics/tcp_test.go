@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/metrics"
+	"github.com/traefik/traefik/v2/pkg/tcp"
+)
+
+func TestTCPMetricsHandler(t *testing.T) {
+	connsCounter := &CollectingCounter{}
+	activeConnsGauge := &collectingGauge{}
+	connDurationHistogram := &collectingHistogram{}
+	bytesReceivedCounter := &CollectingCounter{}
+	bytesSentCounter := &CollectingCounter{}
+
+	next := tcp.HandlerFunc(func(conn tcp.WriteCloser) {
+		assert.Equal(t, float64(1), activeConnsGauge.GaugeValue)
+
+		buf := make([]byte, 5)
+		_, err := io.ReadFull(conn, buf)
+		require.NoError(t, err)
+
+		_, err = conn.Write([]byte("bye"))
+		require.NoError(t, err)
+
+		require.NoError(t, conn.Close())
+		require.NoError(t, conn.Close())
+	})
+
+	handler := &tcpMetricsHandler{
+		next: next,
+		metrics: connMetrics{
+			connsCounter:          connsCounter,
+			activeConnsGauge:      activeConnsGauge,
+			connDurationHistogram: connDurationHistogram,
+			bytesReceivedCounter:  bytesReceivedCounter,
+			bytesSentCounter:      bytesSentCounter,
+			baseLabels:            []string{"entrypoint", "tcp"},
+		},
+	}
+
+	handler.ServeTCP(&fakeConn{reader: strings.NewReader("hello")})
+
+	expectedLabels := []string{"entrypoint", "tcp", "protocol", "tcp"}
+
+	assert.Equal(t, float64(1), connsCounter.CounterValue)
+	assert.Equal(t, expectedLabels, connsCounter.LastLabelValues)
+	assert.Equal(t, float64(0), activeConnsGauge.GaugeValue)
+	assert.Equal(t, expectedLabels, activeConnsGauge.LastLabelValues)
+	assert.Equal(t, 1, connDurationHistogram.Count)
+	assert.Equal(t, float64(5), bytesReceivedCounter.CounterValue)
+	assert.Equal(t, float64(3), bytesSentCounter.CounterValue)
+}
+
+type fakeConn struct {
+	net.Conn
+	reader io.Reader
+	writer bytes.Buffer
+}
+
+func (c *fakeConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+func (c *fakeConn) Write(p []byte) (int, error) {
+	return c.writer.Write(p)
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) CloseWrite() error {
+	return nil
+}
+
+// collectingGauge is a metrics.Gauge implementation that enables access to the GaugeValue and LastLabelValues.
+type collectingGauge struct {
+	GaugeValue      float64
+	LastLabelValues []string
+}
+
+func (g *collectingGauge) With(labelValues ...string) gokitmetrics.Gauge {
+	g.LastLabelValues = labelValues
+	return g
+}
+
+func (g *collectingGauge) Set(value float64) {
+	g.GaugeValue = value
+}
+
+func (g *collectingGauge) Add(delta float64) {
+	g.GaugeValue += delta
+}
+
+// collectingHistogram is a metrics.ScalableHistogram implementation that counts the observations.
+type collectingHistogram struct {
+	Count int
+}
+
+func (h *collectingHistogram) With(_ ...string) metrics.ScalableHistogram {
+	return h
+}
+
+func (h *collectingHistogram) Observe(_ float64) {
+	h.Count++
+}
+
+func (h *collectingHistogram) ObserveFromStart(_ time.Time) {
+	h.Count++
+}
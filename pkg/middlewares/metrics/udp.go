@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/metrics"
+	"github.com/traefik/traefik/v2/pkg/middlewares"
+	"github.com/traefik/traefik/v2/pkg/udp"
+)
+
+type udpMetricsHandler struct {
+	next    udp.Handler
+	metrics connMetrics
+}
+
+// NewUDPEntryPointHandler creates a new metrics handler for the UDP sessions of an Entrypoint.
+func NewUDPEntryPointHandler(ctx context.Context, next udp.Handler, registry metrics.Registry, entryPointName string) udp.Handler {
+	log.FromContext(middlewares.GetLoggerCtx(ctx, nameEntrypoint, typeName)).Debug("Creating UDP middleware")
+
+	return &udpMetricsHandler{
+		next:    next,
+		metrics: newEntryPointConnMetrics(registry, entryPointName),
+	}
+}
+
+// NewUDPRouterHandler creates a new metrics handler for the UDP sessions of a Router.
+func NewUDPRouterHandler(ctx context.Context, next udp.Handler, registry metrics.Registry, routerName, serviceName string) udp.Handler {
+	log.FromContext(middlewares.GetLoggerCtx(ctx, nameRouter, typeName)).Debug("Creating UDP middleware")
+
+	return &udpMetricsHandler{
+		next:    next,
+		metrics: newRouterConnMetrics(registry, routerName, serviceName),
+	}
+}
+
+// NewUDPServiceHandler creates a new metrics handler for the UDP sessions of a Service.
+func NewUDPServiceHandler(ctx context.Context, next udp.Handler, registry metrics.Registry, serviceName string) udp.Handler {
+	log.FromContext(middlewares.GetLoggerCtx(ctx, nameService, typeName)).Debug("Creating UDP middleware")
+
+	return &udpMetricsHandler{
+		next:    next,
+		metrics: newServiceConnMetrics(registry, serviceName),
+	}
+}
+
+// ServeUDP forwards the session to the next handler,
+// the metrics being recorded until the next handler returns, i.e. until the session ends.
+func (h *udpMetricsHandler) ServeUDP(conn *udp.Conn) {
+	tracker := h.metrics.track(protoUDP)
+	defer tracker.close()
+
+	conn.AddObserver(tracker)
+
+	h.next.ServeUDP(conn)
+}
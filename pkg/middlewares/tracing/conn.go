@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"net"
+	"sync"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/traefik/traefik/v2/pkg/tracing"
+)
+
+// connSpans holds the spans of a TCP connection or a UDP session handled by a router,
+// and is notified of its forwarding to a backend.
+type connSpans struct {
+	tracer      *tracing.Tracing
+	protocol    string
+	serviceName string
+
+	entryPoint opentracing.Span
+	router     opentracing.Span
+
+	mu        sync.Mutex
+	dial      opentracing.Span
+	connected bool
+}
+
+// dialing starts the span of the connection to the backend.
+func (s *connSpans) dialing() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dial = s.tracer.StartConnSpanf(s.router, ext.SpanKindRPCClientEnum, s.protocol+" Dial", []string{s.serviceName}, " ")
+	s.dial.SetTag("service.name", s.serviceName)
+}
+
+// BackendConnected ends the span of the connection to the backend.
+func (s *connSpans) BackendConnected(addr net.Addr) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.connected = true
+
+	if s.dial != nil {
+		ext.PeerAddress.Set(s.dial, addr.String())
+		s.dial.Finish()
+	}
+}
+
+// Done flags the spans as in error if the forwarding ended with an error.
+func (s *connSpans) Done(err error) {
+	if err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.connected && s.dial != nil {
+		ext.Error.Set(s.dial, true)
+		s.dial.LogKV("event", err.Error())
+	}
+
+	ext.Error.Set(s.router, true)
+	s.router.LogKV("event", err.Error())
+	ext.Error.Set(s.entryPoint, true)
+}
+
+// finish ends the spans still in progress, once the connection or the session ended.
+func (s *connSpans) finish() {
+	s.mu.Lock()
+	if !s.connected && s.dial != nil {
+		s.dial.Finish()
+	}
+	s.mu.Unlock()
+
+	s.router.Finish()
+	s.entryPoint.Finish()
+}
@@ -0,0 +1,163 @@
+package tracing
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/middlewares"
+	"github.com/traefik/traefik/v2/pkg/tcp"
+	traefiktls "github.com/traefik/traefik/v2/pkg/tls"
+	"github.com/traefik/traefik/v2/pkg/tracing"
+)
+
+const (
+	tcpEntryPointTypeName = "TracingTCPEntryPoint"
+	tcpRouterTypeName     = "TracingTCPRouter"
+)
+
+// NewTCPEntryPoint creates a new handler recording the time at which the TCP connections are accepted by the entry point,
+// their spans being started once they are handled by a TCP router (see NewTCPRouter).
+func NewTCPEntryPoint(ctx context.Context, next tcp.Handler) tcp.Handler {
+	log.FromContext(middlewares.GetLoggerCtx(ctx, "tracing", tcpEntryPointTypeName)).Debug("Creating TCP middleware")
+
+	return &tcpEntryPoint{next: next}
+}
+
+type tcpEntryPoint struct {
+	next tcp.Handler
+}
+
+func (e *tcpEntryPoint) ServeTCP(conn tcp.WriteCloser) {
+	e.next.ServeTCP(&acceptedConn{WriteCloser: conn, accepted: time.Now()})
+}
+
+// acceptedConn is a TCP connection accepted by a traced entry point.
+type acceptedConn struct {
+	tcp.WriteCloser
+	accepted time.Time
+}
+
+// Unwrap returns the accepted connection.
+func (c *acceptedConn) Unwrap() tcp.WriteCloser {
+	return c.WriteCloser
+}
+
+// NewTCPRouter creates a new handler tracing the TCP connections handled by a router:
+// the spans of the entry point, of the routing of the connection (when the entry point records it), of the router,
+// of the TLS handshake (when the router terminates the TLS connection), and of the connection to the backend.
+func NewTCPRouter(ctx context.Context, t *tracing.Tracing, entryPointName, routerName, serviceName string, next tcp.Handler) tcp.Handler {
+	log.FromContext(middlewares.GetLoggerCtx(ctx, "tracing", tcpRouterTypeName)).Debug("Creating TCP middleware")
+
+	return &tcpRouter{
+		Tracing:    t,
+		entryPoint: entryPointName,
+		router:     routerName,
+		service:    serviceName,
+		next:       next,
+	}
+}
+
+type tcpRouter struct {
+	*tracing.Tracing
+	entryPoint string
+	router     string
+	service    string
+	next       tcp.Handler
+}
+
+func (r *tcpRouter) ServeTCP(conn tcp.WriteCloser) {
+	routed := time.Now()
+
+	var accepted *acceptedConn
+	var tlsConn *tls.Conn
+	var serverName string
+
+	for c := conn; c != nil; c = tcp.Unwrap(c) {
+		switch cc := c.(type) {
+		case *acceptedConn:
+			accepted = cc
+		case *tls.Conn:
+			if tlsConn == nil {
+				tlsConn = cc
+			}
+		case *tcp.Conn:
+			if serverName == "" {
+				serverName = cc.ServerName
+			}
+		}
+	}
+
+	start := routed
+	if accepted != nil {
+		start = accepted.accepted
+	}
+
+	opParts := []string{r.entryPoint}
+	if serverName != "" {
+		opParts = append(opParts, serverName)
+	}
+
+	spans := &connSpans{tracer: r.Tracing, protocol: "TCP", serviceName: r.service}
+
+	spans.entryPoint = r.StartConnSpanf(nil, ext.SpanKindRPCServerEnum, "TCP EntryPoint", opParts, " ", opentracing.StartTime(start))
+	ext.Component.Set(spans.entryPoint, r.ServiceName)
+	if addr := conn.RemoteAddr(); addr != nil {
+		ext.PeerAddress.Set(spans.entryPoint, addr.String())
+	}
+	if serverName != "" {
+		spans.entryPoint.SetTag("tls.server_name", serverName)
+	}
+
+	if accepted != nil {
+		routing := r.StartConnSpanf(spans.entryPoint, tracing.SpanKindNoneEnum, "TCP Routing", []string{r.entryPoint}, " ", opentracing.StartTime(start))
+		routing.SetTag("router.name", r.router)
+		routing.FinishWithOptions(opentracing.FinishOptions{FinishTime: routed})
+	}
+
+	spans.router = r.StartConnSpanf(spans.entryPoint, tracing.SpanKindNoneEnum, "TCP Router", []string{r.router}, " ", opentracing.StartTime(routed))
+	spans.router.SetTag("router.name", r.router)
+	spans.router.SetTag("service.name", r.service)
+
+	defer spans.finish()
+
+	if tlsConn != nil && !tlsConn.ConnectionState().HandshakeComplete {
+		r.handshake(spans.router, tlsConn)
+	}
+
+	spans.dialing()
+
+	r.next.ServeTCP(&tracedConn{WriteCloser: conn, connSpans: spans})
+}
+
+// handshake performs the TLS handshake of the connection, for its span to be recorded.
+// The connection is handed over to the next handler even if the handshake fails, for the error to be reported by it as usual.
+func (r *tcpRouter) handshake(parent opentracing.Span, conn *tls.Conn) {
+	span := r.StartConnSpanf(parent, tracing.SpanKindNoneEnum, "TLS Handshake", []string{r.router}, " ")
+	defer span.Finish()
+
+	if err := conn.Handshake(); err != nil {
+		ext.Error.Set(span, true)
+		span.LogKV("event", err.Error())
+		return
+	}
+
+	state := conn.ConnectionState()
+	span.SetTag("tls.version", traefiktls.GetVersion(&state))
+	span.SetTag("tls.cipher", traefiktls.GetCipherName(&state))
+}
+
+// tracedConn is a TCP connection handled by a traced router,
+// notified of its forwarding to a backend.
+type tracedConn struct {
+	tcp.WriteCloser
+	*connSpans
+}
+
+// Unwrap returns the traced connection.
+func (c *tracedConn) Unwrap() tcp.WriteCloser {
+	return c.WriteCloser
+}
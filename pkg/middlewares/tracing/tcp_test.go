@@ -0,0 +1,108 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/tcp"
+	"github.com/traefik/traefik/v2/pkg/tracing"
+)
+
+type fakeConn struct {
+	net.Conn
+}
+
+func (c fakeConn) CloseWrite() error {
+	return nil
+}
+
+func TestTCPRouter(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		forward     func(observer tcp.ProxyObserver)
+		expectedErr bool
+	}{
+		{
+			desc: "connected to the backend",
+			forward: func(observer tcp.ProxyObserver) {
+				observer.BackendConnected(&net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 80})
+				observer.Done(nil)
+			},
+		},
+		{
+			desc: "backend unreachable",
+			forward: func(observer tcp.ProxyObserver) {
+				observer.Done(errors.New("connection refused"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			tracer := mocktracer.New()
+
+			newTracing, err := tracing.NewTracing("traefik", 0, &trackingBackenMock{tracer: tracer})
+			require.NoError(t, err)
+
+			next := tcp.HandlerFunc(func(conn tcp.WriteCloser) {
+				observer, ok := conn.(tcp.ProxyObserver)
+				require.True(t, ok)
+
+				test.forward(observer)
+			})
+
+			handler := NewTCPRouter(context.Background(), newTracing, "web", "router", "service", next)
+			handler = NewTCPEntryPoint(context.Background(), handler)
+
+			server, client := net.Pipe()
+			defer func() { _ = client.Close() }()
+
+			handler.ServeTCP(fakeConn{Conn: server})
+
+			spans := map[string]*mocktracer.MockSpan{}
+			for _, span := range tracer.FinishedSpans() {
+				spans[span.OperationName] = span
+			}
+			require.Len(t, spans, 4)
+
+			entryPoint := spans["TCP EntryPoint web"]
+			require.NotNil(t, entryPoint)
+			assert.Equal(t, ext.SpanKindRPCServerEnum, entryPoint.Tag("span.kind"))
+			assert.Equal(t, "traefik", entryPoint.Tag("component"))
+
+			routing := spans["TCP Routing web"]
+			require.NotNil(t, routing)
+			assert.Equal(t, entryPoint.SpanContext.SpanID, routing.ParentID)
+
+			router := spans["TCP Router router"]
+			require.NotNil(t, router)
+			assert.Equal(t, entryPoint.SpanContext.SpanID, router.ParentID)
+			assert.Equal(t, "service", router.Tag("service.name"))
+
+			dial := spans["TCP Dial service"]
+			require.NotNil(t, dial)
+			assert.Equal(t, router.SpanContext.SpanID, dial.ParentID)
+			assert.Equal(t, ext.SpanKindRPCClientEnum, dial.Tag("span.kind"))
+
+			if test.expectedErr {
+				assert.Equal(t, true, dial.Tag("error"))
+				assert.Equal(t, true, router.Tag("error"))
+				assert.Equal(t, true, entryPoint.Tag("error"))
+				assert.Nil(t, dial.Tag("peer.address"))
+				return
+			}
+
+			assert.Nil(t, dial.Tag("error"))
+			assert.Nil(t, router.Tag("error"))
+			assert.Equal(t, "10.0.0.1:80", dial.Tag("peer.address"))
+		})
+	}
+}
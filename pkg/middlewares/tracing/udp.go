@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/middlewares"
+	"github.com/traefik/traefik/v2/pkg/tracing"
+	"github.com/traefik/traefik/v2/pkg/udp"
+)
+
+const udpRouterTypeName = "TracingUDPRouter"
+
+// NewUDPRouter creates a new handler tracing the UDP sessions handled by a router:
+// the spans of the entry point, of the router, and of the connection to the backend.
+func NewUDPRouter(ctx context.Context, t *tracing.Tracing, entryPointName, routerName, serviceName string, next udp.Handler) udp.Handler {
+	log.FromContext(middlewares.GetLoggerCtx(ctx, "tracing", udpRouterTypeName)).Debug("Creating UDP middleware")
+
+	return &udpRouter{
+		Tracing:    t,
+		entryPoint: entryPointName,
+		router:     routerName,
+		service:    serviceName,
+		next:       next,
+	}
+}
+
+type udpRouter struct {
+	*tracing.Tracing
+	entryPoint string
+	router     string
+	service    string
+	next       udp.Handler
+}
+
+func (r *udpRouter) ServeUDP(conn *udp.Conn) {
+	spans := &connSpans{tracer: r.Tracing, protocol: "UDP", serviceName: r.service}
+
+	spans.entryPoint = r.StartConnSpanf(nil, ext.SpanKindRPCServerEnum, "UDP EntryPoint", []string{r.entryPoint}, " ")
+	ext.Component.Set(spans.entryPoint, r.ServiceName)
+	if addr := conn.RemoteAddr(); addr != nil {
+		ext.PeerAddress.Set(spans.entryPoint, addr.String())
+	}
+
+	spans.router = r.StartConnSpanf(spans.entryPoint, tracing.SpanKindNoneEnum, "UDP Router", []string{r.router}, " ")
+	spans.router.SetTag("router.name", r.router)
+	spans.router.SetTag("service.name", r.service)
+
+	defer spans.finish()
+
+	conn.AddObserver(&udpObserver{spans})
+	spans.dialing()
+
+	r.next.ServeUDP(conn)
+}
+
+// udpObserver is notified of the forwarding of a UDP session to a backend,
+// its traffic not being traced.
+type udpObserver struct {
+	*connSpans
+}
+
+// Received does nothing.
+func (o *udpObserver) Received(int) {}
+
+// Sent does nothing.
+func (o *udpObserver) Sent(int) {}
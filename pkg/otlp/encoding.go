@@ -0,0 +1,126 @@
+// Package otlp provides the OpenTelemetry Protocol (OTLP) transports,
+// and the encoding of the messages common to the OTLP signals.
+// https://github.com/open-telemetry/opentelemetry-proto
+package otlp
+
+import (
+	"math"
+	"sort"
+
+	"github.com/traefik/traefik/v2/pkg/version"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// InstrumentationScope is the name of the OTLP instrumentation scope of the exported data.
+const InstrumentationScope = "github.com/traefik/traefik"
+
+// KeyValue is an OTLP attribute,
+// the value being either a string, a bool, an int64 or a float64.
+type KeyValue struct {
+	Key   string
+	Value interface{}
+}
+
+// ResourceAttributes returns the attributes describing the Traefik instance,
+// the service name being overridable by the given attributes.
+func ResourceAttributes(serviceName string, attributes map[string]string) []KeyValue {
+	attrs := map[string]string{"service.name": serviceName}
+	for k, v := range attributes {
+		attrs[k] = v
+	}
+
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]KeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, KeyValue{Key: k, Value: attrs[k]})
+	}
+
+	return kvs
+}
+
+// MarshalResource encodes the Resource message.
+func MarshalResource(attributes []KeyValue) []byte {
+	return AppendAttributes(nil, 1, attributes)
+}
+
+// MarshalScope encodes the InstrumentationScope message.
+func MarshalScope() []byte {
+	var b []byte
+	b = AppendString(b, 1, InstrumentationScope)
+	return AppendString(b, 2, version.Version)
+}
+
+// AppendAttributes appends the attributes as repeated KeyValue messages of the given field number.
+func AppendAttributes(b []byte, num protowire.Number, attrs []KeyValue) []byte {
+	for _, attr := range attrs {
+		var kv []byte
+		kv = AppendString(kv, 1, attr.Key)
+		kv = AppendMessage(kv, 2, marshalAnyValue(attr.Value))
+
+		b = AppendMessage(b, num, kv)
+	}
+
+	return b
+}
+
+// AppendMessage appends the embedded message field.
+func AppendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+// AppendString appends the string field, omitting it when empty as proto3 does.
+func AppendString(b []byte, num protowire.Number, value string) []byte {
+	if value == "" {
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, value)
+}
+
+// AppendFixed64 appends the fixed64 field.
+func AppendFixed64(b []byte, num protowire.Number, value uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, value)
+}
+
+// AppendDouble appends the double field.
+func AppendDouble(b []byte, num protowire.Number, value float64) []byte {
+	return AppendFixed64(b, num, math.Float64bits(value))
+}
+
+// AppendVarint appends the varint field, omitting it when zero as proto3 does.
+func AppendVarint(b []byte, num protowire.Number, value uint64) []byte {
+	if value == 0 {
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, value)
+}
+
+func marshalAnyValue(value interface{}) []byte {
+	var b []byte
+
+	switch v := value.(type) {
+	case bool:
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(v))
+	case int64:
+		b = protowire.AppendTag(b, 3, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(v))
+	case float64:
+		b = AppendDouble(b, 4, v)
+	case string:
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, v)
+	}
+
+	return b
+}
@@ -0,0 +1,204 @@
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/traefik/traefik/v2/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// The OTLP/gRPC service methods.
+const (
+	TracesGRPCMethod  = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+	MetricsGRPCMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+)
+
+// Default addresses of the collector endpoint, depending on the transport.
+const (
+	DefaultHTTPAddress = "localhost:4318"
+	DefaultGRPCAddress = "localhost:4317"
+)
+
+// Endpoint describes the collector endpoint the payloads are exported to.
+type Endpoint struct {
+	// Address is the address (host:port) of the collector endpoint,
+	// the default address of the transport being used when empty.
+	Address  string
+	Path     string
+	Insecure bool
+	Headers  map[string]string
+	TLS      *types.ClientTLS
+	// GRPC enables the OTLP/gRPC transport instead of OTLP/HTTP.
+	GRPC bool
+}
+
+// Exporter sends the encoded Export*ServiceRequest payloads to the collector.
+type Exporter interface {
+	Export(ctx context.Context, payload []byte) error
+	Close() error
+}
+
+// NewExporter creates an exporter for the endpoint,
+// the gRPC method being the service method of the exported signal.
+func NewExporter(ctx context.Context, endpoint Endpoint, grpcMethod string) (Exporter, error) {
+	if endpoint.GRPC {
+		if endpoint.Address == "" {
+			endpoint.Address = DefaultGRPCAddress
+		}
+		return newGRPCExporter(ctx, endpoint, grpcMethod)
+	}
+
+	if endpoint.Address == "" {
+		endpoint.Address = DefaultHTTPAddress
+	}
+	return newHTTPExporter(ctx, endpoint)
+}
+
+// httpExporter exports the payloads with OTLP/HTTP, using the binary protobuf encoding.
+type httpExporter struct {
+	client   *http.Client
+	endpoint string
+	headers  map[string]string
+}
+
+func newHTTPExporter(ctx context.Context, endpoint Endpoint) (*httpExporter, error) {
+	scheme := "https"
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if endpoint.Insecure {
+		scheme = "http"
+	} else if endpoint.TLS != nil {
+		tlsConfig, err := endpoint.TLS.CreateTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating TLS configuration: %w", err)
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	u := url.URL{Scheme: scheme, Host: endpoint.Address, Path: endpoint.Path}
+
+	return &httpExporter{
+		client:   &http.Client{Transport: transport},
+		endpoint: u.String(),
+		headers:  endpoint.Headers,
+	}, nil
+}
+
+func (e *httpExporter) Export(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (e *httpExporter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// grpcExporter exports the payloads with OTLP/gRPC.
+type grpcExporter struct {
+	conn    *grpc.ClientConn
+	method  string
+	headers metadata.MD
+}
+
+func newGRPCExporter(ctx context.Context, endpoint Endpoint, method string) (*grpcExporter, error) {
+	var opts []grpc.DialOption
+
+	switch {
+	case endpoint.Insecure:
+		opts = append(opts, grpc.WithInsecure())
+	case endpoint.TLS != nil:
+		tlsConfig, err := endpoint.TLS.CreateTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating TLS configuration: %w", err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	default:
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+	}
+
+	// The connection is established lazily, on the first export.
+	conn, err := grpc.DialContext(ctx, endpoint.Address, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcExporter{
+		conn:    conn,
+		method:  method,
+		headers: metadata.New(endpoint.Headers),
+	}, nil
+}
+
+func (e *grpcExporter) Export(ctx context.Context, payload []byte) error {
+	ctx = metadata.NewOutgoingContext(ctx, e.headers)
+
+	var reply []byte
+	return e.conn.Invoke(ctx, e.method, &payload, &reply, grpc.ForceCodec(rawCodec{}))
+}
+
+func (e *grpcExporter) Close() error {
+	return e.conn.Close()
+}
+
+// rawCodec is a gRPC codec passing the already encoded messages through.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type: %T", v)
+	}
+
+	return *b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type: %T", v)
+	}
+
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+// Name implements encoding.Codec.
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// String implements grpc.Codec.
+func (rawCodec) String() string {
+	return "proto"
+}
@@ -0,0 +1,108 @@
+package otlp
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestExporter_HTTP(t *testing.T) {
+	payloads := make(chan []byte, 10)
+
+	collector := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/v1/metrics", req.URL.Path)
+		assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", req.Header.Get("X-Api-Key"))
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		if string(body) == "fail" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		payloads <- body
+	}))
+	t.Cleanup(collector.Close)
+
+	exporter, err := NewExporter(context.Background(), Endpoint{
+		Address:  strings.TrimPrefix(collector.URL, "http://"),
+		Path:     "/v1/metrics",
+		Insecure: true,
+		Headers:  map[string]string{"X-Api-Key": "secret"},
+	}, MetricsGRPCMethod)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, exporter.Close()) })
+
+	require.NoError(t, exporter.Export(context.Background(), []byte("payload")))
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "payload", string(<-payloads))
+
+	assert.Error(t, exporter.Export(context.Background(), []byte("fail")))
+}
+
+func TestExporter_GRPC(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	payloads := make(chan []byte, 10)
+
+	collector := grpc.NewServer(
+		grpc.CustomCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+			method, _ := grpc.MethodFromServerStream(stream)
+			assert.Equal(t, TracesGRPCMethod, method)
+
+			md, _ := metadata.FromIncomingContext(stream.Context())
+			assert.Equal(t, []string{"secret"}, md.Get("x-api-key"))
+
+			var payload []byte
+			if err := stream.RecvMsg(&payload); err != nil {
+				return err
+			}
+			payloads <- payload
+
+			return stream.SendMsg(&[]byte{})
+		}),
+	)
+	go func() { _ = collector.Serve(listener) }()
+	t.Cleanup(collector.Stop)
+
+	exporter, err := NewExporter(context.Background(), Endpoint{
+		Address:  listener.Addr().String(),
+		Insecure: true,
+		Headers:  map[string]string{"X-Api-Key": "secret"},
+		GRPC:     true,
+	}, TracesGRPCMethod)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, exporter.Close()) })
+
+	require.NoError(t, exporter.Export(context.Background(), []byte("payload")))
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "payload", string(<-payloads))
+}
+
+func TestNewExporter_defaultAddress(t *testing.T) {
+	exporter, err := NewExporter(context.Background(), Endpoint{Path: "/v1/traces"}, TracesGRPCMethod)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = exporter.Close() })
+
+	assert.Equal(t, "https://localhost:4318/v1/traces", exporter.(*httpExporter).endpoint)
+
+	exporter, err = NewExporter(context.Background(), Endpoint{GRPC: true}, TracesGRPCMethod)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = exporter.Close() })
+
+	assert.Equal(t, "localhost:4317", exporter.(*grpcExporter).conn.Target())
+}
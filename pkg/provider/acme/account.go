@@ -87,3 +87,22 @@ func GetKeyType(ctx context.Context, value string) certcrypto.KeyType {
 		return certcrypto.RSA4096
 	}
 }
+
+// isECDSAKeyType returns whether the key type generates ECDSA keys, the other ones generating RSA keys.
+func isECDSAKeyType(keyType certcrypto.KeyType) bool {
+	return keyType == certcrypto.EC256 || keyType == certcrypto.EC384
+}
+
+// knownKeyTypes are the key types handled by GetKeyType.
+var knownKeyTypes = []string{"EC256", "EC384", "RSA2048", "RSA4096", "RSA8192"}
+
+// isKnownKeyType returns whether the key type is handled by GetKeyType, which falls back on RSA4096 otherwise.
+func isKnownKeyType(value string) bool {
+	for _, keyType := range knownKeyTypes {
+		if value == keyType {
+			return true
+		}
+	}
+
+	return false
+}
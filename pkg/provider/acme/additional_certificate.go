@@ -0,0 +1,128 @@
+package acme
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// resolveAdditionalCertificateInBackground loads or orders the certificate of the additional key type for the domains,
+// in the background, once their main certificate has been obtained.
+// A failed order is retried with the backoff of the renewals, until the delay reaches the one of the renewal checks,
+// which then obtain the missing certificate.
+func (p *Provider) resolveAdditionalCertificateInBackground(domains []string, domain types.Domain, tlsStore string) {
+	ctx := log.With(context.Background(), log.Str(log.ProviderName, p.ResolverName+".acme"))
+
+	p.pool.GoCtx(func(ctxPool context.Context) {
+		for failures := 1; ; failures++ {
+			err := p.resolveAdditionalCertificate(ctx, domains, domain, tlsStore)
+			if err == nil {
+				return
+			}
+
+			log.FromContext(ctx).Error(err)
+
+			backoff := renewalBackoff(failures)
+			if backoff >= maxRenewalBackoff {
+				return
+			}
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-ctxPool.Done():
+				timer.Stop()
+				return
+			}
+		}
+	})
+}
+
+// resolveAdditionalCertificate loads or orders the certificate of the additional key type for the domains,
+// unless it is already being resolved, or has already been obtained.
+func (p *Provider) resolveAdditionalCertificate(ctx context.Context, domains []string, domain types.Domain, tlsStore string) error {
+	key := tlsStore + "/" + certificateLockName(domain)
+	if !p.startResolvingAdditional(key) {
+		return nil
+	}
+	defer p.stopResolvingAdditional(key)
+
+	unlock, err := p.lock(certificateLockName(domain))
+	if err != nil {
+		return fmt.Errorf("unable to lock the domains %v: %w", domain.ToStrArray(), err)
+	}
+	defer unlock()
+
+	main := &CertAndStore{Certificate: Certificate{Domain: domain}, Store: tlsStore}
+	if hasAdditionalCertificate(p.getCertificates(), main, p.AdditionalKeyType) {
+		return nil
+	}
+
+	if p.loadSharedCertificate(ctx, domain, tlsStore, p.AdditionalKeyType) != nil {
+		return nil
+	}
+
+	if _, err := p.orderCertificate(ctx, domains, domain, tlsStore, p.AdditionalKeyType); err != nil {
+		return fmt.Errorf("unable to obtain the %s certificate for domains %v: %w", p.AdditionalKeyType, domain.ToStrArray(), err)
+	}
+
+	return nil
+}
+
+// startResolvingAdditional marks the additional certificate of the given key as being resolved,
+// and returns false if it already was.
+func (p *Provider) startResolvingAdditional(key string) bool {
+	p.resolvingAdditionalMu.Lock()
+	defer p.resolvingAdditionalMu.Unlock()
+
+	if _, ok := p.resolvingAdditional[key]; ok {
+		return false
+	}
+
+	if p.resolvingAdditional == nil {
+		p.resolvingAdditional = make(map[string]struct{})
+	}
+	p.resolvingAdditional[key] = struct{}{}
+
+	return true
+}
+
+func (p *Provider) stopResolvingAdditional(key string) {
+	p.resolvingAdditionalMu.Lock()
+	defer p.resolvingAdditionalMu.Unlock()
+
+	delete(p.resolvingAdditional, key)
+}
+
+// obtainMissingAdditionalCertificates obtains the certificates of the additional key type
+// for the main certificates obtained before it was configured, or whose additional certificate could not be obtained.
+func (p *Provider) obtainMissingAdditionalCertificates(ctx context.Context) {
+	certificates := p.getCertificates()
+
+	for _, cert := range certificates {
+		if cert.KeyType != "" || hasAdditionalCertificate(certificates, cert, p.AdditionalKeyType) {
+			continue
+		}
+
+		log.FromContext(ctx).Infof("Obtaining the %s certificate for domains %v", p.AdditionalKeyType, cert.Domain.ToStrArray())
+
+		if err := p.resolveAdditionalCertificate(ctx, cert.Domain.ToStrArray(), cert.Domain, cert.Store); err != nil {
+			log.FromContext(ctx).Error(err)
+		}
+	}
+}
+
+// hasAdditionalCertificate returns whether the certificates include the one of the key type for the domains of the main certificate.
+func hasAdditionalCertificate(certificates []*CertAndStore, main *CertAndStore, keyType string) bool {
+	for _, cert := range certificates {
+		if cert.KeyType == keyType && cert.Store == main.Store && reflect.DeepEqual(cert.Domain, main.Domain) {
+			return true
+		}
+	}
+
+	return false
+}
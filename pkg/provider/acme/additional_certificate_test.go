@@ -0,0 +1,149 @@
+package acme
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestHasAdditionalCertificate(t *testing.T) {
+	foo := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}}, Store: "default"}
+	fooEC := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}, KeyType: "EC256"}, Store: "default"}
+	bar := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "bar.com"}}, Store: "default"}
+	barEC := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "bar.com"}, KeyType: "EC256"}, Store: "other"}
+
+	certificates := []*CertAndStore{foo, fooEC, bar, barEC}
+
+	assert.True(t, hasAdditionalCertificate(certificates, foo, "EC256"))
+	assert.False(t, hasAdditionalCertificate(certificates, foo, "EC384"))
+	assert.False(t, hasAdditionalCertificate(certificates, bar, "EC256"))
+}
+
+func TestProvider_removeCertificates_keyTypes(t *testing.T) {
+	foo := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}, Certificate: []byte("cert"), Key: []byte("key")}, Store: "default"}
+	fooEC := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}, Certificate: []byte("cert-ec"), Key: []byte("key"), KeyType: "EC256"}, Store: "default"}
+
+	// The certificates of both key types are stored side by side.
+	store := newKVStore(newKVClientMock(), "traefik/acme", 30*time.Second)
+	require.NoError(t, store.SaveCertificates("myresolver", []*CertAndStore{foo, fooEC}))
+
+	stored, err := store.GetCertificates("myresolver")
+	require.NoError(t, err)
+	assert.Len(t, stored, 2)
+
+	configurationChan := make(chan dynamic.Message, 1)
+	p := &Provider{
+		ResolverName:      "myresolver",
+		Store:             store,
+		certificates:      []*CertAndStore{foo, fooEC},
+		configurationChan: configurationChan,
+	}
+
+	p.removeCertificates(context.Background(), []*CertAndStore{fooEC})
+
+	assert.Equal(t, []*CertAndStore{foo}, p.getCertificates())
+
+	stored, err = store.GetCertificates("myresolver")
+	require.NoError(t, err)
+	assert.Equal(t, []*CertAndStore{foo}, stored)
+
+	msg := <-configurationChan
+	assert.Len(t, msg.Configuration.TLS.Certificates, 1)
+}
+
+func TestProvider_Init_additionalKeyType(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		keyType           string
+		additionalKeyType string
+		expectedErr       bool
+	}{
+		{
+			desc:              "valid key type",
+			additionalKeyType: "EC256",
+		},
+		{
+			desc:              "unknown key type",
+			additionalKeyType: "EC521",
+			expectedErr:       true,
+		},
+		{
+			desc:              "same key type",
+			additionalKeyType: "RSA4096",
+			expectedErr:       true,
+		},
+		{
+			desc:              "RSA key types",
+			additionalKeyType: "RSA2048",
+			expectedErr:       true,
+		},
+		{
+			desc:              "ECDSA key types",
+			keyType:           "EC256",
+			additionalKeyType: "EC384",
+			expectedErr:       true,
+		},
+		{
+			desc:              "ECDSA key type and RSA additional key type",
+			keyType:           "EC384",
+			additionalKeyType: "RSA2048",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			keyType := test.keyType
+			if keyType == "" {
+				keyType = "RSA4096"
+			}
+
+			p := &Provider{
+				Configuration: &Configuration{Storage: "acme.json", KeyType: keyType, AdditionalKeyType: test.additionalKeyType},
+				ResolverName:  "myresolver",
+				Store:         newKVStore(newKVClientMock(), "traefik/acme", 30*time.Second),
+			}
+
+			err := p.Init()
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestProvider_resolveAdditionalCertificate_alreadyObtained(t *testing.T) {
+	domain := types.Domain{Main: "foo.com"}
+	fooEC := &CertAndStore{Certificate: Certificate{Domain: domain, Certificate: []byte("cert-ec"), Key: []byte("key"), KeyType: "EC256"}, Store: "default"}
+
+	p := &Provider{
+		Configuration: &Configuration{AdditionalKeyType: "EC256"},
+		ResolverName:  "myresolver",
+		Store:         newKVStore(newKVClientMock(), "traefik/acme", 30*time.Second),
+		certificates:  []*CertAndStore{fooEC},
+	}
+
+	// No certificate is ordered, which would fail without an ACME client.
+	assert.NoError(t, p.resolveAdditionalCertificate(context.Background(), domain.ToStrArray(), domain, "default"))
+}
+
+func TestProvider_startResolvingAdditional(t *testing.T) {
+	p := &Provider{}
+
+	assert.True(t, p.startResolvingAdditional("default/foo.com"))
+	assert.False(t, p.startResolvingAdditional("default/foo.com"))
+	assert.True(t, p.startResolvingAdditional("default/bar.com"))
+
+	p.stopResolvingAdditional("default/foo.com")
+	assert.True(t, p.startResolvingAdditional("default/foo.com"))
+}
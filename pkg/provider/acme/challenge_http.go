@@ -20,6 +20,7 @@ import (
 // ChallengeHTTP HTTP challenge provider implements challenge.Provider.
 type ChallengeHTTP struct {
 	httpChallenges map[string]map[string][]byte
+	stores         []ChallengeStore
 	lock           sync.RWMutex
 }
 
@@ -30,10 +31,18 @@ func NewChallengeHTTP() *ChallengeHTTP {
 	}
 }
 
+// AddStore adds a store shared by several Traefik instances,
+// to which the challenges are presented for all of them to serve them.
+func (c *ChallengeHTTP) AddStore(store ChallengeStore) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.stores = append(c.stores, store)
+}
+
 // Present presents a challenge to obtain new ACME certificate.
 func (c *ChallengeHTTP) Present(domain, token, keyAuth string) error {
 	c.lock.Lock()
-	defer c.lock.Unlock()
 
 	if _, ok := c.httpChallenges[token]; !ok {
 		c.httpChallenges[token] = map[string][]byte{}
@@ -41,11 +50,32 @@ func (c *ChallengeHTTP) Present(domain, token, keyAuth string) error {
 
 	c.httpChallenges[token][domain] = []byte(keyAuth)
 
+	stores := c.stores
+	c.lock.Unlock()
+
+	// The shared stores are not called with the lock held, not to block the challenge requests.
+	for _, store := range stores {
+		if err := store.SetHTTPChallenge(token, domain, []byte(keyAuth)); err != nil {
+			return fmt.Errorf("unable to share the challenge for token %s: %w", token, err)
+		}
+	}
+
 	return nil
 }
 
 // CleanUp cleans the challenges when certificate is obtained.
 func (c *ChallengeHTTP) CleanUp(domain, token, _ string) error {
+	c.lock.RLock()
+	stores := c.stores
+	c.lock.RUnlock()
+
+	for _, store := range stores {
+		if err := store.RemoveHTTPChallenge(token, domain); err != nil {
+			log.WithoutContext().WithField(log.ProviderName, "acme").
+				Errorf("Unable to remove the shared challenge for token %s: %v", token, err)
+		}
+	}
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -105,6 +135,18 @@ func (c *ChallengeHTTP) getTokenValue(ctx context.Context, token, domain string)
 	logger := log.FromContext(ctx)
 	logger.Debugf("Retrieving the ACME challenge for token %s...", token)
 
+	// The challenges presented by the other instances are looked up once, without holding the lock,
+	// as a miss is most likely an unknown token.
+	if !c.hasToken(token) {
+		result, err := c.getSharedTokenValue(token, domain)
+		if err != nil {
+			logger.Errorf("Cannot retrieve the shared ACME challenge for token %v: %v", token, err)
+		}
+		if result != nil {
+			return result
+		}
+	}
+
 	var result []byte
 
 	operation := func() error {
@@ -139,6 +181,35 @@ func (c *ChallengeHTTP) getTokenValue(ctx context.Context, token, domain string)
 	return result
 }
 
+func (c *ChallengeHTTP) hasToken(token string) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	_, ok := c.httpChallenges[token]
+	return ok
+}
+
+// getSharedTokenValue looks for a challenge presented by another Traefik instance in the shared stores,
+// nil being returned if there is none.
+func (c *ChallengeHTTP) getSharedTokenValue(token, domain string) ([]byte, error) {
+	c.lock.RLock()
+	stores := c.stores
+	c.lock.RUnlock()
+
+	for _, store := range stores {
+		keyAuth, err := store.GetHTTPChallenge(token, domain)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get shared challenge for token %s: %w", token, err)
+		}
+
+		if keyAuth != nil {
+			return keyAuth, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func getPathParam(uri *url.URL) (string, error) {
 	exp := regexp.MustCompile(fmt.Sprintf(`^%s([^/]+)/?$`, http01.ChallengePath("")))
 	parts := exp.FindStringSubmatch(uri.Path)
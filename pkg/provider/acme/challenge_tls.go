@@ -1,7 +1,9 @@
 package acme
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 
@@ -15,6 +17,10 @@ import (
 
 const providerNameALPN = "tlsalpn.acme"
 
+// tlsChallengesWatchRetryDelay is the delay before watching the challenges presented by the other instances again,
+// when the watch of a shared store fails.
+const tlsChallengesWatchRetryDelay = 5 * time.Second
+
 // ChallengeTLSALPN TLSALPN challenge provider implements challenge.Provider.
 type ChallengeTLSALPN struct {
 	Timeout time.Duration
@@ -22,8 +28,11 @@ type ChallengeTLSALPN struct {
 	chans   map[string]chan struct{}
 	muChans sync.Mutex
 
-	certs   map[string]*Certificate
-	muCerts sync.Mutex
+	certs       map[string]*Certificate
+	sharedCerts map[string]*Certificate
+	muCerts     sync.Mutex
+
+	stores []ChallengeStore
 
 	configurationChan chan<- dynamic.Message
 }
@@ -31,12 +40,20 @@ type ChallengeTLSALPN struct {
 // NewChallengeTLSALPN creates a new ChallengeTLSALPN.
 func NewChallengeTLSALPN(timeout time.Duration) *ChallengeTLSALPN {
 	return &ChallengeTLSALPN{
-		Timeout: timeout,
-		chans:   make(map[string]chan struct{}),
-		certs:   make(map[string]*Certificate),
+		Timeout:     timeout,
+		chans:       make(map[string]chan struct{}),
+		certs:       make(map[string]*Certificate),
+		sharedCerts: make(map[string]*Certificate),
 	}
 }
 
+// AddStore adds a store shared by several Traefik instances,
+// to which the challenges are presented for all of them to serve them.
+// It must be called before Provide.
+func (c *ChallengeTLSALPN) AddStore(store ChallengeStore) {
+	c.stores = append(c.stores, store)
+}
+
 // Present presents a challenge to obtain new ACME certificate.
 func (c *ChallengeTLSALPN) Present(domain, _, keyAuth string) error {
 	logger := log.WithoutContext().WithField(log.ProviderName, providerNameALPN)
@@ -49,6 +66,12 @@ func (c *ChallengeTLSALPN) Present(domain, _, keyAuth string) error {
 
 	cert := &Certificate{Certificate: certPEMBlock, Key: keyPEMBlock, Domain: types.Domain{Main: "TEMP-" + domain}}
 
+	for _, store := range c.stores {
+		if err := store.SetTLSChallenge(domain, cert); err != nil {
+			return fmt.Errorf("unable to share the TLS challenge for %s: %w", domain, err)
+		}
+	}
+
 	c.muChans.Lock()
 	ch := make(chan struct{})
 	c.chans[string(certPEMBlock)] = ch
@@ -56,7 +79,7 @@ func (c *ChallengeTLSALPN) Present(domain, _, keyAuth string) error {
 
 	c.muCerts.Lock()
 	c.certs[keyAuth] = cert
-	conf := createMessage(c.certs)
+	conf := createMessage(c.certificates())
 	c.muCerts.Unlock()
 
 	c.configurationChan <- conf
@@ -78,7 +101,12 @@ func (c *ChallengeTLSALPN) Present(domain, _, keyAuth string) error {
 
 		return fmt.Errorf("timeout %s", t)
 	case <-ch:
-		// noop
+		if len(c.stores) > 0 {
+			// Leaves time to the other instances, which are notified of the challenge, to apply their configuration,
+			// in case the validation request reaches one of them.
+			time.Sleep(c.Timeout)
+		}
+
 		return nil
 	}
 }
@@ -88,9 +116,16 @@ func (c *ChallengeTLSALPN) CleanUp(domain, _, keyAuth string) error {
 	log.WithoutContext().WithField(log.ProviderName, providerNameALPN).
 		Debugf("TLS Challenge CleanUp temp certificate for %s", domain)
 
+	for _, store := range c.stores {
+		if err := store.RemoveTLSChallenge(domain); err != nil {
+			log.WithoutContext().WithField(log.ProviderName, providerNameALPN).
+				Errorf("Unable to remove the shared TLS challenge for %s: %v", domain, err)
+		}
+	}
+
 	c.muCerts.Lock()
 	delete(c.certs, keyAuth)
-	conf := createMessage(c.certs)
+	conf := createMessage(c.certificates())
 	c.muCerts.Unlock()
 
 	c.configurationChan <- conf
@@ -104,12 +139,92 @@ func (c *ChallengeTLSALPN) Init() error {
 }
 
 // Provide allows the provider to provide configurations to traefik using the given configuration channel.
-func (c *ChallengeTLSALPN) Provide(configurationChan chan<- dynamic.Message, _ *safe.Pool) error {
+func (c *ChallengeTLSALPN) Provide(configurationChan chan<- dynamic.Message, pool *safe.Pool) error {
 	c.configurationChan = configurationChan
 
+	for _, store := range c.stores {
+		store := store
+		pool.GoCtx(func(ctx context.Context) {
+			c.watchSharedChallenges(ctx, store)
+		})
+	}
+
 	return nil
 }
 
+// watchSharedChallenges loads the challenges presented by the other instances each time they change in the shared store.
+func (c *ChallengeTLSALPN) watchSharedChallenges(ctx context.Context, store ChallengeStore) {
+	logger := log.FromContext(log.With(ctx, log.Str(log.ProviderName, providerNameALPN)))
+
+	for {
+		changes, err := store.WatchTLSChallenges(ctx.Done())
+		if err != nil {
+			// The watch may fail until a first challenge is shared, with the backends requiring the directory to exist.
+			logger.Debugf("Unable to watch the shared TLS challenges, retrying in %s: %v", tlsChallengesWatchRetryDelay, err)
+		} else {
+			for range changes {
+				c.refreshSharedChallenges()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(tlsChallengesWatchRetryDelay):
+		}
+	}
+}
+
+func (c *ChallengeTLSALPN) refreshSharedChallenges() {
+	sharedCerts := make(map[string]*Certificate)
+	for _, store := range c.stores {
+		certs, err := store.GetTLSChallenges()
+		if err != nil {
+			log.WithoutContext().WithField(log.ProviderName, providerNameALPN).
+				Errorf("Unable to get the shared TLS challenges: %v", err)
+			return
+		}
+
+		for domain, cert := range certs {
+			sharedCerts[domain] = cert
+		}
+	}
+
+	c.muCerts.Lock()
+	if reflect.DeepEqual(sharedCerts, c.sharedCerts) {
+		c.muCerts.Unlock()
+		return
+	}
+
+	c.sharedCerts = sharedCerts
+	conf := createMessage(c.certificates())
+	c.muCerts.Unlock()
+
+	c.configurationChan <- conf
+}
+
+// certificates returns the temporary certificates of the challenges presented by this instance and by the other ones.
+// It must be called with muCerts locked.
+func (c *ChallengeTLSALPN) certificates() map[string]*Certificate {
+	certs := make(map[string]*Certificate, len(c.certs)+len(c.sharedCerts))
+
+	presented := make(map[string]struct{}, len(c.certs))
+	for keyAuth, cert := range c.certs {
+		certs[keyAuth] = cert
+		presented[cert.Domain.Main] = struct{}{}
+	}
+
+	for domain, cert := range c.sharedCerts {
+		if _, ok := presented[cert.Domain.Main]; ok {
+			continue
+		}
+
+		certs["shared-"+domain] = cert
+	}
+
+	return certs
+}
+
 // ListenConfiguration sets a new Configuration into the configurationChan.
 func (c *ChallengeTLSALPN) ListenConfiguration(conf dynamic.Configuration) {
 	c.muChans.Lock()
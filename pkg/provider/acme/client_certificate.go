@@ -0,0 +1,114 @@
+package acme
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/traefik/traefik/v2/pkg/log"
+	traefiktls "github.com/traefik/traefik/v2/pkg/tls"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// clientCertificateLockName is the name of the lock preventing several instances from ordering the client certificate.
+const clientCertificateLockName = "client-certificate"
+
+// IssueClientCertificate returns the certificate for the common name, presented as client certificate to the backend servers.
+// The certificate is kept in the store, and only ordered again when it is to be renewed, but is not added to the TLS stores.
+func (p *Provider) IssueClientCertificate(ctx context.Context, commonName string) (*tls.Certificate, error) {
+	ctx = log.With(ctx, log.Str(log.ProviderName, p.ResolverName+".acme"))
+	logger := log.FromContext(ctx)
+
+	unlock, err := p.lock(clientCertificateLockName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to lock the client certificate: %w", err)
+	}
+	defer unlock()
+
+	if tlsCert := p.loadClientCertificate(ctx, commonName); tlsCert != nil {
+		logger.Debugf("Using the stored client certificate for %q", commonName)
+		return tlsCert, nil
+	}
+
+	logger.Debugf("Obtaining the client certificate for %q", commonName)
+
+	client, err := p.getClient()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get ACME client: %w", err)
+	}
+
+	cert, err := client.Certificate.Obtain(certificate.ObtainRequest{
+		Domains:        []string{commonName},
+		Bundle:         true,
+		MustStaple:     oscpMustStaple,
+		PreferredChain: p.PreferredChain,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to obtain the client certificate: %w", err)
+	}
+
+	if cert == nil || len(cert.Certificate) == 0 || len(cert.PrivateKey) == 0 {
+		return nil, errors.New("no client certificate obtained")
+	}
+
+	tlsCert, err := tls.X509KeyPair(cert.Certificate, cert.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate obtained: %w", err)
+	}
+
+	if store, ok := p.Store.(ClientCertificateStore); ok {
+		err = store.SaveClientCertificate(p.ResolverName, &Certificate{
+			Domain:      types.Domain{Main: commonName},
+			Certificate: cert.Certificate,
+			Key:         cert.PrivateKey,
+		})
+		if err != nil {
+			logger.Errorf("Unable to store the client certificate for %q: %v", commonName, err)
+		}
+	}
+
+	return &tlsCert, nil
+}
+
+// loadClientCertificate returns the stored client certificate for the common name,
+// unless it is to be renewed.
+func (p *Provider) loadClientCertificate(ctx context.Context, commonName string) *tls.Certificate {
+	store, ok := p.Store.(ClientCertificateStore)
+	if !ok {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+
+	cert, err := store.GetClientCertificate(p.ResolverName)
+	if err != nil {
+		logger.Errorf("Unable to get the stored client certificate: %v", err)
+		return nil
+	}
+
+	if cert == nil || cert.Domain.Main != commonName {
+		return nil
+	}
+
+	tlsCert, err := tls.X509KeyPair(cert.Certificate, cert.Key)
+	if err != nil {
+		logger.Errorf("Invalid stored client certificate for %q: %v", commonName, err)
+		return nil
+	}
+
+	leaf, err := x509.ParseCertificate(tlsCert.Certificate[0])
+	if err != nil {
+		logger.Errorf("Invalid stored client certificate for %q: %v", commonName, err)
+		return nil
+	}
+
+	if !time.Now().Before(traefiktls.ClientCertificateRenewAt(leaf)) {
+		return nil
+	}
+
+	return &tlsCert
+}
@@ -0,0 +1,77 @@
+package acme
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/tls/generate"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestProvider_loadClientCertificate(t *testing.T) {
+	certPEM, keyPEM, err := generate.KeyPair("client.example.com", time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc       string
+		stored     *Certificate
+		commonName string
+		expected   bool
+	}{
+		{
+			desc:       "no stored certificate",
+			commonName: "client.example.com",
+		},
+		{
+			desc:       "valid stored certificate",
+			stored:     &Certificate{Domain: types.Domain{Main: "client.example.com"}, Certificate: certPEM, Key: keyPEM},
+			commonName: "client.example.com",
+			expected:   true,
+		},
+		{
+			desc:       "stored certificate for another common name",
+			stored:     &Certificate{Domain: types.Domain{Main: "client.example.com"}, Certificate: certPEM, Key: keyPEM},
+			commonName: "other.example.com",
+		},
+		{
+			desc:       "invalid stored certificate",
+			stored:     &Certificate{Domain: types.Domain{Main: "client.example.com"}, Certificate: []byte("cert"), Key: []byte("key")},
+			commonName: "client.example.com",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			store := newKVStore(newKVClientMock(), "traefik/acme", 30*time.Second)
+			if test.stored != nil {
+				require.NoError(t, store.SaveClientCertificate("myresolver", test.stored))
+			}
+
+			p := &Provider{ResolverName: "myresolver", Store: store}
+
+			cert := p.loadClientCertificate(context.Background(), test.commonName)
+			assert.Equal(t, test.expected, cert != nil)
+		})
+	}
+}
+
+func TestProvider_loadClientCertificate_toRenew(t *testing.T) {
+	certPEM, keyPEM, err := generate.KeyPair("client.example.com", time.Now().Add(time.Second))
+	require.NoError(t, err)
+
+	store := newKVStore(newKVClientMock(), "traefik/acme", 30*time.Second)
+	require.NoError(t, store.SaveClientCertificate("myresolver", &Certificate{Domain: types.Domain{Main: "client.example.com"}, Certificate: certPEM, Key: keyPEM}))
+
+	p := &Provider{ResolverName: "myresolver", Store: store}
+
+	// Two thirds of the lifetime of the certificate have elapsed.
+	time.Sleep(time.Second)
+
+	assert.Nil(t, p.loadClientCertificate(context.Background(), "client.example.com"))
+}
@@ -0,0 +1,422 @@
+package acme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abronan/valkeyrie/store"
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/provider/kv"
+)
+
+// lockTimeout is the maximum duration to wait for a lock held by another instance,
+// which may be ordering a certificate.
+const lockTimeout = 10 * time.Minute
+
+var (
+	_ Store                  = (*KVStore)(nil)
+	_ ChallengeStore         = (*KVStore)(nil)
+	_ Locker                 = (*KVStore)(nil)
+	_ CertificateRemover     = (*KVStore)(nil)
+	_ ClientCertificateStore = (*KVStore)(nil)
+)
+
+// KVStore stores the ACME data in a KV store shared by several Traefik instances.
+type KVStore struct {
+	client  store.Store
+	rootKey string
+	lockTTL time.Duration
+}
+
+// NewKVStore creates a new KVStore from its configuration.
+func NewKVStore(ctx context.Context, conf *KVStorage) (*KVStore, error) {
+	var backend store.Backend
+	switch conf.Backend {
+	case "consul":
+		backend = store.CONSUL
+	case "etcd":
+		backend = store.ETCDV3
+	case "redis":
+		backend = store.REDIS
+	case "zookeeper":
+		backend = store.ZK
+	default:
+		return nil, fmt.Errorf("unknown KV store backend: %q", conf.Backend)
+	}
+
+	client, err := kv.NewKVClient(ctx, backend, conf.Endpoints, conf.Username, conf.Password, conf.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the KV store: %w", err)
+	}
+
+	return newKVStore(client, conf.RootKey, time.Duration(conf.LockTTL)), nil
+}
+
+func newKVStore(client store.Store, rootKey string, lockTTL time.Duration) *KVStore {
+	return &KVStore{
+		client:  client,
+		rootKey: strings.Trim(rootKey, "/"),
+		lockTTL: lockTTL,
+	}
+}
+
+// GetAccount returns the account of the resolver.
+func (s *KVStore) GetAccount(resolverName string) (*Account, error) {
+	pair, err := s.client.Get(path.Join(s.rootKey, resolverName, "account"), nil)
+	if errors.Is(err, store.ErrKeyNotFound) || (err == nil && pair == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account := &Account{}
+	if err := json.Unmarshal(pair.Value, account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// SaveAccount stores the account of the resolver.
+func (s *KVStore) SaveAccount(resolverName string, account *Account) error {
+	data, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Put(path.Join(s.rootKey, resolverName, "account"), data, nil)
+}
+
+// GetClientCertificate returns the client certificate obtained by the resolver, if any.
+func (s *KVStore) GetClientCertificate(resolverName string) (*Certificate, error) {
+	pair, err := s.client.Get(path.Join(s.rootKey, resolverName, "clientCertificate"), nil)
+	if errors.Is(err, store.ErrKeyNotFound) || (err == nil && pair == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cert := &Certificate{}
+	if err := json.Unmarshal(pair.Value, cert); err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+// SaveClientCertificate stores the client certificate obtained by the resolver.
+func (s *KVStore) SaveClientCertificate(resolverName string, cert *Certificate) error {
+	data, err := json.Marshal(cert)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Put(path.Join(s.rootKey, resolverName, "clientCertificate"), data, nil)
+}
+
+// GetCertificates returns the certificates of the resolver, including the ones obtained by the other instances,
+// except the revoked ones.
+func (s *KVStore) GetCertificates(resolverName string) ([]*CertAndStore, error) {
+	revoked, err := s.revokedFingerprints(resolverName)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := s.list(path.Join(s.rootKey, resolverName, "certificates"))
+	if err != nil {
+		return nil, err
+	}
+
+	logger := log.WithoutContext().WithField(log.ProviderName, "acme")
+
+	var certificates []*CertAndStore
+	for _, pair := range pairs {
+		certificate := &CertAndStore{}
+		if err := json.Unmarshal(pair.Value, certificate); err != nil {
+			return nil, fmt.Errorf("invalid certificate %s: %w", pair.Key, err)
+		}
+
+		if len(certificate.Certificate.Certificate) == 0 || len(certificate.Key) == 0 {
+			logger.Debugf("Ignoring empty certificate for %v", certificate.Domain.ToStrArray())
+			continue
+		}
+
+		if _, ok := revoked[certificateFingerprint(certificate)]; ok {
+			logger.Debugf("Ignoring revoked certificate for %v", certificate.Domain.ToStrArray())
+			continue
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
+// SaveCertificates stores the given certificates of the resolver,
+// keeping the other ones, which may have been obtained by the other instances.
+// The certificates revoked by any instance are not stored again.
+func (s *KVStore) SaveCertificates(resolverName string, certificates []*CertAndStore) error {
+	revoked, err := s.revokedFingerprints(resolverName)
+	if err != nil {
+		return err
+	}
+
+	for _, certificate := range certificates {
+		if _, ok := revoked[certificateFingerprint(certificate)]; ok {
+			continue
+		}
+
+		data, err := json.Marshal(certificate)
+		if err != nil {
+			return err
+		}
+
+		if err := s.client.Put(s.certificateKey(resolverName, "certificates", certificate), data, nil); err != nil {
+			return fmt.Errorf("unable to store the certificate for %v: %w", certificate.Domain.ToStrArray(), err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveCertificate removes the given certificate of the resolver,
+// and marks it as revoked for the other instances to drop their copy instead of storing it again.
+func (s *KVStore) RemoveCertificate(resolverName string, certificate *CertAndStore) error {
+	// The marker replaces the one of a previously revoked certificate for the same domains.
+	err := s.client.Put(s.certificateKey(resolverName, "revoked", certificate), []byte(certificateFingerprint(certificate)), nil)
+	if err != nil {
+		return fmt.Errorf("unable to mark the certificate for %v as revoked: %w", certificate.Domain.ToStrArray(), err)
+	}
+
+	err = s.client.Delete(s.certificateKey(resolverName, "certificates", certificate))
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// RevokedCertificates returns the given certificates of the resolver which have been revoked by any instance.
+func (s *KVStore) RevokedCertificates(resolverName string, certificates []*CertAndStore) ([]*CertAndStore, error) {
+	revoked, err := s.revokedFingerprints(resolverName)
+	if err != nil || len(revoked) == 0 {
+		return nil, err
+	}
+
+	var revokedCertificates []*CertAndStore
+	for _, certificate := range certificates {
+		if _, ok := revoked[certificateFingerprint(certificate)]; ok {
+			revokedCertificates = append(revokedCertificates, certificate)
+		}
+	}
+
+	return revokedCertificates, nil
+}
+
+// revokedFingerprints returns the fingerprints of the revoked certificates of the resolver.
+func (s *KVStore) revokedFingerprints(resolverName string) (map[string]struct{}, error) {
+	pairs, err := s.list(path.Join(s.rootKey, resolverName, "revoked"))
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprints := make(map[string]struct{}, len(pairs))
+	for _, pair := range pairs {
+		fingerprints[string(pair.Value)] = struct{}{}
+	}
+
+	return fingerprints, nil
+}
+
+// certificateKey returns the key of the certificate, under the given directory of the resolver.
+func (s *KVStore) certificateKey(resolverName, directory string, certificate *CertAndStore) string {
+	// The certificates are stored at the same level, for all the backends to list them.
+	name := certificate.Store + "/" + strings.Join(certificate.Domain.ToStrArray(), ",")
+	if certificate.KeyType != "" {
+		name += "/" + certificate.KeyType
+	}
+
+	return path.Join(s.rootKey, resolverName, directory, url.PathEscape(name))
+}
+
+// certificateFingerprint returns the SHA-256 hash of the certificate, identifying it among the ones for the same domains.
+func certificateFingerprint(certificate *CertAndStore) string {
+	hash := sha256.Sum256(certificate.Certificate.Certificate)
+	return hex.EncodeToString(hash[:])
+}
+
+// Lock acquires the lock of the given name for the resolver, waiting for it to be released by the other instances.
+func (s *KVStore) Lock(resolverName, name string) (func(), error) {
+	renew := make(chan struct{})
+
+	locker, err := s.client.NewLock(path.Join(s.rootKey, resolverName, "locks", url.PathEscape(name)), &store.LockOptions{
+		TTL:       s.lockTTL,
+		RenewLock: renew,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stop := make(chan struct{})
+	timer := time.AfterFunc(lockTimeout, func() { close(stop) })
+	defer timer.Stop()
+
+	if _, err := locker.Lock(stop); err != nil {
+		close(renew)
+		return nil, err
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if err := locker.Unlock(); err != nil {
+				log.WithoutContext().WithField(log.ProviderName, "acme").Errorf("Unable to release the lock %s: %v", name, err)
+			}
+			close(renew)
+		})
+	}, nil
+}
+
+// SetHTTPChallenge stores the key authorization of an HTTP-01 challenge.
+func (s *KVStore) SetHTTPChallenge(token, domain string, keyAuth []byte) error {
+	return s.client.Put(s.httpChallengeKey(token, domain), keyAuth, nil)
+}
+
+// GetHTTPChallenge returns the key authorization of an HTTP-01 challenge, nil if there is none.
+func (s *KVStore) GetHTTPChallenge(token, domain string) ([]byte, error) {
+	pair, err := s.client.Get(s.httpChallengeKey(token, domain), nil)
+	if errors.Is(err, store.ErrKeyNotFound) || (err == nil && pair == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pair.Value, nil
+}
+
+// RemoveHTTPChallenge removes an HTTP-01 challenge.
+func (s *KVStore) RemoveHTTPChallenge(token, domain string) error {
+	err := s.client.Delete(s.httpChallengeKey(token, domain))
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *KVStore) httpChallengeKey(token, domain string) string {
+	return path.Join(s.rootKey, "challenges", "http", url.PathEscape(token), url.PathEscape(domain))
+}
+
+// SetTLSChallenge stores the temporary certificate of a TLS-ALPN-01 challenge.
+func (s *KVStore) SetTLSChallenge(domain string, cert *Certificate) error {
+	data, err := json.Marshal(cert)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Put(s.tlsChallengeKey(domain), data, nil)
+}
+
+// GetTLSChallenges returns the temporary certificates of the TLS-ALPN-01 challenges, by domain.
+func (s *KVStore) GetTLSChallenges() (map[string]*Certificate, error) {
+	pairs, err := s.list(s.tlsChallengesDirectory())
+	if err != nil {
+		return nil, err
+	}
+
+	certs := make(map[string]*Certificate, len(pairs))
+	for _, pair := range pairs {
+		cert := &Certificate{}
+		if err := json.Unmarshal(pair.Value, cert); err != nil {
+			return nil, fmt.Errorf("invalid TLS challenge %s: %w", pair.Key, err)
+		}
+
+		domain, err := url.PathUnescape(path.Base(pair.Key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS challenge %s: %w", pair.Key, err)
+		}
+
+		certs[domain] = cert
+	}
+
+	return certs, nil
+}
+
+// RemoveTLSChallenge removes a TLS-ALPN-01 challenge.
+func (s *KVStore) RemoveTLSChallenge(domain string) error {
+	err := s.client.Delete(s.tlsChallengeKey(domain))
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// WatchTLSChallenges notifies the changes of the TLS-ALPN-01 challenges until the stop channel is closed,
+// the returned channel being closed when the watch ends.
+func (s *KVStore) WatchTLSChallenges(stopCh <-chan struct{}) (<-chan struct{}, error) {
+	events, err := s.client.WatchTree(s.tlsChallengesDirectory(), stopCh, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan struct{}, 1)
+
+	go func() {
+		defer close(changes)
+
+		for range events {
+			select {
+			case changes <- struct{}{}:
+			default:
+				// A change is already pending.
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+func (s *KVStore) tlsChallengesDirectory() string {
+	return path.Join(s.rootKey, "challenges", "tls")
+}
+
+func (s *KVStore) tlsChallengeKey(domain string) string {
+	return path.Join(s.tlsChallengesDirectory(), url.PathEscape(domain))
+}
+
+// list returns the pairs under the given directory, none if it does not exist.
+func (s *KVStore) list(directory string) ([]*store.KVPair, error) {
+	pairs, err := s.client.List(directory, nil)
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var values []*store.KVPair
+	for _, pair := range pairs {
+		// Some backends list the directory itself.
+		if pair == nil || len(pair.Value) == 0 || strings.Trim(pair.Key, "/") == strings.Trim(directory, "/") {
+			continue
+		}
+
+		values = append(values, pair)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,516 @@
+package acme
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abronan/valkeyrie/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/safe"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestKVStore_Account(t *testing.T) {
+	s := newKVStore(newKVClientMock(), "/traefik/acme/", 30*time.Second)
+
+	account, err := s.GetAccount("resolver")
+	require.NoError(t, err)
+	assert.Nil(t, account)
+
+	err = s.SaveAccount("resolver", &Account{Email: "some42@email.com"})
+	require.NoError(t, err)
+
+	account, err = s.GetAccount("resolver")
+	require.NoError(t, err)
+	assert.Equal(t, &Account{Email: "some42@email.com"}, account)
+
+	account, err = s.GetAccount("other")
+	require.NoError(t, err)
+	assert.Nil(t, account)
+}
+
+func TestKVStore_Certificates(t *testing.T) {
+	client := newKVClientMock()
+
+	// Two instances sharing the same KV store.
+	s1 := newKVStore(client, "traefik/acme", 30*time.Second)
+	s2 := newKVStore(client, "traefik/acme", 30*time.Second)
+
+	certificates, err := s1.GetCertificates("resolver")
+	require.NoError(t, err)
+	assert.Empty(t, certificates)
+
+	foo := &CertAndStore{
+		Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}, Certificate: []byte("cert"), Key: []byte("key")},
+		Store:       "default",
+	}
+	bar := &CertAndStore{
+		Certificate: Certificate{Domain: types.Domain{Main: "bar.com", SANs: []string{"www.bar.com"}}, Certificate: []byte("cert"), Key: []byte("key")},
+		Store:       "default",
+	}
+
+	err = s1.SaveCertificates("resolver", []*CertAndStore{foo})
+	require.NoError(t, err)
+
+	// Saving the certificates of an instance keeps the ones obtained by the other ones.
+	err = s2.SaveCertificates("resolver", []*CertAndStore{bar})
+	require.NoError(t, err)
+
+	certificates, err = s1.GetCertificates("resolver")
+	require.NoError(t, err)
+
+	var domains []string
+	for _, certificate := range certificates {
+		domains = append(domains, certificate.Domain.Main)
+	}
+	sort.Strings(domains)
+	assert.Equal(t, []string{"bar.com", "foo.com"}, domains)
+
+	certificates, err = s1.GetCertificates("other")
+	require.NoError(t, err)
+	assert.Empty(t, certificates)
+}
+
+func TestKVStore_RemoveCertificate(t *testing.T) {
+	client := newKVClientMock()
+
+	// Two instances sharing the same KV store.
+	s1 := newKVStore(client, "traefik/acme", 30*time.Second)
+	s2 := newKVStore(client, "traefik/acme", 30*time.Second)
+
+	foo := &CertAndStore{
+		Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}, Certificate: []byte("cert"), Key: []byte("key")},
+		Store:       "default",
+	}
+
+	require.NoError(t, s1.SaveCertificates("resolver", []*CertAndStore{foo}))
+	require.NoError(t, s2.RemoveCertificate("resolver", foo))
+
+	certificates, err := s1.GetCertificates("resolver")
+	require.NoError(t, err)
+	assert.Empty(t, certificates)
+
+	revoked, err := s1.RevokedCertificates("resolver", []*CertAndStore{foo})
+	require.NoError(t, err)
+	assert.Equal(t, []*CertAndStore{foo}, revoked)
+
+	// The instance still holding the revoked certificate does not store it again.
+	require.NoError(t, s1.SaveCertificates("resolver", []*CertAndStore{foo}))
+
+	certificates, err = s2.GetCertificates("resolver")
+	require.NoError(t, err)
+	assert.Empty(t, certificates)
+
+	// The certificate obtained for the same domains after the revocation is stored.
+	renewed := &CertAndStore{
+		Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}, Certificate: []byte("renewed"), Key: []byte("key")},
+		Store:       "default",
+	}
+	require.NoError(t, s2.SaveCertificates("resolver", []*CertAndStore{renewed}))
+
+	certificates, err = s1.GetCertificates("resolver")
+	require.NoError(t, err)
+	assert.Equal(t, []*CertAndStore{renewed}, certificates)
+
+	revoked, err = s1.RevokedCertificates("resolver", []*CertAndStore{renewed})
+	require.NoError(t, err)
+	assert.Empty(t, revoked)
+}
+
+func TestKVStore_HTTPChallenge(t *testing.T) {
+	s := newKVStore(newKVClientMock(), "traefik/acme", 30*time.Second)
+
+	keyAuth, err := s.GetHTTPChallenge("token", "foo.com")
+	require.NoError(t, err)
+	assert.Nil(t, keyAuth)
+
+	err = s.SetHTTPChallenge("token", "foo.com", []byte("keyAuth"))
+	require.NoError(t, err)
+
+	keyAuth, err = s.GetHTTPChallenge("token", "foo.com")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("keyAuth"), keyAuth)
+
+	err = s.RemoveHTTPChallenge("token", "foo.com")
+	require.NoError(t, err)
+
+	keyAuth, err = s.GetHTTPChallenge("token", "foo.com")
+	require.NoError(t, err)
+	assert.Nil(t, keyAuth)
+
+	err = s.RemoveHTTPChallenge("token", "foo.com")
+	require.NoError(t, err)
+}
+
+func TestKVStore_TLSChallenges(t *testing.T) {
+	s := newKVStore(newKVClientMock(), "traefik/acme", 30*time.Second)
+
+	certs, err := s.GetTLSChallenges()
+	require.NoError(t, err)
+	assert.Empty(t, certs)
+
+	cert := &Certificate{Domain: types.Domain{Main: "foo.com"}, Certificate: []byte("cert"), Key: []byte("key")}
+
+	err = s.SetTLSChallenge("foo.com", cert)
+	require.NoError(t, err)
+
+	certs, err = s.GetTLSChallenges()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*Certificate{"foo.com": cert}, certs)
+
+	err = s.RemoveTLSChallenge("foo.com")
+	require.NoError(t, err)
+
+	certs, err = s.GetTLSChallenges()
+	require.NoError(t, err)
+	assert.Empty(t, certs)
+}
+
+func TestKVStore_Lock(t *testing.T) {
+	client := newKVClientMock()
+	s := newKVStore(client, "traefik/acme", 30*time.Second)
+
+	unlock, err := s.Lock("resolver", "foo.com")
+	require.NoError(t, err)
+
+	locked := make(chan struct{})
+	go func() {
+		unlock2, err := s.Lock("resolver", "foo.com")
+		if err == nil {
+			defer unlock2()
+		}
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("the lock has been acquired twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	// Releasing the lock twice is harmless.
+	unlock()
+
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("the lock has not been released")
+	}
+}
+
+func TestChallengeHTTP_sharedToken(t *testing.T) {
+	s := newKVStore(newKVClientMock(), "traefik/acme", 30*time.Second)
+
+	err := s.SetHTTPChallenge("token", "foo.com", []byte("keyAuth"))
+	require.NoError(t, err)
+
+	c := NewChallengeHTTP()
+	c.AddStore(s)
+
+	keyAuth, err := c.getSharedTokenValue("token", "foo.com")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("keyAuth"), keyAuth)
+
+	keyAuth, err = c.getSharedTokenValue("token", "bar.com")
+	require.NoError(t, err)
+	assert.Nil(t, keyAuth)
+}
+
+func TestChallengeHTTP_getTokenValue_shared(t *testing.T) {
+	s := &blockingChallengeStore{release: make(chan struct{})}
+
+	c := NewChallengeHTTP()
+	c.AddStore(s)
+
+	result := make(chan []byte, 1)
+	go func() {
+		result <- c.getTokenValue(context.Background(), "token", "foo.com")
+	}()
+
+	require.Eventually(t, func() bool { return s.getCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	// The shared lookup does not block the challenges being presented.
+	presented := make(chan error, 1)
+	go func() {
+		presented <- c.Present("bar.com", "other", "otherKeyAuth")
+	}()
+
+	select {
+	case err := <-presented:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the challenge presentation is blocked by the shared lookup")
+	}
+
+	close(s.release)
+
+	select {
+	case keyAuth := <-result:
+		assert.Equal(t, []byte("keyAuth"), keyAuth)
+	case <-time.After(time.Second):
+		t.Fatal("the challenge has not been retrieved")
+	}
+
+	assert.Equal(t, 1, s.getCount())
+}
+
+func TestChallengeTLSALPN_watchSharedChallenges(t *testing.T) {
+	s := newKVStore(newKVClientMock(), "traefik/acme", 30*time.Second)
+
+	configurationChan := make(chan dynamic.Message, 10)
+
+	c := NewChallengeTLSALPN(time.Second)
+	c.AddStore(s)
+
+	pool := safe.NewPool(context.Background())
+	t.Cleanup(pool.Stop)
+
+	require.NoError(t, c.Provide(configurationChan, pool))
+
+	cert := &Certificate{Domain: types.Domain{Main: "TEMP-foo.com"}, Certificate: []byte("cert"), Key: []byte("key")}
+	require.NoError(t, s.SetTLSChallenge("foo.com", cert))
+
+	select {
+	case msg := <-configurationChan:
+		require.Len(t, msg.Configuration.TLS.Certificates, 1)
+		assert.Equal(t, "cert", msg.Configuration.TLS.Certificates[0].CertFile.String())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the shared challenge has not been loaded")
+	}
+
+	require.NoError(t, s.RemoveTLSChallenge("foo.com"))
+
+	select {
+	case msg := <-configurationChan:
+		assert.Empty(t, msg.Configuration.TLS.Certificates)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the shared challenge has not been removed")
+	}
+}
+
+// blockingChallengeStore is a ChallengeStore whose HTTP challenge lookups block until released.
+type blockingChallengeStore struct {
+	release chan struct{}
+
+	mu   sync.Mutex
+	gets int
+}
+
+func (s *blockingChallengeStore) getCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.gets
+}
+
+func (s *blockingChallengeStore) SetHTTPChallenge(string, string, []byte) error {
+	return nil
+}
+
+func (s *blockingChallengeStore) GetHTTPChallenge(string, string) ([]byte, error) {
+	s.mu.Lock()
+	s.gets++
+	s.mu.Unlock()
+
+	<-s.release
+
+	return []byte("keyAuth"), nil
+}
+
+func (s *blockingChallengeStore) RemoveHTTPChallenge(string, string) error {
+	return nil
+}
+
+func (s *blockingChallengeStore) SetTLSChallenge(string, *Certificate) error {
+	return nil
+}
+
+func (s *blockingChallengeStore) GetTLSChallenges() (map[string]*Certificate, error) {
+	return nil, nil
+}
+
+func (s *blockingChallengeStore) RemoveTLSChallenge(string) error {
+	return nil
+}
+
+func (s *blockingChallengeStore) WatchTLSChallenges(<-chan struct{}) (<-chan struct{}, error) {
+	return nil, errors.New("method WatchTLSChallenges not supported")
+}
+
+// kvClientMock is an in-memory KV store.
+type kvClientMock struct {
+	mu       sync.Mutex
+	values   map[string][]byte
+	locks    map[string]chan struct{}
+	watchers map[chan []*store.KVPair]string
+}
+
+func newKVClientMock() *kvClientMock {
+	return &kvClientMock{
+		values:   make(map[string][]byte),
+		locks:    make(map[string]chan struct{}),
+		watchers: make(map[chan []*store.KVPair]string),
+	}
+}
+
+func (m *kvClientMock) Put(key string, value []byte, _ *store.WriteOptions) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[key] = value
+	m.notify(key)
+	return nil
+}
+
+func (m *kvClientMock) Get(key string, _ *store.ReadOptions) (*store.KVPair, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.values[key]
+	if !ok {
+		return nil, store.ErrKeyNotFound
+	}
+
+	return &store.KVPair{Key: key, Value: value}, nil
+}
+
+func (m *kvClientMock) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.values[key]; !ok {
+		return store.ErrKeyNotFound
+	}
+
+	delete(m.values, key)
+	m.notify(key)
+	return nil
+}
+
+func (m *kvClientMock) Exists(key string, _ *store.ReadOptions) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.values[key]
+	return ok, nil
+}
+
+func (m *kvClientMock) Watch(string, <-chan struct{}, *store.ReadOptions) (<-chan *store.KVPair, error) {
+	return nil, errors.New("method Watch not supported")
+}
+
+// WatchTree notifies the changes under the directory, without the pairs,
+// a first event being sent like with the actual backends.
+func (m *kvClientMock) WatchTree(directory string, stopCh <-chan struct{}, _ *store.ReadOptions) (<-chan []*store.KVPair, error) {
+	events := make(chan []*store.KVPair, 1)
+	events <- nil
+
+	m.mu.Lock()
+	m.watchers[events] = directory
+	m.mu.Unlock()
+
+	go func() {
+		<-stopCh
+
+		m.mu.Lock()
+		delete(m.watchers, events)
+		close(events)
+		m.mu.Unlock()
+	}()
+
+	return events, nil
+}
+
+// notify notifies the watchers of the key change, with the lock held.
+func (m *kvClientMock) notify(key string) {
+	for events, directory := range m.watchers {
+		if strings.HasPrefix(key, directory+"/") {
+			select {
+			case events <- nil:
+			default:
+			}
+		}
+	}
+}
+
+func (m *kvClientMock) NewLock(key string, _ *store.LockOptions) (store.Locker, error) {
+	return &lockerMock{client: m, key: key}, nil
+}
+
+func (m *kvClientMock) List(directory string, _ *store.ReadOptions) ([]*store.KVPair, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var pairs []*store.KVPair
+	for key, value := range m.values {
+		if strings.HasPrefix(key, directory+"/") {
+			pairs = append(pairs, &store.KVPair{Key: key, Value: value})
+		}
+	}
+
+	if len(pairs) == 0 {
+		return nil, store.ErrKeyNotFound
+	}
+
+	return pairs, nil
+}
+
+func (m *kvClientMock) DeleteTree(string) error {
+	return errors.New("method DeleteTree not supported")
+}
+
+func (m *kvClientMock) AtomicPut(string, []byte, *store.KVPair, *store.WriteOptions) (bool, *store.KVPair, error) {
+	return false, nil, errors.New("method AtomicPut not supported")
+}
+
+func (m *kvClientMock) AtomicDelete(string, *store.KVPair) (bool, error) {
+	return false, errors.New("method AtomicDelete not supported")
+}
+
+func (m *kvClientMock) Close() {}
+
+type lockerMock struct {
+	client *kvClientMock
+	key    string
+}
+
+func (l *lockerMock) Lock(stopCh chan struct{}) (<-chan struct{}, error) {
+	for {
+		l.client.mu.Lock()
+		held, ok := l.client.locks[l.key]
+		if !ok {
+			l.client.locks[l.key] = make(chan struct{})
+			l.client.mu.Unlock()
+			return make(chan struct{}), nil
+		}
+		l.client.mu.Unlock()
+
+		select {
+		case <-held:
+		case <-stopCh:
+			return nil, errors.New("lock cancelled")
+		}
+	}
+}
+
+func (l *lockerMock) Unlock() error {
+	l.client.mu.Lock()
+	defer l.client.mu.Unlock()
+
+	held, ok := l.client.locks[l.key]
+	if !ok {
+		return errors.New("lock not held")
+	}
+
+	close(held)
+	delete(l.client.locks, l.key)
+	return nil
+}
@@ -10,7 +10,10 @@ import (
 	"github.com/traefik/traefik/v2/pkg/safe"
 )
 
-var _ Store = (*LocalStore)(nil)
+var (
+	_ Store                  = (*LocalStore)(nil)
+	_ ClientCertificateStore = (*LocalStore)(nil)
+)
 
 // LocalStore Stores implementation for local file.
 type LocalStore struct {
@@ -170,3 +173,26 @@ func (s *LocalStore) SaveCertificates(resolverName string, certificates []*CertA
 
 	return nil
 }
+
+// GetClientCertificate returns the client certificate obtained by the resolver, if any.
+func (s *LocalStore) GetClientCertificate(resolverName string) (*Certificate, error) {
+	storedData, err := s.get(resolverName)
+	if err != nil {
+		return nil, err
+	}
+
+	return storedData.ClientCertificate, nil
+}
+
+// SaveClientCertificate stores the client certificate obtained by the resolver.
+func (s *LocalStore) SaveClientCertificate(resolverName string, cert *Certificate) error {
+	storedData, err := s.get(resolverName)
+	if err != nil {
+		return err
+	}
+
+	storedData.ClientCertificate = cert
+	s.save(resolverName, storedData)
+
+	return nil
+}
@@ -0,0 +1,267 @@
+package acme
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/safe"
+	"github.com/traefik/traefik/v2/pkg/types"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// onDemandCacheDuration is the duration during which the outcome of an on-demand issuance is kept,
+	// which covers the time needed for an obtained certificate to be added to the TLS store.
+	onDemandCacheDuration = time.Minute
+
+	onDemandAskTimeout = 5 * time.Second
+)
+
+var errOnDemandPending = errors.New("certificate not obtained yet")
+
+var hostnameRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)+$`)
+
+// IssueCertificate obtains a certificate during the TLS handshake with a server name unknown to the TLS store,
+// if the server name is allowed by the on-demand configuration.
+func (p *Provider) IssueCertificate(serverName, storeName string) (*tls.Certificate, error) {
+	if p.onDemandIssuer == nil || storeName != "default" {
+		return nil, nil
+	}
+
+	ctx := log.With(context.Background(), log.Str(log.ProviderName, p.ResolverName+".acme"))
+
+	return p.onDemandIssuer.issue(ctx, serverName, func() (*tls.Certificate, error) {
+		return p.obtainOnDemand(ctx, serverName)
+	})
+}
+
+func (p *Provider) obtainOnDemand(ctx context.Context, serverName string) (*tls.Certificate, error) {
+	logger := log.FromContext(ctx)
+	logger.Infof("Obtaining a certificate on demand for %q", serverName)
+
+	cert, err := p.resolveCertificate(ctx, types.Domain{Main: serverName}, "default")
+	if err != nil {
+		logger.Errorf("Unable to obtain a certificate on demand for %q: %v", serverName, err)
+		return nil, err
+	}
+
+	// The certificate is already being obtained, or has already been obtained and will be added to the TLS store shortly.
+	if cert == nil {
+		return nil, nil
+	}
+
+	tlsCert, err := tls.X509KeyPair(cert.Certificate, cert.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate obtained on demand: %w", err)
+	}
+
+	return &tlsCert, nil
+}
+
+// onDemandIssuer checks whether the server names are allowed to get a certificate on demand,
+// and makes sure that a certificate is obtained only once for concurrent handshakes with the same server name.
+type onDemandIssuer struct {
+	domains    []*regexp.Regexp
+	ask        string
+	client     *http.Client
+	limiter    *rate.Limiter
+	askLimiter *rate.Limiter
+	timeout    time.Duration
+
+	mu    sync.Mutex
+	calls map[string]*onDemandCall
+}
+
+// onDemandCall is the issuance of a certificate for a server name.
+type onDemandCall struct {
+	done    chan struct{}
+	cert    *tls.Certificate
+	err     error
+	expires time.Time
+}
+
+// expired returns whether the issuance is over and its outcome is not to be used anymore.
+func (c *onDemandCall) expired(now time.Time) bool {
+	select {
+	case <-c.done:
+		return now.After(c.expires)
+	default:
+		return false
+	}
+}
+
+func newOnDemandIssuer(conf *OnDemand) (*onDemandIssuer, error) {
+	if len(conf.Domains) == 0 && conf.Ask == "" {
+		return nil, errors.New("domains or ask endpoint required to restrict the server names allowed to get a certificate")
+	}
+
+	issuer := &onDemandIssuer{
+		ask:        conf.Ask,
+		client:     &http.Client{Timeout: onDemandAskTimeout},
+		limiter:    rate.NewLimiter(rate.Inf, 0),
+		askLimiter: rate.NewLimiter(rate.Inf, 0),
+		timeout:    time.Duration(conf.Timeout),
+		calls:      make(map[string]*onDemandCall),
+	}
+
+	for _, domain := range conf.Domains {
+		// The expressions match the whole server name, for "example\.com" not to allow "example.com.attacker.net".
+		exp, err := regexp.Compile(`^(?:` + domain + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid domain regular expression %q: %w", domain, err)
+		}
+
+		issuer.domains = append(issuer.domains, exp)
+	}
+
+	if conf.Ask != "" {
+		if _, err := url.Parse(conf.Ask); err != nil {
+			return nil, fmt.Errorf("invalid ask endpoint: %w", err)
+		}
+	}
+
+	if conf.RateLimit != nil && conf.RateLimit.Burst > 0 {
+		limit := rate.Every(time.Duration(conf.RateLimit.Period) / time.Duration(conf.RateLimit.Burst))
+		issuer.limiter = rate.NewLimiter(limit, conf.RateLimit.Burst)
+	}
+
+	if conf.AskRateLimit != nil && conf.AskRateLimit.Burst > 0 {
+		limit := rate.Every(time.Duration(conf.AskRateLimit.Period) / time.Duration(conf.AskRateLimit.Burst))
+		issuer.askLimiter = rate.NewLimiter(limit, conf.AskRateLimit.Burst)
+	}
+
+	return issuer, nil
+}
+
+// issue returns the certificate for the server name, obtained with the given function if it is allowed.
+// It waits for the certificate up to the configured timeout.
+func (i *onDemandIssuer) issue(ctx context.Context, serverName string, obtain func() (*tls.Certificate, error)) (*tls.Certificate, error) {
+	if !hostnameRegexp.MatchString(serverName) || net.ParseIP(serverName) != nil {
+		return nil, fmt.Errorf("invalid server name %q", serverName)
+	}
+
+	if len(i.domains) > 0 && !i.matchDomains(serverName) {
+		return nil, fmt.Errorf("server name %q not allowed", serverName)
+	}
+
+	now := time.Now()
+
+	i.mu.Lock()
+	call, ok := i.calls[serverName]
+	if !ok || call.expired(now) {
+		i.sweep(now)
+
+		call = &onDemandCall{done: make(chan struct{})}
+		i.calls[serverName] = call
+
+		safe.Go(func() {
+			i.run(ctx, serverName, call, obtain)
+		})
+	}
+	i.mu.Unlock()
+
+	if i.timeout <= 0 {
+		select {
+		case <-call.done:
+			return call.cert, call.err
+		default:
+			return nil, errOnDemandPending
+		}
+	}
+
+	timer := time.NewTimer(i.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-call.done:
+		return call.cert, call.err
+	case <-timer.C:
+		return nil, errOnDemandPending
+	}
+}
+
+func (i *onDemandIssuer) run(ctx context.Context, serverName string, call *onDemandCall, obtain func() (*tls.Certificate, error)) {
+	defer close(call.done)
+
+	call.cert, call.err = i.authorizeAndObtain(ctx, serverName, obtain)
+
+	// Nothing is kept when the certificate is obtained by another issuance, for the next handshake to get it.
+	if call.cert != nil || call.err != nil {
+		call.expires = time.Now().Add(onDemandCacheDuration)
+	}
+}
+
+func (i *onDemandIssuer) authorizeAndObtain(ctx context.Context, serverName string, obtain func() (*tls.Certificate, error)) (*tls.Certificate, error) {
+	if i.ask != "" {
+		// The requests to the ask endpoint have their own rate limit, for a flood of unknown server names
+		// neither to flood the endpoint, nor to use up the certificates rate limit.
+		if !i.askLimiter.Allow() {
+			log.FromContext(ctx).Warnf("Rate limit of the on-demand ask endpoint exceeded, no certificate obtained for %q", serverName)
+			return nil, fmt.Errorf("ask rate limit exceeded for %q", serverName)
+		}
+
+		if err := i.askEndpoint(serverName); err != nil {
+			return nil, err
+		}
+	}
+
+	if !i.limiter.Allow() {
+		log.FromContext(ctx).Warnf("Rate limit of the on-demand certificates exceeded, no certificate obtained for %q", serverName)
+		return nil, fmt.Errorf("rate limit exceeded for %q", serverName)
+	}
+
+	return obtain()
+}
+
+// askEndpoint asks the endpoint whether the server name is allowed to get a certificate,
+// which it is if the endpoint responds with a 200 status code.
+func (i *onDemandIssuer) askEndpoint(serverName string) error {
+	askURL, err := url.Parse(i.ask)
+	if err != nil {
+		return err
+	}
+
+	query := askURL.Query()
+	query.Set("domain", serverName)
+	askURL.RawQuery = query.Encode()
+
+	resp, err := i.client.Get(askURL.String())
+	if err != nil {
+		return fmt.Errorf("unable to ask whether %q is allowed: %w", serverName, err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server name %q not allowed by the ask endpoint: %d", serverName, resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (i *onDemandIssuer) matchDomains(serverName string) bool {
+	for _, exp := range i.domains {
+		if exp.MatchString(serverName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sweep removes the issuances which outcome is not to be used anymore.
+func (i *onDemandIssuer) sweep(now time.Time) {
+	for serverName, call := range i.calls {
+		if call.expired(now) {
+			delete(i.calls, serverName)
+		}
+	}
+}
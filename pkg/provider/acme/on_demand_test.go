@@ -0,0 +1,293 @@
+package acme
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+)
+
+func TestNewOnDemandIssuer(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		conf      *OnDemand
+		expectErr bool
+	}{
+		{
+			desc:      "no allow-check",
+			conf:      &OnDemand{},
+			expectErr: true,
+		},
+		{
+			desc:      "invalid domain regular expression",
+			conf:      &OnDemand{Domains: []string{"("}},
+			expectErr: true,
+		},
+		{
+			desc: "domains",
+			conf: &OnDemand{Domains: []string{`.+\.example\.com`}},
+		},
+		{
+			desc: "ask endpoint",
+			conf: &OnDemand{Ask: "http://127.0.0.1/ask"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := newOnDemandIssuer(test.conf)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOnDemandIssuer_issue(t *testing.T) {
+	ask := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("domain") != "allowed.com" {
+			rw.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	t.Cleanup(ask.Close)
+
+	testCases := []struct {
+		desc       string
+		conf       *OnDemand
+		serverName string
+		expected   bool
+	}{
+		{
+			desc:       "matching domain",
+			conf:       &OnDemand{Domains: []string{`.+\.example\.com`}},
+			serverName: "foo.example.com",
+			expected:   true,
+		},
+		{
+			desc:       "not matching domain",
+			conf:       &OnDemand{Domains: []string{`.+\.example\.com`}},
+			serverName: "foo.example.org",
+		},
+		{
+			desc:       "domain matching only a part of the server name",
+			conf:       &OnDemand{Domains: []string{`example\.com`}},
+			serverName: "example.com.attacker.net",
+		},
+		{
+			desc:       "IP address",
+			conf:       &OnDemand{Domains: []string{`.*`}},
+			serverName: "127.0.0.1",
+		},
+		{
+			desc:       "invalid server name",
+			conf:       &OnDemand{Domains: []string{`.*`}},
+			serverName: "foo_bar.com",
+		},
+		{
+			desc:       "single label",
+			conf:       &OnDemand{Domains: []string{`.*`}},
+			serverName: "localhost",
+		},
+		{
+			desc:       "allowed by the ask endpoint",
+			conf:       &OnDemand{Ask: ask.URL + "/ask"},
+			serverName: "allowed.com",
+			expected:   true,
+		},
+		{
+			desc:       "not allowed by the ask endpoint",
+			conf:       &OnDemand{Ask: ask.URL + "/ask"},
+			serverName: "denied.com",
+		},
+		{
+			desc:       "matching domain not allowed by the ask endpoint",
+			conf:       &OnDemand{Domains: []string{`.*`}, Ask: ask.URL + "/ask"},
+			serverName: "denied.com",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			test.conf.Timeout = ptypes.Duration(time.Second)
+
+			issuer, err := newOnDemandIssuer(test.conf)
+			require.NoError(t, err)
+
+			var obtained int32
+			cert, err := issuer.issue(context.Background(), test.serverName, func() (*tls.Certificate, error) {
+				atomic.AddInt32(&obtained, 1)
+				return &tls.Certificate{}, nil
+			})
+
+			if !test.expected {
+				assert.Error(t, err)
+				assert.Nil(t, cert)
+				assert.Equal(t, int32(0), atomic.LoadInt32(&obtained))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, cert)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&obtained))
+		})
+	}
+}
+
+func TestOnDemandIssuer_issue_concurrent(t *testing.T) {
+	issuer, err := newOnDemandIssuer(&OnDemand{Domains: []string{`.*`}, Timeout: ptypes.Duration(time.Second)})
+	require.NoError(t, err)
+
+	var obtained int32
+	release := make(chan struct{})
+	obtain := func() (*tls.Certificate, error) {
+		atomic.AddInt32(&obtained, 1)
+		<-release
+		return &tls.Certificate{}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			cert, err := issuer.issue(context.Background(), "foo.com", obtain)
+			assert.NoError(t, err)
+			assert.NotNil(t, cert)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// The outcome is kept for the next handshakes.
+	cert, err := issuer.issue(context.Background(), "foo.com", obtain)
+	require.NoError(t, err)
+	assert.NotNil(t, cert)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&obtained))
+}
+
+func TestOnDemandIssuer_issue_timeout(t *testing.T) {
+	issuer, err := newOnDemandIssuer(&OnDemand{Domains: []string{`.*`}, Timeout: ptypes.Duration(10 * time.Millisecond)})
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	obtain := func() (*tls.Certificate, error) {
+		<-release
+		return &tls.Certificate{}, nil
+	}
+
+	_, err = issuer.issue(context.Background(), "foo.com", obtain)
+	assert.Error(t, err)
+
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		cert, err := issuer.issue(context.Background(), "foo.com", obtain)
+		return err == nil && cert != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestOnDemandIssuer_issue_rateLimit(t *testing.T) {
+	issuer, err := newOnDemandIssuer(&OnDemand{
+		Domains:   []string{`.*`},
+		Timeout:   ptypes.Duration(time.Second),
+		RateLimit: &OnDemandRateLimit{Burst: 2, Period: ptypes.Duration(time.Hour)},
+	})
+	require.NoError(t, err)
+
+	obtain := func() (*tls.Certificate, error) {
+		return &tls.Certificate{}, nil
+	}
+
+	_, err = issuer.issue(context.Background(), "foo.com", obtain)
+	require.NoError(t, err)
+
+	_, err = issuer.issue(context.Background(), "bar.com", obtain)
+	require.NoError(t, err)
+
+	_, err = issuer.issue(context.Background(), "baz.com", obtain)
+	assert.Error(t, err)
+
+	// The certificates already obtained are still served.
+	_, err = issuer.issue(context.Background(), "foo.com", obtain)
+	assert.NoError(t, err)
+}
+
+func TestOnDemandIssuer_issue_askRateLimit(t *testing.T) {
+	ask := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !strings.HasPrefix(req.URL.Query().Get("domain"), "allowed") {
+			rw.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	t.Cleanup(ask.Close)
+
+	issuer, err := newOnDemandIssuer(&OnDemand{
+		Ask:          ask.URL + "/ask",
+		Timeout:      ptypes.Duration(time.Second),
+		RateLimit:    &OnDemandRateLimit{Burst: 2, Period: ptypes.Duration(time.Hour)},
+		AskRateLimit: &OnDemandAskRateLimit{Burst: 4, Period: ptypes.Duration(time.Hour)},
+	})
+	require.NoError(t, err)
+
+	obtain := func() (*tls.Certificate, error) {
+		return &tls.Certificate{}, nil
+	}
+
+	// The server names denied by the ask endpoint do not use up the certificates rate limit.
+	for _, serverName := range []string{"denied1.com", "denied2.com"} {
+		_, err = issuer.issue(context.Background(), serverName, obtain)
+		require.Error(t, err)
+	}
+
+	_, err = issuer.issue(context.Background(), "allowed1.com", obtain)
+	require.NoError(t, err)
+
+	_, err = issuer.issue(context.Background(), "allowed2.com", obtain)
+	require.NoError(t, err)
+
+	// The ask rate limit is now exceeded.
+	_, err = issuer.issue(context.Background(), "allowed3.com", obtain)
+	assert.EqualError(t, err, `ask rate limit exceeded for "allowed3.com"`)
+}
+
+func TestOnDemandIssuer_issue_error(t *testing.T) {
+	issuer, err := newOnDemandIssuer(&OnDemand{Domains: []string{`.*`}, Timeout: ptypes.Duration(time.Second)})
+	require.NoError(t, err)
+
+	var obtained int32
+	obtain := func() (*tls.Certificate, error) {
+		atomic.AddInt32(&obtained, 1)
+		return nil, errors.New("boom")
+	}
+
+	_, err = issuer.issue(context.Background(), "foo.com", obtain)
+	assert.Error(t, err)
+
+	// The failure is kept, not to order the certificate again at each handshake.
+	_, err = issuer.issue(context.Background(), "foo.com", obtain)
+	assert.Error(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&obtained))
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
@@ -40,6 +41,12 @@ type Configuration struct {
 	KeyType        string `description:"KeyType used for generating certificate private key. Allow value 'EC256', 'EC384', 'RSA2048', 'RSA4096', 'RSA8192'." json:"keyType,omitempty" toml:"keyType,omitempty" yaml:"keyType,omitempty" export:"true"`
 	EAB            *EAB   `description:"External Account Binding to use." json:"eab,omitempty" toml:"eab,omitempty" yaml:"eab,omitempty"`
 
+	AdditionalKeyType string `description:"KeyType used for generating the private key of an additional certificate obtained for each domain, served to the clients not supporting the other one. Allow value 'EC256', 'EC384', 'RSA2048', 'RSA4096', 'RSA8192'." json:"additionalKeyType,omitempty" toml:"additionalKeyType,omitempty" yaml:"additionalKeyType,omitempty" export:"true"`
+
+	KVStorage *KVStorage `description:"KV store shared by several Traefik instances, used instead of the storage file." json:"kvStorage,omitempty" toml:"kvStorage,omitempty" yaml:"kvStorage,omitempty" export:"true"`
+	OnDemand  *OnDemand  `description:"Obtain certificates during the TLS handshakes with unknown server names." json:"onDemand,omitempty" toml:"onDemand,omitempty" yaml:"onDemand,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+	Renewal   *Renewal   `description:"Certificates renewal configuration." json:"renewal,omitempty" toml:"renewal,omitempty" yaml:"renewal,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+
 	DNSChallenge  *DNSChallenge  `description:"Activate DNS-01 Challenge." json:"dnsChallenge,omitempty" toml:"dnsChallenge,omitempty" yaml:"dnsChallenge,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
 	HTTPChallenge *HTTPChallenge `description:"Activate HTTP-01 Challenge." json:"httpChallenge,omitempty" toml:"httpChallenge,omitempty" yaml:"httpChallenge,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
 	TLSChallenge  *TLSChallenge  `description:"Activate TLS-ALPN-01 Challenge." json:"tlsChallenge,omitempty" toml:"tlsChallenge,omitempty" yaml:"tlsChallenge,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
@@ -63,6 +70,8 @@ type Certificate struct {
 	Domain      types.Domain `json:"domain,omitempty" toml:"domain,omitempty" yaml:"domain,omitempty"`
 	Certificate []byte       `json:"certificate,omitempty" toml:"certificate,omitempty" yaml:"certificate,omitempty"`
 	Key         []byte       `json:"key,omitempty" toml:"key,omitempty" yaml:"key,omitempty"`
+	// KeyType is the key type of the additional certificates, empty for the main ones.
+	KeyType string `json:"keyType,omitempty" toml:"keyType,omitempty" yaml:"keyType,omitempty"`
 }
 
 // EAB contains External Account Binding configuration.
@@ -87,6 +96,80 @@ type HTTPChallenge struct {
 // TLSChallenge contains TLS challenge configuration.
 type TLSChallenge struct{}
 
+// KVStorage contains the configuration of the KV store shared by several Traefik instances.
+type KVStorage struct {
+	Backend   string           `description:"KV store backend: consul | etcd | redis | zookeeper" json:"backend,omitempty" toml:"backend,omitempty" yaml:"backend,omitempty" export:"true"`
+	Endpoints []string         `description:"KV store endpoints." json:"endpoints,omitempty" toml:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	RootKey   string           `description:"Root key of the ACME data." json:"rootKey,omitempty" toml:"rootKey,omitempty" yaml:"rootKey,omitempty" export:"true"`
+	Username  string           `description:"KV Username" json:"username,omitempty" toml:"username,omitempty" yaml:"username,omitempty"`
+	Password  string           `description:"KV Password" json:"password,omitempty" toml:"password,omitempty" yaml:"password,omitempty"`
+	TLS       *types.ClientTLS `description:"Enable TLS support" json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+	LockTTL   ptypes.Duration  `description:"Duration after which a lock held by an instance which stopped responding is released." json:"lockTTL,omitempty" toml:"lockTTL,omitempty" yaml:"lockTTL,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (k *KVStorage) SetDefaults() {
+	k.RootKey = "traefik/acme"
+	k.LockTTL = ptypes.Duration(30 * time.Second)
+}
+
+// OnDemand contains the configuration of the on-demand certificates.
+type OnDemand struct {
+	Domains      []string              `description:"Regular expressions matching the whole server names allowed to get a certificate on demand." json:"domains,omitempty" toml:"domains,omitempty" yaml:"domains,omitempty"`
+	Ask          string                `description:"URL of an endpoint asked whether a server name is allowed to get a certificate on demand." json:"ask,omitempty" toml:"ask,omitempty" yaml:"ask,omitempty"`
+	Timeout      ptypes.Duration       `description:"Maximum duration a TLS handshake waits for a certificate to be obtained on demand." json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty" export:"true"`
+	RateLimit    *OnDemandRateLimit    `description:"Rate limit of the certificates obtained on demand." json:"rateLimit,omitempty" toml:"rateLimit,omitempty" yaml:"rateLimit,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+	AskRateLimit *OnDemandAskRateLimit `description:"Rate limit of the requests to the ask endpoint." json:"askRateLimit,omitempty" toml:"askRateLimit,omitempty" yaml:"askRateLimit,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (o *OnDemand) SetDefaults() {
+	o.Timeout = ptypes.Duration(30 * time.Second)
+	o.RateLimit = &OnDemandRateLimit{}
+	o.RateLimit.SetDefaults()
+	o.AskRateLimit = &OnDemandAskRateLimit{}
+	o.AskRateLimit.SetDefaults()
+}
+
+// OnDemandRateLimit contains the rate limit of the certificates obtained on demand, for all the server names.
+type OnDemandRateLimit struct {
+	Burst  int             `description:"Maximum number of certificates obtained on demand in a row." json:"burst,omitempty" toml:"burst,omitempty" yaml:"burst,omitempty" export:"true"`
+	Period ptypes.Duration `description:"Period over which the burst of certificates is replenished." json:"period,omitempty" toml:"period,omitempty" yaml:"period,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (r *OnDemandRateLimit) SetDefaults() {
+	r.Burst = 10
+	r.Period = ptypes.Duration(time.Hour)
+}
+
+// OnDemandAskRateLimit contains the rate limit of the requests to the ask endpoint, for all the server names.
+type OnDemandAskRateLimit struct {
+	Burst  int             `description:"Maximum number of requests to the ask endpoint in a row." json:"burst,omitempty" toml:"burst,omitempty" yaml:"burst,omitempty" export:"true"`
+	Period ptypes.Duration `description:"Period over which the burst of requests is replenished." json:"period,omitempty" toml:"period,omitempty" yaml:"period,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (r *OnDemandAskRateLimit) SetDefaults() {
+	r.Burst = 60
+	r.Period = ptypes.Duration(time.Minute)
+}
+
+// Renewal contains the configuration of the certificates renewal.
+type Renewal struct {
+	Before        ptypes.Duration `description:"Renew the certificates expiring within this duration." json:"before,omitempty" toml:"before,omitempty" yaml:"before,omitempty" export:"true"`
+	LifetimeRatio float64         `description:"Renew the certificates when this fraction of their lifetime is left, instead of using the before duration." json:"lifetimeRatio,omitempty" toml:"lifetimeRatio,omitempty" yaml:"lifetimeRatio,omitempty" export:"true"`
+	CheckInterval ptypes.Duration `description:"Maximum interval between two checks of the certificates to renew." json:"checkInterval,omitempty" toml:"checkInterval,omitempty" yaml:"checkInterval,omitempty" export:"true"`
+	Jitter        ptypes.Duration `description:"Maximum random delay added to the checks of the certificates to renew." json:"jitter,omitempty" toml:"jitter,omitempty" yaml:"jitter,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (r *Renewal) SetDefaults() {
+	r.Before = ptypes.Duration(30 * 24 * time.Hour)
+	r.CheckInterval = ptypes.Duration(24 * time.Hour)
+	r.Jitter = ptypes.Duration(time.Hour)
+}
+
 // Provider holds configurations of the provider.
 type Provider struct {
 	*Configuration
@@ -107,6 +190,12 @@ type Provider struct {
 	pool                   *safe.Pool
 	resolvingDomains       map[string]struct{}
 	resolvingDomainsMutex  sync.RWMutex
+	onDemandIssuer         *onDemandIssuer
+	certificatesMu         sync.RWMutex
+	renewalStatusesMu      sync.Mutex
+	renewalStatuses        map[string]*renewalStatus
+	resolvingAdditionalMu  sync.Mutex
+	resolvingAdditional    map[string]struct{}
 }
 
 // SetTLSManager sets the tls manager to use.
@@ -129,7 +218,7 @@ func (p *Provider) Init() error {
 	ctx := log.With(context.Background(), log.Str(log.ProviderName, p.ResolverName+".acme"))
 	logger := log.FromContext(ctx)
 
-	if len(p.Configuration.Storage) == 0 {
+	if len(p.Configuration.Storage) == 0 && p.Configuration.KVStorage == nil {
 		return errors.New("unable to initialize ACME provider with no storage location for the certificates")
 	}
 
@@ -153,6 +242,29 @@ func (p *Provider) Init() error {
 	// Init the currently resolved domain map
 	p.resolvingDomains = make(map[string]struct{})
 
+	if p.Renewal != nil && (p.Renewal.LifetimeRatio < 0 || p.Renewal.LifetimeRatio >= 1) {
+		return fmt.Errorf("invalid renewal lifetime ratio %v: must be between 0 and 1", p.Renewal.LifetimeRatio)
+	}
+
+	if p.AdditionalKeyType != "" {
+		// GetKeyType falls back on RSA4096 for the unknown key types.
+		if !isKnownKeyType(p.AdditionalKeyType) {
+			return fmt.Errorf("invalid additional key type %q: must be one of %s", p.AdditionalKeyType, strings.Join(knownKeyTypes, ", "))
+		}
+
+		// The certificates of a domain are told apart by their public key algorithm.
+		if isECDSAKeyType(GetKeyType(ctx, p.AdditionalKeyType)) == isECDSAKeyType(GetKeyType(ctx, p.KeyType)) {
+			return fmt.Errorf("invalid additional key type %q: one of the key type and the additional key type must be an ECDSA one, and the other an RSA one", p.AdditionalKeyType)
+		}
+	}
+
+	if p.OnDemand != nil {
+		p.onDemandIssuer, err = newOnDemandIssuer(p.OnDemand)
+		if err != nil {
+			return fmt.Errorf("invalid on-demand configuration: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -187,16 +299,31 @@ func (p *Provider) Provide(configurationChan chan<- dynamic.Message, pool *safe.
 	p.configurationChan = configurationChan
 	p.refreshCertificates()
 
+	if p.onDemandIssuer != nil {
+		p.tlsManager.AddCertificateIssuer(p)
+	}
+
 	p.renewCertificates(ctx)
 
-	ticker := time.NewTicker(24 * time.Hour)
+	timer := time.NewTimer(p.nextRenewalCheck(ctx))
 	pool.GoCtx(func(ctxPool context.Context) {
+		// The certificates revoked by another instance sharing the store are checked more often than the renewals.
+		var revokedCheck <-chan time.Time
+		if _, ok := p.Store.(CertificateRemover); ok {
+			ticker := time.NewTicker(revokedCheckInterval)
+			defer ticker.Stop()
+			revokedCheck = ticker.C
+		}
+
 		for {
 			select {
-			case <-ticker.C:
+			case <-timer.C:
 				p.renewCertificates(ctx)
+				timer.Reset(p.nextRenewalCheck(ctx))
+			case <-revokedCheck:
+				p.dropRevokedCertificates(ctx)
 			case <-ctxPool.Done():
-				ticker.Stop()
+				timer.Stop()
 				return
 			}
 		}
@@ -216,6 +343,14 @@ func (p *Provider) getClient() (*lego.Client, error) {
 		return p.client, nil
 	}
 
+	unlock, err := p.lock("account")
+	if err != nil {
+		return nil, fmt.Errorf("unable to lock the ACME account: %w", err)
+	}
+	defer unlock()
+
+	p.loadSharedAccount(ctx)
+
 	account, err := p.initAccount(ctx)
 	if err != nil {
 		return nil, err
@@ -313,6 +448,34 @@ func (p *Provider) getClient() (*lego.Client, error) {
 	return p.client, nil
 }
 
+// lock acquires the lock of the given name if the store is shared by several Traefik instances.
+// The returned function releases it.
+func (p *Provider) lock(name string) (func(), error) {
+	locker, ok := p.Store.(Locker)
+	if !ok {
+		return func() {}, nil
+	}
+
+	return locker.Lock(p.ResolverName, name)
+}
+
+// loadSharedAccount loads the account registered by another instance sharing the store, if none is registered yet.
+func (p *Provider) loadSharedAccount(ctx context.Context) {
+	if _, ok := p.Store.(Locker); !ok || (p.account != nil && p.account.GetRegistration() != nil) {
+		return
+	}
+
+	account, err := p.Store.GetAccount(p.ResolverName)
+	if err != nil {
+		log.FromContext(ctx).Errorf("Unable to get the shared ACME account: %v", err)
+		return
+	}
+
+	if account != nil && account.GetRegistration() != nil && isAccountMatchingCaServer(ctx, account.Registration.URI, p.CAServer) {
+		p.account = account
+	}
+}
+
 func (p *Provider) initAccount(ctx context.Context) (*Account, error) {
 	if p.account == nil || len(p.account.Email) == 0 {
 		var err error
@@ -466,6 +629,45 @@ func (p *Provider) resolveCertificate(ctx context.Context, domain types.Domain,
 
 	defer p.removeResolvingDomains(uncheckedDomains)
 
+	if len(uncheckedDomains) > 1 {
+		domain = types.Domain{Main: uncheckedDomains[0], SANs: uncheckedDomains[1:]}
+	} else {
+		domain = types.Domain{Main: uncheckedDomains[0]}
+	}
+
+	unlock, err := p.lock(certificateLockName(domain))
+	if err != nil {
+		return nil, fmt.Errorf("unable to lock the domains %v: %w", uncheckedDomains, err)
+	}
+	defer unlock()
+
+	var cert *certificate.Resource
+	if shared := p.loadSharedCertificate(ctx, domain, tlsStore, ""); shared != nil {
+		cert = &certificate.Resource{
+			Domain:      domain.Main,
+			Certificate: shared.Certificate.Certificate,
+			PrivateKey:  shared.Key,
+		}
+	} else {
+		cert, err = p.orderCertificate(ctx, domains, domain, tlsStore, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The main certificate is returned without waiting for the additional one.
+	if p.AdditionalKeyType != "" {
+		p.resolveAdditionalCertificateInBackground(domains, domain, tlsStore)
+	}
+
+	return cert, nil
+}
+
+// orderCertificate orders a certificate for the domains to the CA, and stores it for the given domain.
+// The private key of the additional certificates is generated with the given key type, empty for the main ones.
+func (p *Provider) orderCertificate(ctx context.Context, domains []string, domain types.Domain, tlsStore, keyType string) (*certificate.Resource, error) {
+	uncheckedDomains := domain.ToStrArray()
+
 	logger := log.FromContext(ctx)
 	logger.Debugf("Loading ACME certificates %+v...", uncheckedDomains)
 
@@ -480,6 +682,13 @@ func (p *Provider) resolveCertificate(ctx context.Context, domain types.Domain,
 		MustStaple: oscpMustStaple,
 	}
 
+	if keyType != "" {
+		request.PrivateKey, err = certcrypto.GeneratePrivateKey(GetKeyType(ctx, keyType))
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate a %s private key: %w", keyType, err)
+		}
+	}
+
 	cert, err := client.Certificate.Obtain(request)
 	if err != nil {
 		return nil, fmt.Errorf("unable to generate a certificate for the domains %v: %w", uncheckedDomains, err)
@@ -493,16 +702,46 @@ func (p *Provider) resolveCertificate(ctx context.Context, domain types.Domain,
 
 	logger.Debugf("Certificates obtained for domains %+v", uncheckedDomains)
 
-	if len(uncheckedDomains) > 1 {
-		domain = types.Domain{Main: uncheckedDomains[0], SANs: uncheckedDomains[1:]}
-	} else {
-		domain = types.Domain{Main: uncheckedDomains[0]}
-	}
-	p.addCertificateForDomain(domain, cert.Certificate, cert.PrivateKey, tlsStore)
+	p.addCertificateForDomain(domain, cert.Certificate, cert.PrivateKey, tlsStore, keyType)
+	p.shareCertificate(ctx, domain, cert.Certificate, cert.PrivateKey, tlsStore, keyType)
 
 	return cert, nil
 }
 
+// certificateLockName returns the name of the lock preventing several instances from ordering a certificate for the same domains.
+func certificateLockName(domain types.Domain) string {
+	return "certificates/" + strings.Join(domain.ToStrArray(), ",")
+}
+
+// loadSharedCertificate loads the certificate for the domains and key type obtained by another instance sharing the store,
+// if it does not need to be renewed. It returns the certificate found, if any.
+func (p *Provider) loadSharedCertificate(ctx context.Context, domain types.Domain, tlsStore, keyType string) *CertAndStore {
+	if _, ok := p.Store.(Locker); !ok {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+
+	certificates, err := p.Store.GetCertificates(p.ResolverName)
+	if err != nil {
+		logger.Errorf("Unable to get the shared ACME certificates: %v", err)
+		return nil
+	}
+
+	for _, cert := range certificates {
+		if cert.Store != tlsStore || cert.KeyType != keyType || !reflect.DeepEqual(cert.Domain, domain) || p.needsRenewal(ctx, &cert.Certificate) {
+			continue
+		}
+
+		logger.Debugf("Using the certificate obtained by another instance for domains %v", domain.ToStrArray())
+		p.addCertificateForDomain(domain, cert.Certificate.Certificate, cert.Key, tlsStore, keyType)
+
+		return cert
+	}
+
+	return nil
+}
+
 func (p *Provider) removeResolvingDomains(resolvingDomains []string) {
 	p.resolvingDomainsMutex.Lock()
 	defer p.resolvingDomainsMutex.Unlock()
@@ -512,8 +751,21 @@ func (p *Provider) removeResolvingDomains(resolvingDomains []string) {
 	}
 }
 
-func (p *Provider) addCertificateForDomain(domain types.Domain, certificate, key []byte, tlsStore string) {
-	p.certsChan <- &CertAndStore{Certificate: Certificate{Certificate: certificate, Key: key, Domain: domain}, Store: tlsStore}
+func (p *Provider) addCertificateForDomain(domain types.Domain, certificate, key []byte, tlsStore, keyType string) {
+	p.certsChan <- &CertAndStore{Certificate: Certificate{Certificate: certificate, Key: key, Domain: domain, KeyType: keyType}, Store: tlsStore}
+}
+
+// shareCertificate stores the certificate right away if the store is shared by several Traefik instances,
+// for the other ones to find it once the lock of its domains is released.
+func (p *Provider) shareCertificate(ctx context.Context, domain types.Domain, certificate, key []byte, tlsStore, keyType string) {
+	if _, ok := p.Store.(Locker); !ok {
+		return
+	}
+
+	cert := &CertAndStore{Certificate: Certificate{Certificate: certificate, Key: key, Domain: domain, KeyType: keyType}, Store: tlsStore}
+	if err := p.Store.SaveCertificates(p.ResolverName, []*CertAndStore{cert}); err != nil {
+		log.FromContext(ctx).Errorf("Unable to share the certificate for domains %v: %v", domain.ToStrArray(), err)
+	}
 }
 
 // deleteUnnecessaryDomains deletes from the configuration :
@@ -580,10 +832,11 @@ func (p *Provider) watchCertificate(ctx context.Context) {
 		for {
 			select {
 			case cert := <-p.certsChan:
+				p.certificatesMu.Lock()
 				certUpdated := false
-				for _, domainsCertificate := range p.certificates {
-					if reflect.DeepEqual(cert.Domain, domainsCertificate.Certificate.Domain) {
-						domainsCertificate.Certificate = cert.Certificate
+				for i, domainsCertificate := range p.certificates {
+					if cert.KeyType == domainsCertificate.KeyType && reflect.DeepEqual(cert.Domain, domainsCertificate.Certificate.Domain) {
+						p.certificates[i] = &CertAndStore{Certificate: cert.Certificate, Store: domainsCertificate.Store}
 						certUpdated = true
 						break
 					}
@@ -591,6 +844,7 @@ func (p *Provider) watchCertificate(ctx context.Context) {
 				if !certUpdated {
 					p.certificates = append(p.certificates, cert)
 				}
+				p.certificatesMu.Unlock()
 
 				err := p.saveCertificates()
 				if err != nil {
@@ -604,7 +858,7 @@ func (p *Provider) watchCertificate(ctx context.Context) {
 }
 
 func (p *Provider) saveCertificates() error {
-	err := p.Store.SaveCertificates(p.ResolverName, p.certificates)
+	err := p.Store.SaveCertificates(p.ResolverName, p.getCertificates())
 
 	p.refreshCertificates()
 
@@ -624,7 +878,7 @@ func (p *Provider) refreshCertificates() {
 		},
 	}
 
-	for _, cert := range p.certificates {
+	for _, cert := range p.getCertificates() {
 		certConf := &traefiktls.CertAndStores{
 			Certificate: traefiktls.Certificate{
 				CertFile: traefiktls.FileOrContent(cert.Certificate.Certificate),
@@ -642,37 +896,82 @@ func (p *Provider) renewCertificates(ctx context.Context) {
 	logger := log.FromContext(ctx)
 
 	logger.Info("Testing certificate renew...")
-	for _, cert := range p.certificates {
-		crt, err := getX509Certificate(ctx, &cert.Certificate)
-		// If there's an error, we assume the cert is broken, and needs update
-		// <= 30 days left, renew certificate
-		if err != nil || crt == nil || crt.NotAfter.Before(time.Now().Add(24*30*time.Hour)) {
-			client, err := p.getClient()
-			if err != nil {
-				logger.Infof("Error renewing certificate from LE : %+v, %v", cert.Domain, err)
-				continue
-			}
+	now := time.Now()
+	for _, cert := range p.getCertificates() {
+		if !p.needsRenewal(ctx, &cert.Certificate) {
+			continue
+		}
 
-			logger.Infof("Renewing certificate from LE : %+v", cert.Domain)
+		if retryAt := p.retryRenewalAt(cert); now.Before(retryAt) {
+			logger.Debugf("Renewal of the certificate for %v postponed to %s after failed attempts", cert.Domain, retryAt.Format(time.RFC3339))
+			continue
+		}
 
-			renewedCert, err := client.Certificate.Renew(certificate.Resource{
-				Domain:      cert.Domain.Main,
-				PrivateKey:  cert.Key,
-				Certificate: cert.Certificate.Certificate,
-			}, true, oscpMustStaple, p.PreferredChain)
-			if err != nil {
-				logger.Errorf("Error renewing certificate from LE: %v, %v", cert.Domain, err)
-				continue
-			}
+		p.renewCertificate(ctx, cert)
+	}
 
-			if len(renewedCert.Certificate) == 0 || len(renewedCert.PrivateKey) == 0 {
-				logger.Errorf("domains %v renew certificate with no value: %v", cert.Domain.ToStrArray(), cert)
-				continue
-			}
+	if p.AdditionalKeyType != "" {
+		p.obtainMissingAdditionalCertificates(ctx)
+	}
+}
 
-			p.addCertificateForDomain(cert.Domain, renewedCert.Certificate, renewedCert.PrivateKey, cert.Store)
-		}
+func (p *Provider) renewCertificate(ctx context.Context, cert *CertAndStore) {
+	attempt := time.Now()
+
+	err := p.obtainRenewedCertificate(ctx, cert)
+	if err != nil {
+		log.FromContext(ctx).Errorf("Error renewing certificate from LE: %v, %v", cert.Domain, err)
+	}
+
+	p.recordRenewal(cert, attempt, err)
+}
+
+func (p *Provider) obtainRenewedCertificate(ctx context.Context, cert *CertAndStore) error {
+	client, err := p.getClient()
+	if err != nil {
+		return fmt.Errorf("cannot get ACME client: %w", err)
 	}
+
+	unlock, err := p.lock(certificateLockName(cert.Domain))
+	if err != nil {
+		return fmt.Errorf("unable to lock the domains: %w", err)
+	}
+	defer unlock()
+
+	if p.loadSharedCertificate(ctx, cert.Domain, cert.Store, cert.KeyType) != nil {
+		return nil
+	}
+
+	log.FromContext(ctx).Infof("Renewing certificate from LE : %+v", cert.Domain)
+
+	renewedCert, err := client.Certificate.Renew(certificate.Resource{
+		Domain:      cert.Domain.Main,
+		PrivateKey:  cert.Key,
+		Certificate: cert.Certificate.Certificate,
+	}, true, oscpMustStaple, p.PreferredChain)
+	if err != nil {
+		return err
+	}
+
+	if len(renewedCert.Certificate) == 0 || len(renewedCert.PrivateKey) == 0 {
+		return fmt.Errorf("domains %v renew certificate with no value", cert.Domain.ToStrArray())
+	}
+
+	p.addCertificateForDomain(cert.Domain, renewedCert.Certificate, renewedCert.PrivateKey, cert.Store, cert.KeyType)
+	p.shareCertificate(ctx, cert.Domain, renewedCert.Certificate, renewedCert.PrivateKey, cert.Store, cert.KeyType)
+
+	return nil
+}
+
+// getCertificates returns a copy of the list of the certificates obtained by the resolver.
+func (p *Provider) getCertificates() []*CertAndStore {
+	p.certificatesMu.RLock()
+	defer p.certificatesMu.RUnlock()
+
+	certificates := make([]*CertAndStore, len(p.certificates))
+	copy(certificates, p.certificates)
+
+	return certificates
 }
 
 // Get provided certificate which check a domains list (Main and SANs)
@@ -686,7 +985,7 @@ func (p *Provider) getUncheckedDomains(ctx context.Context, domainsToCheck []str
 	allDomains := p.tlsManager.GetStore(tlsStore).GetAllDomains()
 
 	// Get ACME certificates
-	for _, cert := range p.certificates {
+	for _, cert := range p.getCertificates() {
 		allDomains = append(allDomains, strings.Join(cert.Domain.ToStrArray(), ","))
 	}
 
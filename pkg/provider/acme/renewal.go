@@ -0,0 +1,223 @@
+package acme
+
+import (
+	"context"
+	"crypto/x509"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+const (
+	// minRenewalCheckInterval is the minimum interval between two checks of the certificates to renew.
+	minRenewalCheckInterval = 5 * time.Minute
+
+	// maxRenewalBackoff is the maximum delay before retrying the renewal of a certificate in error,
+	// the delay doubling from minRenewalCheckInterval after each failed attempt.
+	maxRenewalBackoff = 24 * time.Hour
+)
+
+// CertificateStatus is the renewal status of a certificate obtained by a resolver.
+type CertificateStatus struct {
+	Resolver           string       `json:"resolver"`
+	Domain             types.Domain `json:"domain"`
+	Store              string       `json:"store"`
+	KeyType            string       `json:"keyType,omitempty"`
+	NotBefore          *time.Time   `json:"notBefore,omitempty"`
+	NotAfter           *time.Time   `json:"notAfter,omitempty"`
+	RenewAt            *time.Time   `json:"renewAt,omitempty"`
+	LastRenewal        *time.Time   `json:"lastRenewal,omitempty"`
+	LastRenewalAttempt *time.Time   `json:"lastRenewalAttempt,omitempty"`
+	LastRenewalError   string       `json:"lastRenewalError,omitempty"`
+}
+
+// renewalStatus is the outcome of the renewals of a certificate.
+type renewalStatus struct {
+	lastRenewal        time.Time
+	lastRenewalAttempt time.Time
+	lastRenewalError   string
+	// failures is the number of renewal attempts in error since the last renewal.
+	failures int
+}
+
+// CertificatesStatus returns the renewal status of the certificates obtained by the resolver.
+func (p *Provider) CertificatesStatus(ctx context.Context) []CertificateStatus {
+	renewal := p.renewal()
+
+	var statuses []CertificateStatus
+	for _, cert := range p.getCertificates() {
+		status := CertificateStatus{
+			Resolver: p.ResolverName,
+			Domain:   cert.Domain,
+			Store:    cert.Store,
+			KeyType:  cert.KeyType,
+		}
+
+		if crt, err := getX509Certificate(ctx, &cert.Certificate); err == nil && crt != nil {
+			notBefore, notAfter, renewAt := crt.NotBefore, crt.NotAfter, renewal.renewAt(crt)
+			status.NotBefore = &notBefore
+			status.NotAfter = &notAfter
+			status.RenewAt = &renewAt
+		}
+
+		p.renewalStatusesMu.Lock()
+		if renewed, ok := p.renewalStatuses[renewalStatusKey(cert)]; ok {
+			if !renewed.lastRenewal.IsZero() {
+				lastRenewal := renewed.lastRenewal
+				status.LastRenewal = &lastRenewal
+			}
+			lastRenewalAttempt := renewed.lastRenewalAttempt
+			status.LastRenewalAttempt = &lastRenewalAttempt
+			status.LastRenewalError = renewed.lastRenewalError
+		}
+		p.renewalStatusesMu.Unlock()
+
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Domain.Main < statuses[j].Domain.Main
+	})
+
+	return statuses
+}
+
+// recordRenewal records the outcome of a renewal attempt of the certificate.
+func (p *Provider) recordRenewal(cert *CertAndStore, attempt time.Time, err error) {
+	p.renewalStatusesMu.Lock()
+	defer p.renewalStatusesMu.Unlock()
+
+	if p.renewalStatuses == nil {
+		p.renewalStatuses = make(map[string]*renewalStatus)
+	}
+
+	key := renewalStatusKey(cert)
+
+	status, ok := p.renewalStatuses[key]
+	if !ok {
+		status = &renewalStatus{}
+		p.renewalStatuses[key] = status
+	}
+
+	status.lastRenewalAttempt = attempt
+	status.lastRenewalError = ""
+
+	if err != nil {
+		status.lastRenewalError = err.Error()
+		status.failures++
+		return
+	}
+
+	status.lastRenewal = attempt
+	status.failures = 0
+}
+
+// retryRenewalAt returns the time from which the renewal of the certificate can be attempted again,
+// which is zero unless the last attempts are in error.
+func (p *Provider) retryRenewalAt(cert *CertAndStore) time.Time {
+	p.renewalStatusesMu.Lock()
+	defer p.renewalStatusesMu.Unlock()
+
+	status, ok := p.renewalStatuses[renewalStatusKey(cert)]
+	if !ok || status.failures == 0 {
+		return time.Time{}
+	}
+
+	return status.lastRenewalAttempt.Add(renewalBackoff(status.failures))
+}
+
+// renewalBackoff returns the delay before retrying a renewal after the given number of failed attempts.
+func renewalBackoff(failures int) time.Duration {
+	backoff := minRenewalCheckInterval
+	for i := 1; i < failures && backoff < maxRenewalBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxRenewalBackoff {
+		return maxRenewalBackoff
+	}
+
+	return backoff
+}
+
+func renewalStatusKey(cert *CertAndStore) string {
+	key := cert.Store + "/" + strings.Join(cert.Domain.ToStrArray(), ",")
+	if cert.KeyType != "" {
+		key += "/" + cert.KeyType
+	}
+
+	return key
+}
+
+// renewal returns the renewal configuration of the resolver.
+func (p *Provider) renewal() *Renewal {
+	if p.Renewal != nil {
+		return p.Renewal
+	}
+
+	renewal := &Renewal{}
+	renewal.SetDefaults()
+
+	return renewal
+}
+
+// renewAt returns the time from which the certificate is to be renewed.
+func (r *Renewal) renewAt(crt *x509.Certificate) time.Time {
+	if r.LifetimeRatio > 0 {
+		lifetime := crt.NotAfter.Sub(crt.NotBefore)
+		return crt.NotAfter.Add(-time.Duration(float64(lifetime) * r.LifetimeRatio))
+	}
+
+	return crt.NotAfter.Add(-time.Duration(r.Before))
+}
+
+// needsRenewal returns whether the certificate is within its renewal window.
+// If it cannot be parsed, it is assumed to be broken and to need to be renewed.
+func (p *Provider) needsRenewal(ctx context.Context, cert *Certificate) bool {
+	crt, err := getX509Certificate(ctx, cert)
+	return err != nil || crt == nil || !time.Now().Before(p.renewal().renewAt(crt))
+}
+
+// nextRenewalCheck returns the delay before the next check of the certificates to renew:
+// the check interval, shortened to the renewal time of the first certificate to renew
+// (postponed by the backoff of the renewals in error), plus a random delay up to the jitter, and up to half the delay.
+func (p *Provider) nextRenewalCheck(ctx context.Context) time.Duration {
+	renewal := p.renewal()
+
+	now := time.Now()
+	delay := time.Duration(renewal.CheckInterval)
+
+	for _, cert := range p.getCertificates() {
+		crt, err := getX509Certificate(ctx, &cert.Certificate)
+		if err != nil || crt == nil {
+			continue
+		}
+
+		renewAt := renewal.renewAt(crt)
+		if retryAt := p.retryRenewalAt(cert); retryAt.After(renewAt) {
+			renewAt = retryAt
+		}
+
+		if d := renewAt.Sub(now); d < delay {
+			delay = d
+		}
+	}
+
+	if delay < minRenewalCheckInterval {
+		delay = minRenewalCheckInterval
+	}
+
+	jitter := time.Duration(renewal.Jitter)
+	if jitter > delay/2 {
+		jitter = delay / 2
+	}
+
+	if jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(jitter)))
+	}
+
+	return delay
+}
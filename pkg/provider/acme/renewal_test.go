@@ -0,0 +1,205 @@
+package acme
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/tls/generate"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestRenewal_renewAt(t *testing.T) {
+	notBefore := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	crt := &x509.Certificate{NotBefore: notBefore, NotAfter: notBefore.Add(90 * 24 * time.Hour)}
+
+	testCases := []struct {
+		desc     string
+		renewal  *Renewal
+		expected time.Time
+	}{
+		{
+			desc:     "before duration",
+			renewal:  &Renewal{Before: ptypes.Duration(30 * 24 * time.Hour)},
+			expected: notBefore.Add(60 * 24 * time.Hour),
+		},
+		{
+			desc:     "lifetime ratio",
+			renewal:  &Renewal{Before: ptypes.Duration(30 * 24 * time.Hour), LifetimeRatio: 0.5},
+			expected: notBefore.Add(45 * 24 * time.Hour),
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.renewal.renewAt(crt))
+		})
+	}
+}
+
+func TestProvider_needsRenewal(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		renewal    *Renewal
+		expiration time.Duration
+		expected   bool
+	}{
+		{
+			desc:       "default renewal, far from the expiration",
+			expiration: 60 * 24 * time.Hour,
+		},
+		{
+			desc:       "default renewal, close to the expiration",
+			expiration: 20 * 24 * time.Hour,
+			expected:   true,
+		},
+		{
+			desc:       "short-lived certificate, far from the expiration",
+			renewal:    &Renewal{LifetimeRatio: 0.3},
+			expiration: 24 * time.Hour,
+		},
+		{
+			desc:       "short-lived certificate, close to the expiration",
+			renewal:    &Renewal{Before: ptypes.Duration(2 * time.Hour)},
+			expiration: time.Hour,
+			expected:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			certPEM, keyPEM, err := generate.KeyPair("foo.com", time.Now().Add(test.expiration))
+			require.NoError(t, err)
+
+			p := &Provider{Configuration: &Configuration{Renewal: test.renewal}}
+
+			assert.Equal(t, test.expected, p.needsRenewal(context.Background(), &Certificate{Certificate: certPEM, Key: keyPEM}))
+		})
+	}
+}
+
+func TestProvider_nextRenewalCheck(t *testing.T) {
+	certPEM, keyPEM, err := generate.KeyPair("foo.com", time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc        string
+		renewal     *Renewal
+		certificate bool
+		failures    int
+		expectedMin time.Duration
+		expectedMax time.Duration
+	}{
+		{
+			desc:        "check interval",
+			renewal:     &Renewal{CheckInterval: ptypes.Duration(24 * time.Hour)},
+			expectedMin: 24 * time.Hour,
+			expectedMax: 24 * time.Hour,
+		},
+		{
+			desc:        "jitter",
+			renewal:     &Renewal{CheckInterval: ptypes.Duration(24 * time.Hour), Jitter: ptypes.Duration(time.Hour)},
+			expectedMin: 24 * time.Hour,
+			expectedMax: 25 * time.Hour,
+		},
+		{
+			desc:        "certificate to renew before the check interval",
+			renewal:     &Renewal{Before: ptypes.Duration(time.Hour), CheckInterval: ptypes.Duration(24 * time.Hour)},
+			certificate: true,
+			expectedMin: 59 * time.Minute,
+			expectedMax: time.Hour,
+		},
+		{
+			desc:        "certificate to renew now",
+			renewal:     &Renewal{Before: ptypes.Duration(3 * time.Hour), CheckInterval: ptypes.Duration(24 * time.Hour), Jitter: ptypes.Duration(time.Hour)},
+			certificate: true,
+			expectedMin: minRenewalCheckInterval,
+			expectedMax: minRenewalCheckInterval * 3 / 2,
+		},
+		{
+			desc:        "certificate to renew in error",
+			renewal:     &Renewal{Before: ptypes.Duration(3 * time.Hour), CheckInterval: ptypes.Duration(24 * time.Hour)},
+			certificate: true,
+			failures:    3,
+			expectedMin: 19 * time.Minute,
+			expectedMax: 20 * time.Minute,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Provider{Configuration: &Configuration{Renewal: test.renewal}}
+			if test.certificate {
+				p.certificates = []*CertAndStore{{Certificate: Certificate{Certificate: certPEM, Key: keyPEM}}}
+			}
+
+			for i := 0; i < test.failures; i++ {
+				p.recordRenewal(p.certificates[0], time.Now(), errors.New("boom"))
+			}
+
+			delay := p.nextRenewalCheck(context.Background())
+			assert.GreaterOrEqual(t, int64(delay), int64(test.expectedMin))
+			assert.LessOrEqual(t, int64(delay), int64(test.expectedMax))
+		})
+	}
+}
+
+func TestRenewalBackoff(t *testing.T) {
+	assert.Equal(t, minRenewalCheckInterval, renewalBackoff(1))
+	assert.Equal(t, 2*minRenewalCheckInterval, renewalBackoff(2))
+	assert.Equal(t, 8*minRenewalCheckInterval, renewalBackoff(4))
+	assert.Equal(t, maxRenewalBackoff, renewalBackoff(100))
+}
+
+func TestProvider_CertificatesStatus(t *testing.T) {
+	expiration := time.Now().Add(60 * 24 * time.Hour).Truncate(time.Second)
+
+	certPEM, keyPEM, err := generate.KeyPair("foo.com", expiration)
+	require.NoError(t, err)
+
+	foo := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}, Certificate: certPEM, Key: keyPEM}, Store: "default"}
+	bar := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "bar.com"}, Certificate: []byte("invalid")}, Store: "default"}
+
+	p := &Provider{
+		Configuration: &Configuration{},
+		ResolverName:  "myresolver",
+		certificates:  []*CertAndStore{foo, bar},
+	}
+
+	attempt := time.Now()
+	p.recordRenewal(bar, attempt.Add(-time.Hour), nil)
+	p.recordRenewal(bar, attempt, errors.New("boom"))
+
+	statuses := p.CertificatesStatus(context.Background())
+	require.Len(t, statuses, 2)
+
+	assert.Equal(t, "myresolver", statuses[0].Resolver)
+	assert.Equal(t, types.Domain{Main: "bar.com"}, statuses[0].Domain)
+	assert.Nil(t, statuses[0].NotAfter)
+	require.NotNil(t, statuses[0].LastRenewal)
+	assert.Equal(t, attempt.Add(-time.Hour), *statuses[0].LastRenewal)
+	require.NotNil(t, statuses[0].LastRenewalAttempt)
+	assert.Equal(t, attempt, *statuses[0].LastRenewalAttempt)
+	assert.Equal(t, "boom", statuses[0].LastRenewalError)
+
+	assert.Equal(t, types.Domain{Main: "foo.com"}, statuses[1].Domain)
+	require.NotNil(t, statuses[1].NotAfter)
+	assert.True(t, expiration.Equal(*statuses[1].NotAfter))
+	require.NotNil(t, statuses[1].RenewAt)
+	assert.True(t, expiration.Add(-30*24*time.Hour).Equal(*statuses[1].RenewAt))
+	assert.Nil(t, statuses[1].LastRenewalAttempt)
+}
@@ -0,0 +1,275 @@
+package acme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/safe"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// revokedCheckInterval is the interval at which the certificates revoked by another instance sharing the store are dropped.
+const revokedCheckInterval = time.Minute
+
+var (
+	// ErrCertificateNotFound is returned when no certificate of the resolver matches the given domains.
+	ErrCertificateNotFound = errors.New("certificate not found")
+
+	// ErrDomainsRequired is returned when the certificates to revoke are neither selected by domain nor all selected.
+	ErrDomainsRequired = errors.New("domains required to select the certificates to revoke, unless all of them are")
+)
+
+// RevokeCertificates revokes the certificates of the resolver for the given domains, or all of them if all is true,
+// and removes them from the store and from the TLS configuration.
+// If renew is true, new certificates are ordered right after, in the background.
+// It returns the domains of the revoked certificates.
+func (p *Provider) RevokeCertificates(ctx context.Context, domains []string, all, renew bool) ([]types.Domain, error) {
+	// A revocation cannot be undone, so revoking all the certificates must be explicit.
+	if len(domains) == 0 && !all {
+		return nil, ErrDomainsRequired
+	}
+
+	ctx = log.With(ctx, log.Str(log.ProviderName, p.ResolverName+".acme"))
+	logger := log.FromContext(ctx)
+
+	certificates := p.getCertificates()
+	if !all {
+		certificates = p.matchCertificates(domains)
+	}
+	if len(certificates) == 0 {
+		return nil, ErrCertificateNotFound
+	}
+
+	client, err := p.getClient()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get ACME client: %w", err)
+	}
+
+	var revoked []*CertAndStore
+	for _, cert := range certificates {
+		logger.Infof("Revoking certificate for domains %v", cert.Domain.ToStrArray())
+
+		if err = client.Certificate.Revoke(cert.Certificate.Certificate); err != nil {
+			err = fmt.Errorf("unable to revoke the certificate for domains %v: %w", cert.Domain.ToStrArray(), err)
+			break
+		}
+
+		revoked = append(revoked, cert)
+	}
+
+	p.removeCertificates(ctx, revoked)
+
+	if renew {
+		p.renewInBackground(revoked)
+	}
+
+	var revokedDomains []types.Domain
+	for _, cert := range revoked {
+		revokedDomains = append(revokedDomains, cert.Domain)
+	}
+
+	return revokedDomains, err
+}
+
+// RenewCertificates orders new certificates for the given domains (all of them if none is given) right away,
+// in the background, regardless of their renewal window.
+// It returns the domains of the certificates to be renewed.
+func (p *Provider) RenewCertificates(_ context.Context, domains []string) ([]types.Domain, error) {
+	certificates := p.matchCertificates(domains)
+	if len(certificates) == 0 {
+		return nil, ErrCertificateNotFound
+	}
+
+	p.renewInBackground(certificates)
+
+	var renewedDomains []types.Domain
+	for _, cert := range certificates {
+		renewedDomains = append(renewedDomains, cert.Domain)
+	}
+
+	return renewedDomains, nil
+}
+
+// renewInBackground orders new certificates replacing the given ones, one after the other.
+func (p *Provider) renewInBackground(certificates []*CertAndStore) {
+	if len(certificates) == 0 {
+		return
+	}
+
+	ctx := log.With(context.Background(), log.Str(log.ProviderName, p.ResolverName+".acme"))
+
+	safe.Go(func() {
+		for _, cert := range certificates {
+			p.forceRenewal(ctx, cert)
+		}
+	})
+}
+
+// forceRenewal orders a new certificate, with a new private key, replacing the given one.
+func (p *Provider) forceRenewal(ctx context.Context, cert *CertAndStore) {
+	attempt := time.Now()
+
+	err := p.orderLockedCertificate(ctx, cert.Domain, cert.Store, cert.KeyType)
+	if err != nil {
+		log.FromContext(ctx).Errorf("Error renewing certificate from LE: %v, %v", cert.Domain, err)
+	}
+
+	p.recordRenewal(cert, attempt, err)
+}
+
+func (p *Provider) orderLockedCertificate(ctx context.Context, domain types.Domain, tlsStore, keyType string) error {
+	unlock, err := p.lock(certificateLockName(domain))
+	if err != nil {
+		return fmt.Errorf("unable to lock the domains: %w", err)
+	}
+	defer unlock()
+
+	_, err = p.orderCertificate(ctx, domain.ToStrArray(), domain, tlsStore, keyType)
+	return err
+}
+
+// matchCertificates returns the certificates of the resolver including one of the given domains,
+// all of them if none is given.
+func (p *Provider) matchCertificates(domains []string) []*CertAndStore {
+	certificates := p.getCertificates()
+	if len(domains) == 0 {
+		return certificates
+	}
+
+	var matched []*CertAndStore
+	for _, cert := range certificates {
+		if includesDomain(cert.Domain, domains) {
+			matched = append(matched, cert)
+		}
+	}
+
+	return matched
+}
+
+func includesDomain(certDomain types.Domain, domains []string) bool {
+	for _, certDomainName := range certDomain.ToStrArray() {
+		for _, domain := range domains {
+			if strings.EqualFold(certDomainName, types.CanonicalDomain(domain)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// removeCertificates removes the given certificates from the resolver, its store, and the TLS configuration.
+func (p *Provider) removeCertificates(ctx context.Context, certificates []*CertAndStore) {
+	if len(certificates) == 0 {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+
+	p.certificatesMu.Lock()
+	var kept []*CertAndStore
+	for _, cert := range p.certificates {
+		if !containsCertificate(certificates, cert) {
+			kept = append(kept, cert)
+		}
+	}
+	p.certificates = kept
+	p.certificatesMu.Unlock()
+
+	if remover, ok := p.Store.(CertificateRemover); ok {
+		for _, cert := range certificates {
+			if err := p.removeSharedCertificate(remover, cert); err != nil {
+				logger.Errorf("Unable to remove the certificate for domains %v from the store: %v", cert.Domain.ToStrArray(), err)
+			}
+		}
+	}
+
+	if err := p.saveCertificates(); err != nil {
+		logger.Error(err)
+	}
+}
+
+// removeSharedCertificate removes the certificate from the shared store, holding the lock of its domains
+// for another instance not to store it again in the meantime.
+func (p *Provider) removeSharedCertificate(remover CertificateRemover, cert *CertAndStore) error {
+	unlock, err := p.lock(certificateLockName(cert.Domain))
+	if err != nil {
+		return fmt.Errorf("unable to lock the domains: %w", err)
+	}
+	defer unlock()
+
+	return remover.RemoveCertificate(p.ResolverName, cert)
+}
+
+// dropRevokedCertificates drops the certificates revoked by another instance sharing the store,
+// replacing them by the ones obtained since, if any.
+func (p *Provider) dropRevokedCertificates(ctx context.Context) {
+	remover, ok := p.Store.(CertificateRemover)
+	if !ok {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+
+	revoked, err := remover.RevokedCertificates(p.ResolverName, p.getCertificates())
+	if err != nil {
+		logger.Errorf("Unable to get the revoked ACME certificates: %v", err)
+		return
+	}
+	if len(revoked) == 0 {
+		return
+	}
+
+	shared, err := p.Store.GetCertificates(p.ResolverName)
+	if err != nil {
+		logger.Errorf("Unable to get the shared ACME certificates: %v", err)
+		return
+	}
+
+	p.certificatesMu.Lock()
+	var kept []*CertAndStore
+	for _, cert := range p.certificates {
+		if !isRevoked(revoked, cert) {
+			kept = append(kept, cert)
+			continue
+		}
+
+		logger.Infof("Dropping the certificate for domains %v revoked by another instance", cert.Domain.ToStrArray())
+
+		for _, sharedCert := range shared {
+			if containsCertificate([]*CertAndStore{sharedCert}, cert) {
+				kept = append(kept, sharedCert)
+				break
+			}
+		}
+	}
+	p.certificates = kept
+	p.certificatesMu.Unlock()
+
+	p.refreshCertificates()
+}
+
+func isRevoked(revoked []*CertAndStore, cert *CertAndStore) bool {
+	for _, c := range revoked {
+		if c == cert {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsCertificate(certificates []*CertAndStore, cert *CertAndStore) bool {
+	for _, c := range certificates {
+		if c.Store == cert.Store && c.KeyType == cert.KeyType && reflect.DeepEqual(c.Domain, cert.Domain) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package acme
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestProvider_matchCertificates(t *testing.T) {
+	foo := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "foo.com", SANs: []string{"www.foo.com"}}}, Store: "default"}
+	bar := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "bar.com"}}, Store: "default"}
+
+	testCases := []struct {
+		desc     string
+		domains  []string
+		expected []*CertAndStore
+	}{
+		{
+			desc:     "all the certificates",
+			expected: []*CertAndStore{foo, bar},
+		},
+		{
+			desc:     "main domain",
+			domains:  []string{"bar.com"},
+			expected: []*CertAndStore{bar},
+		},
+		{
+			desc:     "SAN",
+			domains:  []string{"WWW.foo.com"},
+			expected: []*CertAndStore{foo},
+		},
+		{
+			desc:     "several domains",
+			domains:  []string{"foo.com", "bar.com"},
+			expected: []*CertAndStore{foo, bar},
+		},
+		{
+			desc:    "unknown domain",
+			domains: []string{"sub.foo.com"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Provider{certificates: []*CertAndStore{foo, bar}}
+
+			assert.Equal(t, test.expected, p.matchCertificates(test.domains))
+		})
+	}
+}
+
+func TestProvider_removeCertificates(t *testing.T) {
+	foo := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}, Certificate: []byte("foo"), Key: []byte("key")}, Store: "default"}
+	bar := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "bar.com"}, Certificate: []byte("bar"), Key: []byte("key")}, Store: "default"}
+
+	store := newKVStore(newKVClientMock(), "traefik/acme", 30*time.Second)
+	require.NoError(t, store.SaveCertificates("myresolver", []*CertAndStore{foo, bar}))
+
+	configurationChan := make(chan dynamic.Message, 1)
+	p := &Provider{
+		ResolverName:      "myresolver",
+		Store:             store,
+		certificates:      []*CertAndStore{foo, bar},
+		configurationChan: configurationChan,
+	}
+
+	p.removeCertificates(context.Background(), []*CertAndStore{foo})
+
+	assert.Equal(t, []*CertAndStore{bar}, p.getCertificates())
+
+	stored, err := store.GetCertificates("myresolver")
+	require.NoError(t, err)
+	assert.Equal(t, []*CertAndStore{bar}, stored)
+
+	msg := <-configurationChan
+	assert.Len(t, msg.Configuration.TLS.Certificates, 1)
+}
+
+func TestProvider_dropRevokedCertificates(t *testing.T) {
+	foo := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}, Certificate: []byte("foo"), Key: []byte("key")}, Store: "default"}
+	bar := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "bar.com"}, Certificate: []byte("bar"), Key: []byte("key")}, Store: "default"}
+	baz := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "baz.com"}, Certificate: []byte("baz"), Key: []byte("key")}, Store: "default"}
+	renewedBar := &CertAndStore{Certificate: Certificate{Domain: types.Domain{Main: "bar.com"}, Certificate: []byte("renewed"), Key: []byte("key")}, Store: "default"}
+
+	// Two instances sharing the same KV store.
+	client := newKVClientMock()
+	store := newKVStore(client, "traefik/acme", 30*time.Second)
+	require.NoError(t, store.SaveCertificates("myresolver", []*CertAndStore{foo, bar, baz}))
+
+	configurationChan := make(chan dynamic.Message, 1)
+	p := &Provider{
+		ResolverName:      "myresolver",
+		Store:             store,
+		certificates:      []*CertAndStore{foo, bar, baz},
+		configurationChan: configurationChan,
+	}
+
+	other := newKVStore(client, "traefik/acme", 30*time.Second)
+	require.NoError(t, other.RemoveCertificate("myresolver", foo))
+	require.NoError(t, other.RemoveCertificate("myresolver", bar))
+	require.NoError(t, other.SaveCertificates("myresolver", []*CertAndStore{renewedBar}))
+
+	p.dropRevokedCertificates(context.Background())
+
+	assert.Equal(t, []*CertAndStore{renewedBar, baz}, p.getCertificates())
+
+	msg := <-configurationChan
+	assert.Len(t, msg.Configuration.TLS.Certificates, 2)
+
+	// The revoked certificates are not stored again.
+	require.NoError(t, p.saveCertificates())
+
+	stored, err := store.GetCertificates("myresolver")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*CertAndStore{renewedBar, baz}, stored)
+}
+
+func TestProvider_RenewCertificates_notFound(t *testing.T) {
+	p := &Provider{certificates: []*CertAndStore{{Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}}}}}
+
+	_, err := p.RenewCertificates(context.Background(), []string{"bar.com"})
+	assert.ErrorIs(t, err, ErrCertificateNotFound)
+
+	_, err = p.RevokeCertificates(context.Background(), []string{"bar.com"}, false, true)
+	assert.ErrorIs(t, err, ErrCertificateNotFound)
+}
+
+func TestProvider_RevokeCertificates_domainsRequired(t *testing.T) {
+	p := &Provider{certificates: []*CertAndStore{{Certificate: Certificate{Domain: types.Domain{Main: "foo.com"}}}}}
+
+	_, err := p.RevokeCertificates(context.Background(), nil, false, false)
+	assert.ErrorIs(t, err, ErrDomainsRequired)
+}
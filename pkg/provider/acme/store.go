@@ -2,8 +2,9 @@ package acme
 
 // StoredData represents the data managed by Store.
 type StoredData struct {
-	Account      *Account
-	Certificates []*CertAndStore
+	Account           *Account
+	Certificates      []*CertAndStore
+	ClientCertificate *Certificate `json:",omitempty"`
 }
 
 // Store is a generic interface that represents a storage.
@@ -13,3 +14,45 @@ type Store interface {
 	GetCertificates(string) ([]*CertAndStore, error)
 	SaveCertificates(string, []*CertAndStore) error
 }
+
+// ChallengeStore is implemented by the stores shared by several Traefik instances,
+// for the HTTP-01 and TLS-ALPN-01 challenges presented by one of them to be served by all of them.
+type ChallengeStore interface {
+	SetHTTPChallenge(token, domain string, keyAuth []byte) error
+	GetHTTPChallenge(token, domain string) ([]byte, error)
+	RemoveHTTPChallenge(token, domain string) error
+
+	SetTLSChallenge(domain string, cert *Certificate) error
+	GetTLSChallenges() (map[string]*Certificate, error)
+	RemoveTLSChallenge(domain string) error
+	// WatchTLSChallenges notifies the changes of the TLS-ALPN-01 challenges until the stop channel is closed,
+	// the returned channel being closed when the watch ends.
+	WatchTLSChallenges(stopCh <-chan struct{}) (<-chan struct{}, error)
+}
+
+// Locker is implemented by the stores shared by several Traefik instances,
+// for a single one of them to register an account or to order a certificate at a time.
+// The stores implementing it keep the certificates not given to SaveCertificates,
+// which may have been obtained by the other instances.
+type Locker interface {
+	// Lock acquires the lock of the given name for the resolver, waiting for it to be released by the other instances.
+	// The returned function releases it.
+	Lock(resolverName, name string) (unlock func(), err error)
+}
+
+// ClientCertificateStore is implemented by the stores keeping the client certificate obtained by the resolver,
+// for it to be reused while it is valid, instead of being ordered again on each start.
+type ClientCertificateStore interface {
+	GetClientCertificate(resolverName string) (*Certificate, error)
+	SaveClientCertificate(resolverName string, cert *Certificate) error
+}
+
+// CertificateRemover is implemented by the stores keeping the certificates not given to SaveCertificates,
+// for the revoked certificates to be removed from them, and dropped by the other instances.
+type CertificateRemover interface {
+	// RemoveCertificate removes the certificate, and marks it as revoked.
+	// The revoked certificates are neither returned by GetCertificates nor stored again by SaveCertificates.
+	RemoveCertificate(resolverName string, certificate *CertAndStore) error
+	// RevokedCertificates returns the given certificates which have been revoked by any instance.
+	RevokedCertificates(resolverName string, certificates []*CertAndStore) ([]*CertAndStore, error)
+}
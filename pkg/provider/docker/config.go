@@ -281,11 +281,20 @@ func (p *Provider) getIPPort(ctx context.Context, container dockerData, serverPo
 	var ip, port string
 	usedBound := false
 
-	if p.UseBindPortIP {
+	// The containers of a remote Docker host are reached through the ports they publish on that host.
+	hostIP := container.Endpoint.getHostIP()
+
+	if p.UseBindPortIP || hostIP != "" {
 		portBinding, err := p.getPortBinding(container, serverPort)
 		switch {
 		case err != nil:
-			logger.Infof("Unable to find a binding for container %q, falling back on its internal IP/Port.", container.Name)
+			if p.UseBindPortIP {
+				logger.Infof("Unable to find a binding for container %q, falling back on its internal IP/Port.", container.Name)
+			}
+		case (portBinding.HostIP == "0.0.0.0" || len(portBinding.HostIP) == 0) && hostIP != "":
+			ip = hostIP
+			port = portBinding.HostPort
+			usedBound = true
 		case portBinding.HostIP == "0.0.0.0" || len(portBinding.HostIP) == 0:
 			logger.Infof("Cannot determine the IP address (got %q) for %q's binding, falling back on its internal IP/Port.", portBinding.HostIP, container.Name)
 		default:
@@ -323,6 +332,9 @@ func (p Provider) getIPAddress(ctx context.Context, container dockerData) string
 	}
 
 	if container.NetworkSettings.NetworkMode.IsHost() {
+		if hostIP := container.Endpoint.getHostIP(); hostIP != "" {
+			return hostIP
+		}
 		if container.Node != nil && container.Node.IPAddress != "" {
 			return container.Node.IPAddress
 		}
@@ -333,7 +345,7 @@ func (p Provider) getIPAddress(ctx context.Context, container dockerData) string
 	}
 
 	if container.NetworkSettings.NetworkMode.IsContainer() {
-		dockerClient, err := p.createClient()
+		dockerClient, err := p.createClient(container.Endpoint)
 		if err != nil {
 			logger.Warnf("Unable to get IP address: %s", err)
 			return ""
@@ -349,6 +361,7 @@ func (p Provider) getIPAddress(ctx context.Context, container dockerData) string
 		// Check connected container for traefik.docker.network, falling back to
 		// the network specified on the current container.
 		containerParsed := parseContainer(containerInspected)
+		containerParsed.Endpoint = container.Endpoint
 		extraConf, err := p.getConfiguration(containerParsed)
 		if err != nil {
 			logger.Warnf("Unable to get IP address for container %s : failed to get extra configuration for container %s: %s", container.Name, containerInspected.Name, err)
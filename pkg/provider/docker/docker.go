@@ -51,6 +51,7 @@ type Provider struct {
 	Constraints             string           `description:"Constraints is an expression that Traefik matches against the container's labels to determine whether to create any route for that container." json:"constraints,omitempty" toml:"constraints,omitempty" yaml:"constraints,omitempty" export:"true"`
 	Watch                   bool             `description:"Watch Docker Swarm events." json:"watch,omitempty" toml:"watch,omitempty" yaml:"watch,omitempty" export:"true"`
 	Endpoint                string           `description:"Docker server endpoint. Can be a tcp or a unix socket endpoint." json:"endpoint,omitempty" toml:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Endpoints               []*Endpoint      `description:"Docker server endpoints aggregated by the provider. Takes precedence over endpoint and tls." json:"endpoints,omitempty" toml:"endpoints,omitempty" yaml:"endpoints,omitempty" export:"true"`
 	DefaultRule             string           `description:"Default rule." json:"defaultRule,omitempty" toml:"defaultRule,omitempty" yaml:"defaultRule,omitempty"`
 	TLS                     *types.ClientTLS `description:"Enable Docker TLS support." json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
 	ExposedByDefault        bool             `description:"Expose containers by default." json:"exposedByDefault,omitempty" toml:"exposedByDefault,omitempty" yaml:"exposedByDefault,omitempty" export:"true"`
@@ -80,9 +81,41 @@ func (p *Provider) Init() error {
 	}
 
 	p.defaultRuleTpl = defaultRuleTpl
+
+	names := make(map[string]struct{})
+	for _, endpoint := range p.Endpoints {
+		if endpoint == nil || endpoint.Address == "" {
+			return errors.New("endpoint address is required")
+		}
+
+		if _, ok := names[endpoint.getName()]; ok {
+			return fmt.Errorf("duplicate endpoint name: %s", endpoint.getName())
+		}
+		names[endpoint.getName()] = struct{}{}
+	}
+
 	return nil
 }
 
+// getEndpoints returns the Docker hosts watched by the provider.
+func (p *Provider) getEndpoints() []*Endpoint {
+	if len(p.Endpoints) > 0 {
+		return p.Endpoints
+	}
+
+	return []*Endpoint{p.defaultEndpoint()}
+}
+
+// defaultEndpoint returns the endpoint defined by the Endpoint and TLS options.
+// The published ports of its containers are only used when UseBindPortIP is enabled.
+func (p *Provider) defaultEndpoint() *Endpoint {
+	return &Endpoint{
+		Name:    "default",
+		Address: p.Endpoint,
+		TLS:     p.TLS,
+	}
+}
+
 // dockerData holds the need data to the provider.
 type dockerData struct {
 	ID              string
@@ -93,6 +126,8 @@ type dockerData struct {
 	Health          string
 	Node            *dockertypes.ContainerNode
 	ExtraConf       configuration
+	// Endpoint is the Docker host the container runs on, nil for the default one.
+	Endpoint *Endpoint
 }
 
 // NetworkSettings holds the networks data to the provider.
@@ -111,8 +146,8 @@ type networkData struct {
 	ID       string
 }
 
-func (p *Provider) createClient() (client.APIClient, error) {
-	opts, err := p.getClientOpts()
+func (p *Provider) createClient(endpoint *Endpoint) (client.APIClient, error) {
+	opts, err := p.getClientOpts(endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -131,8 +166,12 @@ func (p *Provider) createClient() (client.APIClient, error) {
 	return client.NewClientWithOpts(opts...)
 }
 
-func (p *Provider) getClientOpts() ([]client.Opt, error) {
-	helper, err := connhelper.GetConnectionHelper(p.Endpoint)
+func (p *Provider) getClientOpts(endpoint *Endpoint) ([]client.Opt, error) {
+	if endpoint == nil {
+		endpoint = p.defaultEndpoint()
+	}
+
+	helper, err := connhelper.GetConnectionHelper(endpoint.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -156,19 +195,19 @@ func (p *Provider) getClientOpts() ([]client.Opt, error) {
 	}
 
 	opts := []client.Opt{
-		client.WithHost(p.Endpoint),
+		client.WithHost(endpoint.Address),
 		client.WithTimeout(time.Duration(p.HTTPClientTimeout)),
 	}
 
-	if p.TLS != nil {
+	if endpoint.TLS != nil {
 		ctx := log.With(context.Background(), log.Str(log.ProviderName, "docker"))
 
-		conf, err := p.TLS.CreateTLSConfig(ctx)
+		conf, err := endpoint.TLS.CreateTLSConfig(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		hostURL, err := client.ParseHostURL(p.Endpoint)
+		hostURL, err := client.ParseHostURL(endpoint.Address)
 		if err != nil {
 			return nil, err
 		}
@@ -189,151 +228,188 @@ func (p *Provider) getClientOpts() ([]client.Opt, error) {
 
 // Provide allows the docker provider to provide configurations to traefik using the given configuration channel.
 func (p *Provider) Provide(configurationChan chan<- dynamic.Message, pool *safe.Pool) error {
-	pool.GoCtx(func(routineCtx context.Context) {
-		ctxLog := log.With(routineCtx, log.Str(log.ProviderName, "docker"))
-		logger := log.FromContext(ctxLog)
+	endpoints := p.getEndpoints()
+	states := newEndpointsState(endpoints)
+
+	for _, endpoint := range endpoints {
+		endpoint := endpoint
+		pool.GoCtx(func(routineCtx context.Context) {
+			p.watchEndpoint(routineCtx, endpoint, states, configurationChan, pool)
+		})
+	}
 
-		operation := func() error {
-			var err error
-			ctx, cancel := context.WithCancel(ctxLog)
-			defer cancel()
+	return nil
+}
 
-			ctx = log.With(ctx, log.Str(log.ProviderName, "docker"))
+// watchEndpoint lists the containers (or services) of a Docker host and watches their changes.
+func (p *Provider) watchEndpoint(routineCtx context.Context, endpoint *Endpoint, states *endpointsState, configurationChan chan<- dynamic.Message, pool *safe.Pool) {
+	ctxLog := log.With(routineCtx, log.Str(log.ProviderName, "docker"))
+	if len(p.Endpoints) > 0 {
+		ctxLog = log.With(ctxLog, log.Str("dockerEndpoint", endpoint.getName()))
+	}
+	logger := log.FromContext(ctxLog)
 
-			dockerClient, err := p.createClient()
-			if err != nil {
-				logger.Errorf("Failed to create a client for docker, error: %s", err)
-				return err
-			}
+	// The containers of the default endpoint are not bound to it,
+	// so that their published ports are only used when UseBindPortIP is enabled.
+	var dataEndpoint *Endpoint
+	if len(p.Endpoints) > 0 {
+		dataEndpoint = endpoint
+	}
 
-			serverVersion, err := dockerClient.ServerVersion(ctx)
-			if err != nil {
-				logger.Errorf("Failed to retrieve information of the docker client and server host: %s", err)
-				return err
-			}
-			logger.Debugf("Provider connection established with docker %s (API %s)", serverVersion.Version, serverVersion.APIVersion)
-			var dockerDataList []dockerData
+	list := func(ctx context.Context, dockerClient client.APIClient) ([]dockerData, error) {
+		var dockerDataList []dockerData
+		var err error
+		if p.SwarmMode {
+			dockerDataList, err = p.listServices(ctx, dockerClient)
+		} else {
+			dockerDataList, err = p.listContainers(ctx, dockerClient)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range dockerDataList {
+			dockerDataList[i].Endpoint = dataEndpoint
+		}
+		return dockerDataList, nil
+	}
+
+	// When several endpoints are aggregated, the containers of an unreachable host are removed
+	// from the configuration until it is back, so that the other hosts keep serving the traffic.
+	setUnreachable := func(err error) {
+		if len(p.Endpoints) == 0 {
+			return
+		}
+
+		if states.setUnreachable(endpoint.getName(), err) {
+			logger.Warnf("Docker endpoint %s is unreachable, removing its containers: %v", endpoint.getName(), err)
+			p.publish(ctxLog, states, configurationChan)
+		}
+	}
+
+	operation := func() error {
+		var err error
+		ctx, cancel := context.WithCancel(ctxLog)
+		defer cancel()
+
+		dockerClient, err := p.createClient(dataEndpoint)
+		if err != nil {
+			logger.Errorf("Failed to create a client for docker, error: %s", err)
+			return err
+		}
+
+		serverVersion, err := dockerClient.ServerVersion(ctx)
+		if err != nil {
+			logger.Errorf("Failed to retrieve information of the docker client and server host: %s", err)
+			setUnreachable(err)
+			return err
+		}
+		logger.Debugf("Provider connection established with docker %s (API %s)", serverVersion.Version, serverVersion.APIVersion)
+
+		dockerDataList, err := list(ctx, dockerClient)
+		if err != nil {
 			if p.SwarmMode {
-				dockerDataList, err = p.listServices(ctx, dockerClient)
-				if err != nil {
-					logger.Errorf("Failed to list services for docker swarm mode, error %s", err)
-					return err
-				}
+				logger.Errorf("Failed to list services for docker swarm mode, error %s", err)
 			} else {
-				dockerDataList, err = p.listContainers(ctx, dockerClient)
-				if err != nil {
-					logger.Errorf("Failed to list containers for docker, error %s", err)
-					return err
-				}
+				logger.Errorf("Failed to list containers for docker, error %s", err)
 			}
+			setUnreachable(err)
+			return err
+		}
 
-			configuration := p.buildConfiguration(ctxLog, dockerDataList)
-			configurationChan <- dynamic.Message{
-				ProviderName:  "docker",
-				Configuration: configuration,
-			}
-			if p.Watch {
-				if p.SwarmMode {
-					errChan := make(chan error)
-
-					// TODO: This need to be change. Linked to Swarm events docker/docker#23827
-					ticker := time.NewTicker(time.Duration(p.SwarmModeRefreshSeconds))
-
-					pool.GoCtx(func(ctx context.Context) {
-						ctx = log.With(ctx, log.Str(log.ProviderName, "docker"))
-						logger := log.FromContext(ctx)
-
-						defer close(errChan)
-						for {
-							select {
-							case <-ticker.C:
-								services, err := p.listServices(ctx, dockerClient)
-								if err != nil {
-									logger.Errorf("Failed to list services for docker, error %s", err)
-									errChan <- err
-									return
-								}
-
-								configuration := p.buildConfiguration(ctx, services)
-								if configuration != nil {
-									configurationChan <- dynamic.Message{
-										ProviderName:  "docker",
-										Configuration: configuration,
-									}
-								}
-
-							case <-ctx.Done():
-								ticker.Stop()
-								return
-							}
-						}
-					})
-					if err, ok := <-errChan; ok {
-						return err
-					}
-					// channel closed
-				} else {
-					f := filters.NewArgs()
-					f.Add("type", "container")
-					options := dockertypes.EventsOptions{
-						Filters: f,
-					}
+		states.setContainers(ctx, endpoint.getName(), dockerDataList)
+		p.publish(ctxLog, states, configurationChan)
+
+		if !p.Watch {
+			return nil
+		}
+
+		if p.SwarmMode {
+			errChan := make(chan error)
+
+			// TODO: This need to be change. Linked to Swarm events docker/docker#23827
+			ticker := time.NewTicker(time.Duration(p.SwarmModeRefreshSeconds))
+
+			pool.GoCtx(func(ctx context.Context) {
+				ctx = log.With(ctx, log.Str(log.ProviderName, "docker"))
+				if len(p.Endpoints) > 0 {
+					ctx = log.With(ctx, log.Str("dockerEndpoint", endpoint.getName()))
+				}
+				logger := log.FromContext(ctx)
 
-					startStopHandle := func(m eventtypes.Message) {
-						logger.Debugf("Provider event received %+v", m)
-						containers, err := p.listContainers(ctx, dockerClient)
+				defer close(errChan)
+				for {
+					select {
+					case <-ticker.C:
+						services, err := list(ctx, dockerClient)
 						if err != nil {
-							logger.Errorf("Failed to list containers for docker, error %s", err)
-							// Call cancel to get out of the monitor
+							logger.Errorf("Failed to list services for docker, error %s", err)
+							errChan <- err
 							return
 						}
 
-						configuration := p.buildConfiguration(ctx, containers)
-						if configuration != nil {
-							message := dynamic.Message{
-								ProviderName:  "docker",
-								Configuration: configuration,
-							}
-							select {
-							case configurationChan <- message:
-							case <-ctx.Done():
-							}
-						}
-					}
+						states.setContainers(ctx, endpoint.getName(), services)
+						p.publish(ctx, states, configurationChan)
 
-					eventsc, errc := dockerClient.Events(ctx, options)
-					for {
-						select {
-						case event := <-eventsc:
-							if event.Action == "start" ||
-								event.Action == "die" ||
-								strings.HasPrefix(event.Action, "health_status") {
-								startStopHandle(event)
-							}
-						case err := <-errc:
-							if errors.Is(err, io.EOF) {
-								logger.Debug("Provider event stream closed")
-							}
-							return err
-						case <-ctx.Done():
-							return nil
-						}
+					case <-ctx.Done():
+						ticker.Stop()
+						return
 					}
 				}
+			})
+			if err, ok := <-errChan; ok {
+				return err
 			}
+			// channel closed
 			return nil
 		}
 
-		notify := func(err error, time time.Duration) {
-			logger.Errorf("Provider connection error %+v, retrying in %s", err, time)
+		f := filters.NewArgs()
+		f.Add("type", "container")
+		options := dockertypes.EventsOptions{
+			Filters: f,
 		}
-		err := backoff.RetryNotify(safe.OperationWithRecover(operation), backoff.WithContext(job.NewBackOff(backoff.NewExponentialBackOff()), ctxLog), notify)
-		if err != nil {
-			logger.Errorf("Cannot connect to docker server %+v", err)
+
+		startStopHandle := func(m eventtypes.Message) {
+			logger.Debugf("Provider event received %+v", m)
+			containers, err := list(ctx, dockerClient)
+			if err != nil {
+				logger.Errorf("Failed to list containers for docker, error %s", err)
+				// Call cancel to get out of the monitor
+				return
+			}
+
+			states.setContainers(ctx, endpoint.getName(), containers)
+			p.publish(ctx, states, configurationChan)
 		}
-	})
 
-	return nil
+		eventsc, errc := dockerClient.Events(ctx, options)
+		for {
+			select {
+			case event := <-eventsc:
+				if event.Action == "start" ||
+					event.Action == "die" ||
+					strings.HasPrefix(event.Action, "health_status") {
+					startStopHandle(event)
+				}
+			case err := <-errc:
+				if errors.Is(err, io.EOF) {
+					logger.Debug("Provider event stream closed")
+				}
+				return err
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+
+	notify := func(err error, time time.Duration) {
+		logger.Errorf("Provider connection error %+v, retrying in %s", err, time)
+	}
+	err := backoff.RetryNotify(safe.OperationWithRecover(operation), backoff.WithContext(job.NewBackOff(backoff.NewExponentialBackOff()), ctxLog), notify)
+	if err != nil {
+		logger.Errorf("Cannot connect to docker server %+v", err)
+	}
 }
 
 func (p *Provider) listContainers(ctx context.Context, dockerClient client.ContainerAPIClient) ([]dockerData, error) {
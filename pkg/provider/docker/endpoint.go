@@ -0,0 +1,144 @@
+package docker
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// Endpoint holds the configuration of one of the Docker hosts watched by the provider.
+type Endpoint struct {
+	Name    string           `description:"Name identifying the Docker host (defaults to the address)." json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty" export:"true"`
+	Address string           `description:"Docker server endpoint. Can be a tcp, ssh or a unix socket endpoint." json:"address,omitempty" toml:"address,omitempty" yaml:"address,omitempty"`
+	TLS     *types.ClientTLS `description:"Enable Docker TLS support." json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+	HostIP  string           `description:"IP address used to reach the ports published on this host (defaults to the host of the endpoint address)." json:"hostIP,omitempty" toml:"hostIP,omitempty" yaml:"hostIP,omitempty"`
+}
+
+// getName returns the name identifying the endpoint.
+func (e *Endpoint) getName() string {
+	if e.Name != "" {
+		return e.Name
+	}
+
+	return e.Address
+}
+
+// getHostIP returns the address used to reach the ports published on the endpoint host.
+// It is empty for local (unix socket) endpoints.
+func (e *Endpoint) getHostIP() string {
+	if e == nil {
+		return ""
+	}
+
+	if e.HostIP != "" {
+		return e.HostIP
+	}
+
+	u, err := url.Parse(e.Address)
+	if err != nil {
+		return ""
+	}
+
+	switch u.Scheme {
+	case "tcp", "http", "https", "ssh":
+		return u.Hostname()
+	default:
+		return ""
+	}
+}
+
+// endpointState holds the last known state of a Docker host.
+type endpointState struct {
+	reachable  bool
+	lastError  error
+	containers []dockerData
+}
+
+// endpointsState aggregates the containers listed on every Docker host watched by the provider.
+type endpointsState struct {
+	mu     sync.Mutex
+	names  []string
+	states map[string]*endpointState
+
+	// publishMu serializes the builds so that an older aggregate is never sent after a newer one.
+	publishMu sync.Mutex
+}
+
+func newEndpointsState(endpoints []*Endpoint) *endpointsState {
+	s := &endpointsState{states: make(map[string]*endpointState)}
+	for _, endpoint := range endpoints {
+		s.names = append(s.names, endpoint.getName())
+		s.states[endpoint.getName()] = &endpointState{}
+	}
+
+	return s
+}
+
+// setContainers marks the endpoint as reachable and stores its containers.
+func (s *endpointsState) setContainers(ctx context.Context, name string, containers []dockerData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.states[name]
+	if !state.reachable && state.lastError != nil {
+		log.FromContext(ctx).Infof("Docker endpoint %s is reachable again", name)
+	}
+
+	state.reachable = true
+	state.lastError = nil
+	state.containers = containers
+}
+
+// setUnreachable marks the endpoint as unreachable and forgets its containers.
+// It returns whether the state of the endpoint has changed.
+func (s *endpointsState) setUnreachable(name string, err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.states[name]
+	changed := state.reachable
+	state.reachable = false
+	state.lastError = err
+	state.containers = nil
+
+	return changed
+}
+
+// getContainers returns the containers of all the reachable endpoints, in the endpoints order.
+func (s *endpointsState) getContainers() []dockerData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var containers []dockerData
+	for _, name := range s.names {
+		state := s.states[name]
+		if state.reachable {
+			containers = append(containers, state.containers...)
+		}
+	}
+
+	return containers
+}
+
+// publish builds the configuration from the containers of all the reachable endpoints and sends it.
+func (p *Provider) publish(ctx context.Context, states *endpointsState, configurationChan chan<- dynamic.Message) {
+	states.publishMu.Lock()
+	defer states.publishMu.Unlock()
+
+	configuration := p.buildConfiguration(ctx, states.getContainers())
+	if configuration == nil {
+		return
+	}
+
+	select {
+	case configurationChan <- dynamic.Message{
+		ProviderName:  "docker",
+		Configuration: configuration,
+	}:
+	case <-ctx.Done():
+	}
+}
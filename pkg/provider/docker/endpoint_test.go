@@ -0,0 +1,210 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	docker "github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointGetHostIP(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		endpoint *Endpoint
+		expected string
+	}{
+		{
+			desc:     "nil endpoint",
+			expected: "",
+		},
+		{
+			desc:     "unix socket",
+			endpoint: &Endpoint{Address: "unix:///var/run/docker.sock"},
+			expected: "",
+		},
+		{
+			desc:     "tcp endpoint",
+			endpoint: &Endpoint{Address: "tcp://10.0.0.2:2376"},
+			expected: "10.0.0.2",
+		},
+		{
+			desc:     "ssh endpoint",
+			endpoint: &Endpoint{Address: "ssh://traefik@docker-2.example.com:22"},
+			expected: "docker-2.example.com",
+		},
+		{
+			desc:     "explicit host IP",
+			endpoint: &Endpoint{Address: "tcp://docker-2.example.com:2376", HostIP: "192.168.1.2"},
+			expected: "192.168.1.2",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.endpoint.getHostIP())
+		})
+	}
+}
+
+func TestInitEndpoints(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		endpoints []*Endpoint
+		expectErr bool
+	}{
+		{
+			desc: "no endpoints",
+		},
+		{
+			desc: "named endpoints",
+			endpoints: []*Endpoint{
+				{Name: "a", Address: "tcp://10.0.0.1:2376"},
+				{Name: "b", Address: "tcp://10.0.0.1:2376"},
+			},
+		},
+		{
+			desc: "missing address",
+			endpoints: []*Endpoint{
+				{Name: "a"},
+			},
+			expectErr: true,
+		},
+		{
+			desc: "duplicate names",
+			endpoints: []*Endpoint{
+				{Address: "tcp://10.0.0.1:2376"},
+				{Address: "tcp://10.0.0.1:2376"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := Provider{DefaultRule: DefaultTemplateRule, Endpoints: test.endpoints}
+
+			err := p.Init()
+			if test.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEndpointsState(t *testing.T) {
+	endpoints := []*Endpoint{
+		{Name: "a", Address: "tcp://10.0.0.1:2376"},
+		{Name: "b", Address: "tcp://10.0.0.2:2376"},
+	}
+
+	states := newEndpointsState(endpoints)
+	assert.Empty(t, states.getContainers())
+
+	states.setContainers(context.Background(), "b", []dockerData{{ID: "b1"}})
+	states.setContainers(context.Background(), "a", []dockerData{{ID: "a1"}, {ID: "a2"}})
+	assert.Equal(t, []dockerData{{ID: "a1"}, {ID: "a2"}, {ID: "b1"}}, states.getContainers())
+
+	assert.True(t, states.setUnreachable("a", errors.New("connection refused")))
+	assert.False(t, states.setUnreachable("a", errors.New("connection refused")))
+	assert.Equal(t, []dockerData{{ID: "b1"}}, states.getContainers())
+
+	states.setContainers(context.Background(), "a", []dockerData{{ID: "a3"}})
+	assert.Equal(t, []dockerData{{ID: "a3"}, {ID: "b1"}}, states.getContainers())
+}
+
+func TestDockerGetIPPortWithEndpoint(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		container    docker.ContainerJSON
+		endpoint     *Endpoint
+		serverPort   string
+		expectedIP   string
+		expectedPort string
+	}{
+		{
+			desc: "local endpoint, falling back on the container's IP/Port",
+			container: containerJSON(
+				ports(nat.PortMap{
+					"80/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "8080"}},
+				}),
+				withNetwork("testnet", ipv4("10.11.12.13"))),
+			endpoint:     &Endpoint{Address: "unix:///var/run/docker.sock"},
+			expectedIP:   "10.11.12.13",
+			expectedPort: "80",
+		},
+		{
+			desc: "remote endpoint, published port on all interfaces",
+			container: containerJSON(
+				ports(nat.PortMap{
+					"80/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "8080"}},
+				}),
+				withNetwork("testnet", ipv4("10.11.12.13"))),
+			endpoint:     &Endpoint{Address: "tcp://192.168.1.2:2376"},
+			expectedIP:   "192.168.1.2",
+			expectedPort: "8080",
+		},
+		{
+			desc: "remote endpoint, published port on a specific interface",
+			container: containerJSON(
+				ports(nat.PortMap{
+					"80/tcp": []nat.PortBinding{{HostIP: "192.168.1.3", HostPort: "8080"}},
+				}),
+				withNetwork("testnet", ipv4("10.11.12.13"))),
+			endpoint:     &Endpoint{Address: "tcp://192.168.1.2:2376"},
+			expectedIP:   "192.168.1.3",
+			expectedPort: "8080",
+		},
+		{
+			desc: "remote endpoint, no published port",
+			container: containerJSON(
+				ports(nat.PortMap{
+					"80/tcp": {},
+				}),
+				withNetwork("testnet", ipv4("10.11.12.13"))),
+			endpoint:     &Endpoint{Address: "tcp://192.168.1.2:2376"},
+			expectedIP:   "10.11.12.13",
+			expectedPort: "80",
+		},
+		{
+			desc: "remote endpoint, host network",
+			container: containerJSON(
+				networkMode("host"),
+				ports(nat.PortMap{
+					"80/tcp": {},
+				})),
+			endpoint:     &Endpoint{Address: "tcp://192.168.1.2:2376"},
+			expectedIP:   "192.168.1.2",
+			expectedPort: "80",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dData := parseContainer(test.container)
+			dData.Endpoint = test.endpoint
+
+			provider := &Provider{Network: "testnet"}
+
+			ip, port, err := provider.getIPPort(context.Background(), dData, "")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedIP, ip)
+			assert.Equal(t, test.expectedPort, port)
+		})
+	}
+}
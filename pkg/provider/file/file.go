@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
@@ -30,6 +33,14 @@ type Provider struct {
 	Watch                     bool   `description:"Watch provider." json:"watch,omitempty" toml:"watch,omitempty" yaml:"watch,omitempty" export:"true"`
 	Filename                  string `description:"Load dynamic configuration from a file." json:"filename,omitempty" toml:"filename,omitempty" yaml:"filename,omitempty" export:"true"`
 	DebugLogGeneratedTemplate bool   `description:"Enable debug logging of generated configuration template." json:"debugLogGeneratedTemplate,omitempty" toml:"debugLogGeneratedTemplate,omitempty" yaml:"debugLogGeneratedTemplate,omitempty" export:"true"`
+	EnvInterpolation          bool   `description:"Replace the ${VAR} and ${VAR:-default} references with the value of the environment variables." json:"envInterpolation,omitempty" toml:"envInterpolation,omitempty" yaml:"envInterpolation,omitempty" export:"true"`
+	SkipInvalidFiles          bool   `description:"Skip the invalid files of the directory, reporting their errors, instead of failing to load the whole directory." json:"skipInvalidFiles,omitempty" toml:"skipInvalidFiles,omitempty" yaml:"skipInvalidFiles,omitempty" export:"true"`
+
+	includedMu    sync.RWMutex
+	includedFiles map[string]struct{}
+
+	referencedMu    sync.RWMutex
+	referencedFiles map[string]struct{}
 }
 
 // SetDefaults sets the default values.
@@ -77,6 +88,9 @@ func (p *Provider) Provide(configurationChan chan<- dynamic.Message, pool *safe.
 func (p *Provider) BuildConfiguration() (*dynamic.Configuration, error) {
 	ctx := log.With(context.Background(), log.Str(log.ProviderName, providerName))
 
+	p.resetIncludedFiles()
+	p.resetReferencedFiles()
+
 	if len(p.Directory) > 0 {
 		return p.loadFileConfigFromDirectory(ctx, p.Directory, nil)
 	}
@@ -99,6 +113,8 @@ func (p *Provider) addWatcher(pool *safe.Pool, directory string, configurationCh
 		return fmt.Errorf("error adding file watcher: %w", err)
 	}
 
+	p.watchDirectories(watcher)
+
 	// Process events
 	pool.GoCtx(func(ctx context.Context) {
 		defer watcher.Close()
@@ -110,12 +126,14 @@ func (p *Provider) addWatcher(pool *safe.Pool, directory string, configurationCh
 				if p.Directory == "" {
 					_, evtFileName := filepath.Split(evt.Name)
 					_, confFileName := filepath.Split(p.Filename)
-					if evtFileName == confFileName {
+					if evtFileName == confFileName || p.isIncludedFile(evt.Name) || p.isReferencedDirectory(filepath.Dir(evt.Name)) {
 						callback(configurationChan, evt)
 					}
 				} else {
 					callback(configurationChan, evt)
 				}
+
+				p.watchDirectories(watcher)
 			case err := <-watcher.Errors:
 				log.WithoutContext().WithField(log.ProviderName, providerName).Errorf("Watcher event error: %s", err)
 			}
@@ -124,6 +142,21 @@ func (p *Provider) addWatcher(pool *safe.Pool, directory string, configurationCh
 	return nil
 }
 
+// watchDirectories adds the directories of the included and referenced files to the watcher.
+func (p *Provider) watchDirectories(watcher *fsnotify.Watcher) {
+	for _, dir := range p.includedDirectories() {
+		if err := watcher.Add(dir); err != nil {
+			log.WithoutContext().WithField(log.ProviderName, providerName).Errorf("Unable to watch included files directory %s: %v", dir, err)
+		}
+	}
+
+	for _, dir := range p.referencedDirectories() {
+		if err := watcher.Add(dir); err != nil {
+			log.WithoutContext().WithField(log.ProviderName, providerName).Errorf("Unable to watch referenced files directory %s: %v", dir, err)
+		}
+	}
+}
+
 func (p *Provider) watcherCallback(configurationChan chan<- dynamic.Message, event fsnotify.Event) {
 	watchItem := p.Filename
 	if len(p.Directory) > 0 {
@@ -154,27 +187,85 @@ func sendConfigToChannel(configurationChan chan<- dynamic.Message, configuration
 }
 
 func (p *Provider) loadFileConfig(ctx context.Context, filename string, parseTemplate bool) (*dynamic.Configuration, error) {
-	var err error
-	var configuration *dynamic.Configuration
+	return p.loadFileConfigWithIncludes(ctx, filename, parseTemplate, map[string]struct{}{})
+}
+
+// loadFileConfigWithIncludes loads the configuration of a file, merged with the configuration of the files it includes.
+// The loading holds the files being loaded, to detect the include cycles.
+func (p *Provider) loadFileConfigWithIncludes(ctx context.Context, filename string, parseTemplate bool, loading map[string]struct{}) (*dynamic.Configuration, error) {
+	absFilename, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, exists := loading[absFilename]; exists {
+		return nil, fmt.Errorf("include cycle detected on file %s", filename)
+	}
+	loading[absFilename] = struct{}{}
+	defer delete(loading, absFilename)
+
+	content, err := readFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configuration file: %s - %w", filename, err)
+	}
+
 	if parseTemplate {
-		configuration, err = p.CreateConfiguration(ctx, filename, template.FuncMap{}, false)
-	} else {
-		configuration, err = p.DecodeConfiguration(filename)
+		content, err = p.renderTemplate(ctx, content, template.FuncMap{}, false)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
 	}
+
+	configuration, err := p.decodeConfiguration(filename, content)
 	if err != nil {
 		return nil, err
 	}
 
 	if configuration.TLS != nil {
-		configuration.TLS.Certificates = flattenCertificates(ctx, configuration.TLS)
+		configuration.TLS.Certificates = p.flattenCertificates(ctx, configuration.TLS)
+		p.flattenTLSFiles(ctx, configuration.TLS)
+	}
+
+	if configuration.HTTP != nil {
+		p.flattenServersTransports(ctx, configuration.HTTP.ServersTransports)
+	}
+
+	includedFiles, err := decodeIncludes(filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, includedFile := range includedFiles {
+		p.addIncludedFile(includedFile)
+
+		c, err := p.loadFileConfigWithIncludes(ctx, includedFile, parseTemplate, loading)
+		if err != nil {
+			return nil, fmt.Errorf("%s: include error: %w", filename, err)
+		}
+
+		mergeConfiguration(log.With(ctx, log.Str("filename", includedFile)), configuration, c)
+
+		if c.TLS != nil && len(c.TLS.Certificates) > 0 {
+			if configuration.TLS == nil {
+				configuration.TLS = &dynamic.TLSConfiguration{}
+			}
+			configuration.TLS.Certificates = append(configuration.TLS.Certificates, c.TLS.Certificates...)
+		}
 	}
 
 	return configuration, nil
 }
 
-func flattenCertificates(ctx context.Context, tlsConfig *dynamic.TLSConfiguration) []*tls.CertAndStores {
+func (p *Provider) flattenCertificates(ctx context.Context, tlsConfig *dynamic.TLSConfiguration) []*tls.CertAndStores {
 	var certs []*tls.CertAndStores
 	for _, cert := range tlsConfig.Certificates {
+		if cert.Certificate.CertFile.IsPath() {
+			p.addReferencedFile(cert.Certificate.CertFile.String())
+		}
+		if cert.Certificate.KeyFile.IsPath() {
+			p.addReferencedFile(cert.Certificate.KeyFile.String())
+		}
+
 		content, err := cert.Certificate.CertFile.Read()
 		if err != nil {
 			log.FromContext(ctx).Error(err)
@@ -196,9 +287,9 @@ func flattenCertificates(ctx context.Context, tlsConfig *dynamic.TLSConfiguratio
 }
 
 func (p *Provider) loadFileConfigFromDirectory(ctx context.Context, directory string, configuration *dynamic.Configuration) (*dynamic.Configuration, error) {
-	fileList, err := os.ReadDir(directory)
+	fileList, err := listConfigurationFiles(directory)
 	if err != nil {
-		return configuration, fmt.Errorf("unable to read directory %s: %w", directory, err)
+		return configuration, err
 	}
 
 	if configuration == nil {
@@ -224,136 +315,176 @@ func (p *Provider) loadFileConfigFromDirectory(ctx context.Context, directory st
 		}
 	}
 
+	// The files included by another file of the directory are only loaded as part of the including file,
+	// so they are all found before loading any file.
+	for _, filename := range fileList {
+		for _, includedFile := range p.scanIncludes(ctx, filename) {
+			p.addIncludedFile(includedFile)
+		}
+	}
+
 	configTLSMaps := make(map[*tls.CertAndStores]struct{})
 
-	for _, item := range fileList {
-		logger := log.FromContext(log.With(ctx, log.Str("filename", item.Name())))
+	for _, filename := range fileList {
+		ctxFile := log.With(ctx, log.Str("filename", filepath.Base(filename)))
+		logger := log.FromContext(ctxFile)
 
-		if item.IsDir() {
-			configuration, err = p.loadFileConfigFromDirectory(ctx, filepath.Join(directory, item.Name()), configuration)
-			if err != nil {
-				return configuration, fmt.Errorf("unable to load content configuration from subdirectory %s: %w", item, err)
-			}
+		if p.isIncludedFile(filename) {
+			logger.Debug("Skipping file included by another file")
 			continue
 		}
 
-		switch strings.ToLower(filepath.Ext(item.Name())) {
-		case ".toml", ".yaml", ".yml":
-			// noop
-		default:
-			continue
-		}
-
-		var c *dynamic.Configuration
-		c, err = p.loadFileConfig(ctx, filepath.Join(directory, item.Name()), true)
+		c, err := p.loadFileConfig(ctx, filename, true)
 		if err != nil {
-			return configuration, fmt.Errorf("%s: %w", filepath.Join(directory, item.Name()), err)
+			if p.SkipInvalidFiles {
+				logger.Errorf("Skipping invalid file: %v", err)
+				continue
+			}
+			return configuration, err
 		}
 
-		for name, conf := range c.HTTP.Routers {
-			if _, exists := configuration.HTTP.Routers[name]; exists {
-				logger.WithField(log.RouterName, name).Warn("HTTP router already configured, skipping")
-			} else {
-				configuration.HTTP.Routers[name] = conf
-			}
+		mergeConfiguration(ctxFile, configuration, c)
+
+		if c.TLS == nil {
+			continue
 		}
 
-		for name, conf := range c.HTTP.Middlewares {
-			if _, exists := configuration.HTTP.Middlewares[name]; exists {
-				logger.WithField(log.MiddlewareName, name).Warn("HTTP middleware already configured, skipping")
+		for _, conf := range c.TLS.Certificates {
+			if _, exists := configTLSMaps[conf]; exists {
+				logger.Warnf("TLS configuration %v already configured, skipping", conf)
 			} else {
-				configuration.HTTP.Middlewares[name] = conf
+				configTLSMaps[conf] = struct{}{}
 			}
 		}
+	}
 
-		for name, conf := range c.HTTP.Services {
-			if _, exists := configuration.HTTP.Services[name]; exists {
-				logger.WithField(log.ServiceName, name).Warn("HTTP service already configured, skipping")
-			} else {
-				configuration.HTTP.Services[name] = conf
+	if len(configTLSMaps) > 0 && configuration.TLS == nil {
+		configuration.TLS = &dynamic.TLSConfiguration{}
+	}
+
+	for conf := range configTLSMaps {
+		configuration.TLS.Certificates = append(configuration.TLS.Certificates, conf)
+	}
+
+	return configuration, nil
+}
+
+// listConfigurationFiles returns the TOML and YAML files of the directory and of its subdirectories.
+func listConfigurationFiles(directory string) ([]string, error) {
+	fileList, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read directory %s: %w", directory, err)
+	}
+
+	var files []string
+	for _, item := range fileList {
+		if item.IsDir() {
+			subFiles, err := listConfigurationFiles(filepath.Join(directory, item.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("unable to load content configuration from subdirectory %s: %w", item, err)
 			}
+			files = append(files, subFiles...)
+			continue
 		}
 
-		for name, conf := range c.HTTP.ServersTransports {
-			if _, exists := configuration.HTTP.ServersTransports[name]; exists {
-				logger.WithField(log.ServersTransportName, name).Warn("HTTP servers transport already configured, skipping")
-			} else {
-				configuration.HTTP.ServersTransports[name] = conf
-			}
+		switch strings.ToLower(filepath.Ext(item.Name())) {
+		case ".toml", ".yaml", ".yml":
+			files = append(files, filepath.Join(directory, item.Name()))
 		}
+	}
 
-		for name, conf := range c.TCP.Routers {
-			if _, exists := configuration.TCP.Routers[name]; exists {
-				logger.WithField(log.RouterName, name).Warn("TCP router already configured, skipping")
-			} else {
-				configuration.TCP.Routers[name] = conf
-			}
+	return files, nil
+}
+
+// mergeConfiguration adds the elements of the configuration c which are not already defined in the configuration.
+func mergeConfiguration(ctx context.Context, configuration, c *dynamic.Configuration) {
+	logger := log.FromContext(ctx)
+
+	for name, conf := range c.HTTP.Routers {
+		if _, exists := configuration.HTTP.Routers[name]; exists {
+			logger.WithField(log.RouterName, name).Warn("HTTP router already configured, skipping")
+		} else {
+			configuration.HTTP.Routers[name] = conf
 		}
+	}
 
-		for name, conf := range c.TCP.Services {
-			if _, exists := configuration.TCP.Services[name]; exists {
-				logger.WithField(log.ServiceName, name).Warn("TCP service already configured, skipping")
-			} else {
-				configuration.TCP.Services[name] = conf
-			}
+	for name, conf := range c.HTTP.Middlewares {
+		if _, exists := configuration.HTTP.Middlewares[name]; exists {
+			logger.WithField(log.MiddlewareName, name).Warn("HTTP middleware already configured, skipping")
+		} else {
+			configuration.HTTP.Middlewares[name] = conf
 		}
+	}
 
-		for name, conf := range c.UDP.Routers {
-			if _, exists := configuration.UDP.Routers[name]; exists {
-				logger.WithField(log.RouterName, name).Warn("UDP router already configured, skipping")
-			} else {
-				configuration.UDP.Routers[name] = conf
-			}
+	for name, conf := range c.HTTP.Services {
+		if _, exists := configuration.HTTP.Services[name]; exists {
+			logger.WithField(log.ServiceName, name).Warn("HTTP service already configured, skipping")
+		} else {
+			configuration.HTTP.Services[name] = conf
 		}
+	}
 
-		for name, conf := range c.UDP.Services {
-			if _, exists := configuration.UDP.Services[name]; exists {
-				logger.WithField(log.ServiceName, name).Warn("UDP service already configured, skipping")
-			} else {
-				configuration.UDP.Services[name] = conf
-			}
+	for name, conf := range c.HTTP.ServersTransports {
+		if _, exists := configuration.HTTP.ServersTransports[name]; exists {
+			logger.WithField(log.ServersTransportName, name).Warn("HTTP servers transport already configured, skipping")
+		} else {
+			configuration.HTTP.ServersTransports[name] = conf
 		}
+	}
 
-		for _, conf := range c.TLS.Certificates {
-			if _, exists := configTLSMaps[conf]; exists {
-				logger.Warnf("TLS configuration %v already configured, skipping", conf)
-			} else {
-				configTLSMaps[conf] = struct{}{}
-			}
+	for name, conf := range c.TCP.Routers {
+		if _, exists := configuration.TCP.Routers[name]; exists {
+			logger.WithField(log.RouterName, name).Warn("TCP router already configured, skipping")
+		} else {
+			configuration.TCP.Routers[name] = conf
 		}
+	}
 
-		for name, conf := range c.TLS.Options {
-			if _, exists := configuration.TLS.Options[name]; exists {
-				logger.Warnf("TLS options %v already configured, skipping", name)
-			} else {
-				if configuration.TLS.Options == nil {
-					configuration.TLS.Options = map[string]tls.Options{}
-				}
-				configuration.TLS.Options[name] = conf
-			}
+	for name, conf := range c.TCP.Services {
+		if _, exists := configuration.TCP.Services[name]; exists {
+			logger.WithField(log.ServiceName, name).Warn("TCP service already configured, skipping")
+		} else {
+			configuration.TCP.Services[name] = conf
 		}
+	}
 
-		for name, conf := range c.TLS.Stores {
-			if _, exists := configuration.TLS.Stores[name]; exists {
-				logger.Warnf("TLS store %v already configured, skipping", name)
-			} else {
-				if configuration.TLS.Stores == nil {
-					configuration.TLS.Stores = map[string]tls.Store{}
-				}
-				configuration.TLS.Stores[name] = conf
-			}
+	for name, conf := range c.UDP.Routers {
+		if _, exists := configuration.UDP.Routers[name]; exists {
+			logger.WithField(log.RouterName, name).Warn("UDP router already configured, skipping")
+		} else {
+			configuration.UDP.Routers[name] = conf
 		}
 	}
 
-	if len(configTLSMaps) > 0 && configuration.TLS == nil {
-		configuration.TLS = &dynamic.TLSConfiguration{}
+	for name, conf := range c.UDP.Services {
+		if _, exists := configuration.UDP.Services[name]; exists {
+			logger.WithField(log.ServiceName, name).Warn("UDP service already configured, skipping")
+		} else {
+			configuration.UDP.Services[name] = conf
+		}
 	}
 
-	for conf := range configTLSMaps {
-		configuration.TLS.Certificates = append(configuration.TLS.Certificates, conf)
+	for name, conf := range c.TLS.Options {
+		if _, exists := configuration.TLS.Options[name]; exists {
+			logger.Warnf("TLS options %v already configured, skipping", name)
+		} else {
+			if configuration.TLS.Options == nil {
+				configuration.TLS.Options = map[string]tls.Options{}
+			}
+			configuration.TLS.Options[name] = conf
+		}
 	}
 
-	return configuration, nil
+	for name, conf := range c.TLS.Stores {
+		if _, exists := configuration.TLS.Stores[name]; exists {
+			logger.Warnf("TLS store %v already configured, skipping", name)
+		} else {
+			if configuration.TLS.Stores == nil {
+				configuration.TLS.Stores = map[string]tls.Store{}
+			}
+			configuration.TLS.Stores[name] = conf
+		}
+	}
 }
 
 // CreateConfiguration creates a provider configuration from content using templating.
@@ -363,6 +494,15 @@ func (p *Provider) CreateConfiguration(ctx context.Context, filename string, fun
 		return nil, fmt.Errorf("error reading configuration file: %s - %w", filename, err)
 	}
 
+	renderedTemplate, err := p.renderTemplate(ctx, tmplContent, funcMap, templateObjects)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.decodeConfiguration(filename, renderedTemplate)
+}
+
+func (p *Provider) renderTemplate(ctx context.Context, tmplContent string, funcMap template.FuncMap, templateObjects interface{}) (string, error) {
 	defaultFuncMap := sprig.TxtFuncMap()
 	defaultFuncMap["normalize"] = provider.Normalize
 	defaultFuncMap["split"] = strings.Split
@@ -372,15 +512,15 @@ func (p *Provider) CreateConfiguration(ctx context.Context, filename string, fun
 
 	tmpl := template.New(p.Filename).Funcs(defaultFuncMap)
 
-	_, err = tmpl.Parse(tmplContent)
+	_, err := tmpl.Parse(tmplContent)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	var buffer bytes.Buffer
 	err = tmpl.Execute(&buffer, templateObjects)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	renderedTemplate := buffer.String()
@@ -390,7 +530,7 @@ func (p *Provider) CreateConfiguration(ctx context.Context, filename string, fun
 		logger.Debugf("Rendering results: %s", renderedTemplate)
 	}
 
-	return p.decodeConfiguration(filename, renderedTemplate)
+	return renderedTemplate, nil
 }
 
 // DecodeConfiguration Decodes a *types.Configuration from a content.
@@ -425,14 +565,69 @@ func (p *Provider) decodeConfiguration(filePath, content string) (*dynamic.Confi
 		},
 	}
 
+	if p.EnvInterpolation {
+		var err error
+		content, err = interpolateEnv(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filePath, err)
+		}
+	}
+
 	err := file.DecodeContent(content, strings.ToLower(filepath.Ext(filePath)), configuration)
 	if err != nil {
-		return nil, err
+		return nil, newDecodeError(filePath, content, err)
 	}
 
 	return configuration, nil
 }
 
+var (
+	errorLineRegexp  = regexp.MustCompile(`(?i)\bline (\d+)`)
+	errorNodeRegexp  = regexp.MustCompile(`node: (\S+)`)
+	errorValueRegexp = regexp.MustCompile(`parsing "([^"]+)"`)
+)
+
+// decodeError reports the location of an error found while decoding a configuration file.
+type decodeError struct {
+	filename string
+	line     int
+	err      error
+}
+
+// newDecodeError locates the error in the content, either from the line reported by the decoder,
+// or from the first line holding the offending node or value.
+func newDecodeError(filename, content string, err error) *decodeError {
+	dErr := &decodeError{filename: filename, err: err}
+
+	if match := errorLineRegexp.FindStringSubmatch(err.Error()); match != nil {
+		dErr.line, _ = strconv.Atoi(match[1])
+		return dErr
+	}
+
+	if match := errorNodeRegexp.FindStringSubmatch(err.Error()); match != nil {
+		dErr.line = lineOf(content, match[1])
+		return dErr
+	}
+
+	if match := errorValueRegexp.FindStringSubmatch(err.Error()); match != nil {
+		dErr.line = lineOf(content, match[1])
+	}
+
+	return dErr
+}
+
+func (e *decodeError) Error() string {
+	if e.line > 0 {
+		return fmt.Sprintf("%s:%d: %v", e.filename, e.line, e.err)
+	}
+
+	return fmt.Sprintf("%s: %v", e.filename, e.err)
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
 func readFile(filename string) (string, error) {
 	if len(filename) > 0 {
 		buf, err := os.ReadFile(filename)
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/safe"
+	"github.com/traefik/traefik/v2/pkg/tls"
 )
 
 type ProvideTestCase struct {
@@ -314,3 +315,358 @@ func createTempFile(srcPath, tempDir string) (*os.File, error) {
 	_, err = io.Copy(file, src)
 	return file, err
 }
+
+func TestInclude(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tempDir, "main.toml"), `
+include = ["routers.toml", "services/*.yml"]
+
+[http.routers.main]
+  rule = "Host(`+"`main.localhost`"+`)"
+  service = "main"
+`)
+	writeFile(t, filepath.Join(tempDir, "routers.toml"), `
+[http.routers.main]
+  rule = "Host(`+"`ignored.localhost`"+`)"
+  service = "ignored"
+
+[http.routers.other]
+  rule = "Host(`+"`other.localhost`"+`)"
+  service = "other"
+`)
+	require.NoError(t, os.Mkdir(filepath.Join(tempDir, "services"), 0o755))
+	writeFile(t, filepath.Join(tempDir, "services", "main.yml"), `
+http:
+  services:
+    main:
+      loadBalancer:
+        servers:
+          - url: http://127.0.0.1:8080
+`)
+	writeFile(t, filepath.Join(tempDir, "services", "other.yml"), `
+http:
+  services:
+    other:
+      loadBalancer:
+        servers:
+          - url: http://127.0.0.1:8081
+`)
+
+	provider := &Provider{Filename: filepath.Join(tempDir, "main.toml")}
+
+	configuration, err := provider.BuildConfiguration()
+	require.NoError(t, err)
+
+	require.Len(t, configuration.HTTP.Routers, 2)
+	assert.Equal(t, "main", configuration.HTTP.Routers["main"].Service)
+	assert.Equal(t, "other", configuration.HTTP.Routers["other"].Service)
+	assert.Len(t, configuration.HTTP.Services, 2)
+
+	assert.True(t, provider.isIncludedFile(filepath.Join(tempDir, "routers.toml")))
+	assert.True(t, provider.isIncludedFile(filepath.Join(tempDir, "services", "other.yml")))
+	assert.False(t, provider.isIncludedFile(filepath.Join(tempDir, "main.toml")))
+}
+
+func TestInclude_directory(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// The included file sorts before the including one, and is only loaded as part of it.
+	writeFile(t, filepath.Join(tempDir, "a-routers.toml"), `
+[http.routers.main]
+  rule = "Host(`+"`ignored.localhost`"+`)"
+  service = "ignored"
+
+[http.routers.other]
+  rule = "Host(`+"`other.localhost`"+`)"
+  service = "main"
+`)
+	writeFile(t, filepath.Join(tempDir, "main.toml"), `
+include = "a-routers.toml"
+
+[http.routers.main]
+  rule = "Host(`+"`main.localhost`"+`)"
+  service = "main"
+
+[http.services.main.loadBalancer]
+  [[http.services.main.loadBalancer.servers]]
+    url = "http://127.0.0.1:8080"
+`)
+
+	provider := &Provider{Directory: tempDir}
+
+	configuration, err := provider.BuildConfiguration()
+	require.NoError(t, err)
+
+	require.Len(t, configuration.HTTP.Routers, 2)
+	assert.Equal(t, "Host(`main.localhost`)", configuration.HTTP.Routers["main"].Rule)
+	assert.Equal(t, "Host(`other.localhost`)", configuration.HTTP.Routers["other"].Rule)
+	assert.Len(t, configuration.HTTP.Services, 1)
+}
+
+func TestInclude_directoryFragment(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// The fragments sort before the including file, and are only loaded as part of it.
+	writeFile(t, filepath.Join(tempDir, "a-fragment.toml"), `
+include = "b-fragment.toml"
+
+[http.routers.fragment]
+  rule = "Host(`+"`fragment.localhost`"+`)"
+  service = "main"
+`)
+	writeFile(t, filepath.Join(tempDir, "b-fragment.toml"), `
+[http.routers.nested]
+  rule = "Host(`+"`nested.localhost`"+`)"
+  service = ["main"
+`)
+	writeFile(t, filepath.Join(tempDir, "main.toml"), `
+include = "a-fragment.toml"
+`)
+	writeFile(t, filepath.Join(tempDir, "other.toml"), `
+[http.routers.other]
+  rule = "Host(`+"`other.localhost`"+`)"
+  service = "other"
+`)
+
+	provider := &Provider{Directory: tempDir}
+
+	_, err := provider.BuildConfiguration()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "main.toml: include error: ")
+	assert.True(t, provider.isIncludedFile(filepath.Join(tempDir, "a-fragment.toml")))
+	assert.True(t, provider.isIncludedFile(filepath.Join(tempDir, "b-fragment.toml")))
+
+	provider.SkipInvalidFiles = true
+
+	configuration, err := provider.BuildConfiguration()
+	require.NoError(t, err)
+	assert.Len(t, configuration.HTTP.Routers, 1)
+	assert.Contains(t, configuration.HTTP.Routers, "other")
+}
+
+func TestIncludeErrors(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		files       map[string]string
+		expectedErr string
+	}{
+		{
+			desc: "missing file",
+			files: map[string]string{
+				"main.toml": `include = "missing.toml"`,
+			},
+			expectedErr: "included file not found",
+		},
+		{
+			desc: "cycle",
+			files: map[string]string{
+				"main.toml":  `include = "other.toml"`,
+				"other.toml": `include = "main.toml"`,
+			},
+			expectedErr: "include cycle detected",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			for name, content := range test.files {
+				writeFile(t, filepath.Join(tempDir, name), content)
+			}
+
+			provider := &Provider{Filename: filepath.Join(tempDir, "main.toml")}
+
+			_, err := provider.BuildConfiguration()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedErr)
+		})
+	}
+}
+
+func TestDecodeErrorLocation(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		filename    string
+		content     string
+		expectedErr string
+	}{
+		{
+			desc:     "toml syntax error",
+			filename: "file.toml",
+			content: `[http.routers.foo]
+  rule = "Host(` + "`foo`" + `)"
+  service = ["foo"
+`,
+			expectedErr: "file.toml:3: ",
+		},
+		{
+			desc:     "yaml syntax error",
+			filename: "file.yml",
+			content: `http:
+  routers:
+    foo:
+      rule: foo
+     service: [
+`,
+			expectedErr: "file.yml:4: ",
+		},
+		{
+			desc:     "unknown field",
+			filename: "file.toml",
+			content: `[http.routers.foo]
+  rule = "Host(` + "`foo`" + `)"
+  unknownField = "foo"
+`,
+			expectedErr: "file.toml:3: field not found, node: unknownField",
+		},
+		{
+			desc:     "invalid value",
+			filename: "file.yml",
+			content: `http:
+  routers:
+    foo:
+      rule: foo
+      priority: abc
+`,
+			expectedErr: "file.yml:5: ",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			provider := &Provider{}
+
+			_, err := provider.decodeConfiguration(test.filename, test.content)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedErr)
+		})
+	}
+}
+
+func TestSkipInvalidFiles(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tempDir, "valid.toml"), `
+[http.routers.foo]
+  rule = "Host(`+"`foo.localhost`"+`)"
+  service = "foo"
+`)
+	writeFile(t, filepath.Join(tempDir, "invalid.toml"), `
+[http.routers.bar]
+  rule = "Host(`+"`bar.localhost`"+`)"
+  service = ["bar"
+`)
+
+	provider := &Provider{Directory: tempDir}
+
+	_, err := provider.BuildConfiguration()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid.toml:4: ")
+
+	provider.SkipInvalidFiles = true
+
+	configuration, err := provider.BuildConfiguration()
+	require.NoError(t, err)
+	assert.Len(t, configuration.HTTP.Routers, 1)
+	assert.Contains(t, configuration.HTTP.Routers, "foo")
+}
+
+func TestReferencedFiles(t *testing.T) {
+	configDir := t.TempDir()
+	certsDir := t.TempDir()
+
+	certFile := filepath.Join(certsDir, "tls.crt")
+	keyFile := filepath.Join(certsDir, "tls.key")
+	caFile := filepath.Join(certsDir, "ca.crt")
+
+	writeFile(t, certFile, "certificate")
+	writeFile(t, keyFile, "key")
+	writeFile(t, caFile, "ca")
+
+	writeFile(t, filepath.Join(configDir, "dynamic.yml"), `
+http:
+  serversTransports:
+    transport:
+      rootCAs:
+        - `+caFile+`
+      certificates:
+        - certFile: `+certFile+`
+          keyFile: `+keyFile+`
+tls:
+  certificates:
+    - certFile: `+certFile+`
+      keyFile: `+keyFile+`
+  stores:
+    default:
+      defaultCertificate:
+        certFile: `+certFile+`
+        keyFile: `+keyFile+`
+  options:
+    mtls:
+      clientAuth:
+        caFiles:
+          - `+caFile+`
+`)
+
+	provider := &Provider{Filename: filepath.Join(configDir, "dynamic.yml"), Watch: true}
+
+	configuration, err := provider.BuildConfiguration()
+	require.NoError(t, err)
+
+	serversTransport := configuration.HTTP.ServersTransports["transport"]
+	assert.Equal(t, []tls.FileOrContent{"ca"}, serversTransport.RootCAs)
+	assert.Equal(t, tls.Certificates{{CertFile: "certificate", KeyFile: "key"}}, serversTransport.Certificates)
+
+	require.Len(t, configuration.TLS.Certificates, 1)
+	assert.Equal(t, tls.Certificate{CertFile: "certificate", KeyFile: "key"}, configuration.TLS.Certificates[0].Certificate)
+	assert.Equal(t, &tls.Certificate{CertFile: "certificate", KeyFile: "key"}, configuration.TLS.Stores["default"].DefaultCertificate)
+	assert.Equal(t, []tls.FileOrContent{"ca"}, configuration.TLS.Options["mtls"].ClientAuth.CAFiles)
+
+	assert.True(t, provider.isReferencedDirectory(certsDir))
+	assert.Equal(t, []string{certsDir}, provider.referencedDirectories())
+
+	configChan := make(chan dynamic.Message)
+	pool := safe.NewPool(context.Background())
+	t.Cleanup(pool.Stop)
+
+	go func() {
+		err := provider.Provide(configChan, pool)
+		assert.NoError(t, err)
+	}()
+
+	select {
+	case <-configChan:
+	case <-time.After(time.Second):
+		t.Fatal("timeout while waiting for config")
+	}
+
+	// The CA file is rewritten in place, without touching the configuration file.
+	writeFile(t, caFile, "renewed ca")
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case conf := <-configChan:
+			if conf.Configuration.TLS.Options["mtls"].ClientAuth.CAFiles[0] == "renewed ca" {
+				assert.Equal(t, []tls.FileOrContent{"renewed ca"}, conf.Configuration.HTTP.ServersTransports["transport"].RootCAs)
+				return
+			}
+		case <-timeout:
+			t.Fatal("timeout while waiting for the reloaded config")
+		}
+	}
+}
+
+func writeFile(t *testing.T, filename, content string) {
+	t.Helper()
+
+	err := os.WriteFile(filename, []byte(content), 0o644)
+	require.NoError(t, err)
+}
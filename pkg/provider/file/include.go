@@ -0,0 +1,144 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/traefik/paerser/file"
+	"github.com/traefik/traefik/v2/pkg/log"
+)
+
+// includes holds the include directive of a dynamic configuration file.
+type includes struct {
+	Include []string `json:"include,omitempty" toml:"include,omitempty" yaml:"include,omitempty"`
+}
+
+// decodeIncludes returns the files included by the given configuration content.
+// The include patterns are relative to the directory of the including file, and can be globs.
+func decodeIncludes(filePath, content string) ([]string, error) {
+	var incl includes
+	err := file.DecodeContent(content, strings.ToLower(filepath.Ext(filePath)), &incl)
+	if err != nil {
+		return nil, newDecodeError(filePath, content, err)
+	}
+
+	var files []string
+	for _, pattern := range incl.Include {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(filePath), pattern)
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid include pattern %q: %w", filePath, pattern, err)
+		}
+
+		if len(matches) == 0 && !hasMeta(pattern) {
+			return nil, fmt.Errorf("%s: included file not found: %s", filePath, pattern)
+		}
+
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+
+	return files, nil
+}
+
+// scanIncludes returns the files included by the given file, only decoding its include directive.
+// The errors are ignored, as they are reported when loading the file, or the file including it.
+func (p *Provider) scanIncludes(ctx context.Context, filename string) []string {
+	logger := log.FromContext(log.With(ctx, log.Str("filename", filepath.Base(filename))))
+
+	content, err := readFile(filename)
+	if err != nil {
+		logger.Debugf("Unable to read the includes: %v", err)
+		return nil
+	}
+
+	content, err = p.renderTemplate(ctx, content, template.FuncMap{}, false)
+	if err != nil {
+		logger.Debugf("Unable to read the includes: %v", err)
+		return nil
+	}
+
+	includedFiles, err := decodeIncludes(filename, content)
+	if err != nil {
+		logger.Debugf("Unable to read the includes: %v", err)
+		return nil
+	}
+
+	return includedFiles
+}
+
+func hasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
+
+// addIncludedFile records a file included by the configuration, so that it is watched.
+func (p *Provider) addIncludedFile(filename string) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		abs = filename
+	}
+
+	p.includedMu.Lock()
+	defer p.includedMu.Unlock()
+
+	if p.includedFiles == nil {
+		p.includedFiles = make(map[string]struct{})
+	}
+	p.includedFiles[abs] = struct{}{}
+}
+
+// resetIncludedFiles forgets the files included by the previous configuration.
+func (p *Provider) resetIncludedFiles() {
+	p.includedMu.Lock()
+	defer p.includedMu.Unlock()
+
+	p.includedFiles = nil
+}
+
+// isIncludedFile returns whether the file is included by the current configuration.
+func (p *Provider) isIncludedFile(filename string) bool {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		abs = filename
+	}
+
+	p.includedMu.RLock()
+	defer p.includedMu.RUnlock()
+
+	_, ok := p.includedFiles[abs]
+	return ok
+}
+
+// includedDirectories returns the directories containing the included files.
+func (p *Provider) includedDirectories() []string {
+	p.includedMu.RLock()
+	defer p.includedMu.RUnlock()
+
+	dirs := make(map[string]struct{})
+	for filename := range p.includedFiles {
+		dirs[filepath.Dir(filename)] = struct{}{}
+	}
+
+	var result []string
+	for dir := range dirs {
+		if _, err := os.Stat(dir); err == nil {
+			result = append(result, dir)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
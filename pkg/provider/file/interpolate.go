@@ -0,0 +1,54 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// envVarRegexp matches the escaped `$${` sequences and the `${NAME}` or `${NAME:-default}` references.
+var envVarRegexp = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// interpolateEnv replaces the environment variable references found in the content.
+// A reference to an undefined variable without a default value is an error.
+// References which are not variable names, like the `${1}` of a regular expression replacement, are left as is.
+func interpolateEnv(content string) (string, error) {
+	var errs []string
+
+	result := envVarRegexp.ReplaceAllStringFunc(content, func(match string) string {
+		if match == "$${" {
+			return "${"
+		}
+
+		groups := envVarRegexp.FindStringSubmatch(match)
+		name, hasDefault, defaultValue := groups[1], groups[2] != "", groups[3]
+
+		value, ok := os.LookupEnv(name)
+		switch {
+		case ok && (value != "" || !hasDefault):
+			return value
+		case hasDefault:
+			return defaultValue
+		default:
+			errs = append(errs, fmt.Sprintf("line %d: environment variable %q is not defined", lineOf(content, match), name))
+			return match
+		}
+	})
+
+	if len(errs) > 0 {
+		return "", fmt.Errorf("interpolation error: %s", strings.Join(errs, ", "))
+	}
+
+	return result, nil
+}
+
+// lineOf returns the line number of the first occurrence of the substring in the content, or 0 if it is not found.
+func lineOf(content, substr string) int {
+	index := strings.Index(content, substr)
+	if index < 0 {
+		return 0
+	}
+
+	return strings.Count(content[:index], "\n") + 1
+}
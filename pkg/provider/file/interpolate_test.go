@@ -0,0 +1,81 @@
+package file
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateEnv(t *testing.T) {
+	require.NoError(t, os.Setenv("TRAEFIK_TEST_HOST", "foo.localhost"))
+	require.NoError(t, os.Setenv("TRAEFIK_TEST_EMPTY", ""))
+	defer func() {
+		_ = os.Unsetenv("TRAEFIK_TEST_HOST")
+		_ = os.Unsetenv("TRAEFIK_TEST_EMPTY")
+	}()
+
+	testCases := []struct {
+		desc        string
+		content     string
+		expected    string
+		expectedErr string
+	}{
+		{
+			desc:     "no reference",
+			content:  `rule = "Host(` + "`foo`" + `)"`,
+			expected: `rule = "Host(` + "`foo`" + `)"`,
+		},
+		{
+			desc:     "defined variable",
+			content:  `rule = "Host(` + "`${TRAEFIK_TEST_HOST}`" + `)"`,
+			expected: `rule = "Host(` + "`foo.localhost`" + `)"`,
+		},
+		{
+			desc:     "default value of an undefined variable",
+			content:  `url = "http://${TRAEFIK_TEST_UNDEFINED:-127.0.0.1}:8080"`,
+			expected: `url = "http://127.0.0.1:8080"`,
+		},
+		{
+			desc:     "default value of an empty variable",
+			content:  `url = "http://${TRAEFIK_TEST_EMPTY:-127.0.0.1}:8080"`,
+			expected: `url = "http://127.0.0.1:8080"`,
+		},
+		{
+			desc:     "empty variable without default value",
+			content:  `prefix = "${TRAEFIK_TEST_EMPTY}"`,
+			expected: `prefix = ""`,
+		},
+		{
+			desc:     "escaped reference",
+			content:  `prefix = "$${TRAEFIK_TEST_HOST}"`,
+			expected: `prefix = "${TRAEFIK_TEST_HOST}"`,
+		},
+		{
+			desc:     "regular expression replacement",
+			content:  `replacement = "/foo/${1}"`,
+			expected: `replacement = "/foo/${1}"`,
+		},
+		{
+			desc:        "undefined variable",
+			content:     "[http]\nprefix = \"${TRAEFIK_TEST_UNDEFINED}\"",
+			expectedErr: `line 2: environment variable "TRAEFIK_TEST_UNDEFINED" is not defined`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			content, err := interpolateEnv(test.content)
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, content)
+		})
+	}
+}
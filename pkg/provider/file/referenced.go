@@ -0,0 +1,156 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/tls"
+)
+
+// flattenTLSFiles replaces the files referenced by the TLS stores and options with their content.
+func (p *Provider) flattenTLSFiles(ctx context.Context, tlsConfig *dynamic.TLSConfiguration) {
+	for name, store := range tlsConfig.Stores {
+		if store.DefaultCertificate == nil {
+			continue
+		}
+
+		defaultCertificate := *store.DefaultCertificate
+		defaultCertificate.CertFile = p.flattenFile(ctx, defaultCertificate.CertFile)
+		defaultCertificate.KeyFile = p.flattenFile(ctx, defaultCertificate.KeyFile)
+
+		store.DefaultCertificate = &defaultCertificate
+		tlsConfig.Stores[name] = store
+	}
+
+	for name, options := range tlsConfig.Options {
+		options.ClientAuth.CAFiles = p.flattenFiles(ctx, options.ClientAuth.CAFiles)
+
+		if options.SessionTickets != nil {
+			sessionTickets := *options.SessionTickets
+			sessionTickets.KeyFiles = p.flattenFiles(ctx, sessionTickets.KeyFiles)
+			options.SessionTickets = &sessionTickets
+		}
+
+		tlsConfig.Options[name] = options
+	}
+}
+
+// flattenServersTransports replaces the files referenced by the servers transports with their content.
+func (p *Provider) flattenServersTransports(ctx context.Context, serversTransports map[string]*dynamic.ServersTransport) {
+	for _, serversTransport := range serversTransports {
+		if serversTransport == nil {
+			continue
+		}
+
+		for i, rootCA := range serversTransport.RootCAs {
+			serversTransport.RootCAs[i] = p.flattenFile(ctx, rootCA)
+		}
+
+		for i, cert := range serversTransport.Certificates {
+			serversTransport.Certificates[i].CertFile = p.flattenFile(ctx, cert.CertFile)
+			serversTransport.Certificates[i].KeyFile = p.flattenFile(ctx, cert.KeyFile)
+		}
+	}
+}
+
+// flattenFiles returns a copy of the references, with the referenced files replaced with their content.
+func (p *Provider) flattenFiles(ctx context.Context, files []tls.FileOrContent) []tls.FileOrContent {
+	if len(files) == 0 {
+		return files
+	}
+
+	flattened := make([]tls.FileOrContent, len(files))
+	for i, file := range files {
+		flattened[i] = p.flattenFile(ctx, file)
+	}
+
+	return flattened
+}
+
+// flattenFile returns the content of the referenced file, recording the file so that it is watched.
+// The reference is kept as is when it is not a file, or when the file cannot be read.
+func (p *Provider) flattenFile(ctx context.Context, fileOrContent tls.FileOrContent) tls.FileOrContent {
+	if !fileOrContent.IsPath() {
+		return fileOrContent
+	}
+
+	p.addReferencedFile(fileOrContent.String())
+
+	content, err := fileOrContent.Read()
+	if err != nil {
+		log.FromContext(ctx).Error(err)
+		return fileOrContent
+	}
+
+	return tls.FileOrContent(content)
+}
+
+// addReferencedFile records a certificate, key or CA file referenced by the configuration, so that it is watched.
+func (p *Provider) addReferencedFile(filename string) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		abs = filename
+	}
+
+	p.referencedMu.Lock()
+	defer p.referencedMu.Unlock()
+
+	if p.referencedFiles == nil {
+		p.referencedFiles = make(map[string]struct{})
+	}
+	p.referencedFiles[abs] = struct{}{}
+}
+
+// resetReferencedFiles forgets the files referenced by the previous configuration.
+func (p *Provider) resetReferencedFiles() {
+	p.referencedMu.Lock()
+	defer p.referencedMu.Unlock()
+
+	p.referencedFiles = nil
+}
+
+// isReferencedDirectory returns whether the directory contains a file referenced by the current configuration.
+// The whole directory is considered, as the referenced files are often replaced through symlinks,
+// as with the Kubernetes secrets mounted as volumes.
+func (p *Provider) isReferencedDirectory(dir string) bool {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+
+	p.referencedMu.RLock()
+	defer p.referencedMu.RUnlock()
+
+	for filename := range p.referencedFiles {
+		if filepath.Dir(filename) == abs {
+			return true
+		}
+	}
+
+	return false
+}
+
+// referencedDirectories returns the directories containing the referenced files.
+func (p *Provider) referencedDirectories() []string {
+	p.referencedMu.RLock()
+	defer p.referencedMu.RUnlock()
+
+	dirs := make(map[string]struct{})
+	for filename := range p.referencedFiles {
+		dirs[filepath.Dir(filename)] = struct{}{}
+	}
+
+	var result []string
+	for dir := range dirs {
+		if _, err := os.Stat(dir); err == nil {
+			result = append(result, dir)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
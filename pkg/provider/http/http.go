@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"io"
@@ -24,12 +25,23 @@ var _ provider.Provider = (*Provider)(nil)
 
 // Provider is a provider.Provider implementation that queries an HTTP(s) endpoint for a configuration.
 type Provider struct {
-	Endpoint              string           `description:"Load configuration from this endpoint." json:"endpoint" toml:"endpoint" yaml:"endpoint"`
-	PollInterval          ptypes.Duration  `description:"Polling interval for endpoint." json:"pollInterval,omitempty" toml:"pollInterval,omitempty" yaml:"pollInterval,omitempty" export:"true"`
-	PollTimeout           ptypes.Duration  `description:"Polling timeout for endpoint." json:"pollTimeout,omitempty" toml:"pollTimeout,omitempty" yaml:"pollTimeout,omitempty" export:"true"`
-	TLS                   *types.ClientTLS `description:"Enable TLS support." json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+	Endpoint              string            `description:"Load configuration from this endpoint." json:"endpoint" toml:"endpoint" yaml:"endpoint"`
+	PollInterval          ptypes.Duration   `description:"Polling interval for endpoint." json:"pollInterval,omitempty" toml:"pollInterval,omitempty" yaml:"pollInterval,omitempty" export:"true"`
+	PollTimeout           ptypes.Duration   `description:"Polling timeout for endpoint." json:"pollTimeout,omitempty" toml:"pollTimeout,omitempty" yaml:"pollTimeout,omitempty" export:"true"`
+	TLS                   *types.ClientTLS  `description:"Enable TLS support." json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+	LongPollTimeout       ptypes.Duration   `description:"Enable long polling: the endpoint can hold each request up to this duration, until the configuration changes." json:"longPollTimeout,omitempty" toml:"longPollTimeout,omitempty" yaml:"longPollTimeout,omitempty" export:"true"`
+	Headers               map[string]string `description:"Define custom headers to be sent to the endpoint." json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty"`
+	BasicAuth             *BasicAuth        `description:"Enable basic authentication on the endpoint." json:"basicAuth,omitempty" toml:"basicAuth,omitempty" yaml:"basicAuth,omitempty"`
+	BearerToken           string            `description:"Enable bearer token authentication on the endpoint." json:"bearerToken,omitempty" toml:"bearerToken,omitempty" yaml:"bearerToken,omitempty"`
 	httpClient            *http.Client
 	lastConfigurationHash uint64
+	lastETag              string
+}
+
+// BasicAuth holds the basic authentication credentials sent to the endpoint.
+type BasicAuth struct {
+	Username string `description:"Basic auth username." json:"username,omitempty" toml:"username,omitempty" yaml:"username,omitempty"`
+	Password string `description:"Basic auth password." json:"password,omitempty" toml:"password,omitempty" yaml:"password,omitempty"`
 }
 
 // SetDefaults sets the default values.
@@ -48,8 +60,16 @@ func (p *Provider) Init() error {
 		return fmt.Errorf("poll interval must be greater than 0")
 	}
 
+	if p.LongPollTimeout < 0 {
+		return fmt.Errorf("long poll timeout must be greater than or equal to 0")
+	}
+
+	if p.BasicAuth != nil && p.BearerToken != "" {
+		return errors.New("basic auth and bearer token are mutually exclusive")
+	}
+
 	p.httpClient = &http.Client{
-		Timeout: time.Duration(p.PollTimeout),
+		Timeout: time.Duration(p.PollTimeout + p.LongPollTimeout),
 	}
 
 	if p.TLS != nil {
@@ -73,39 +93,18 @@ func (p *Provider) Provide(configurationChan chan<- dynamic.Message, pool *safe.
 		logger := log.FromContext(ctxLog)
 
 		operation := func() error {
+			if p.LongPollTimeout > 0 {
+				return p.longPoll(ctxLog, configurationChan)
+			}
+
 			ticker := time.NewTicker(time.Duration(p.PollInterval))
 			defer ticker.Stop()
 
 			for {
 				select {
 				case <-ticker.C:
-					configData, err := p.fetchConfigurationData()
-					if err != nil {
-						return fmt.Errorf("cannot fetch configuration data: %w", err)
-					}
-
-					fnvHasher := fnv.New64()
-
-					_, err = fnvHasher.Write(configData)
-					if err != nil {
-						return fmt.Errorf("cannot hash configuration data: %w", err)
-					}
-
-					hash := fnvHasher.Sum64()
-					if hash == p.lastConfigurationHash {
-						continue
-					}
-
-					p.lastConfigurationHash = hash
-
-					configuration, err := decodeConfiguration(configData)
-					if err != nil {
-						return fmt.Errorf("cannot decode configuration data: %w", err)
-					}
-
-					configurationChan <- dynamic.Message{
-						ProviderName:  "http",
-						Configuration: configuration,
+					if _, err := p.poll(ctxLog, configurationChan); err != nil {
+						return err
 					}
 
 				case <-routineCtx.Done():
@@ -126,20 +125,129 @@ func (p *Provider) Provide(configurationChan chan<- dynamic.Message, pool *safe.
 	return nil
 }
 
+// longPoll requests the endpoint again as soon as it answers.
+// When an answer does not change the configuration, the next request is delayed
+// until the poll interval has elapsed, so that an endpoint which does not hold the requests is not flooded.
+func (p *Provider) longPoll(ctx context.Context, configurationChan chan<- dynamic.Message) error {
+	for {
+		start := time.Now()
+
+		changed, err := p.poll(ctx, configurationChan)
+		if err != nil {
+			return err
+		}
+
+		var wait time.Duration
+		if elapsed := time.Since(start); !changed && elapsed < time.Duration(p.PollInterval) {
+			wait = time.Duration(p.PollInterval) - elapsed
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		}
+	}
+}
+
+// poll fetches the configuration from the endpoint and sends it if it has changed.
+// It returns whether a new configuration has been sent.
+func (p *Provider) poll(ctx context.Context, configurationChan chan<- dynamic.Message) (bool, error) {
+	configData, err := p.fetchConfigurationData(ctx)
+	if err != nil {
+		return false, fmt.Errorf("cannot fetch configuration data: %w", err)
+	}
+
+	if configData == nil {
+		// Not modified.
+		return false, nil
+	}
+
+	fnvHasher := fnv.New64()
+
+	_, err = fnvHasher.Write(configData)
+	if err != nil {
+		return false, fmt.Errorf("cannot hash configuration data: %w", err)
+	}
+
+	hash := fnvHasher.Sum64()
+	if hash == p.lastConfigurationHash {
+		return false, nil
+	}
+
+	p.lastConfigurationHash = hash
+
+	configuration, err := decodeConfiguration(configData)
+	if err != nil {
+		return false, fmt.Errorf("cannot decode configuration data (hash %016x): %w", hash, err)
+	}
+
+	log.FromContext(ctx).Infof("New configuration received (hash %016x, ETag %q)", hash, p.lastETag)
+
+	select {
+	case configurationChan <- dynamic.Message{
+		ProviderName:  "http",
+		Configuration: configuration,
+	}:
+	case <-ctx.Done():
+	}
+
+	return true, nil
+}
+
 // fetchConfigurationData fetches the configuration data from the configured endpoint.
-func (p *Provider) fetchConfigurationData() ([]byte, error) {
-	res, err := p.httpClient.Get(p.Endpoint)
+// It returns nil data when the endpoint answers that the configuration has not been modified.
+func (p *Provider) fetchConfigurationData(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range p.Headers {
+		req.Header.Set(name, value)
+	}
+
+	switch {
+	case p.BasicAuth != nil:
+		req.SetBasicAuth(p.BasicAuth.Username, p.BasicAuth.Password)
+	case p.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+p.BearerToken)
+	}
+
+	if p.lastETag != "" {
+		req.Header.Set("If-None-Match", p.lastETag)
+	}
+
+	if p.LongPollTimeout > 0 {
+		// https://datatracker.ietf.org/doc/html/rfc7240#section-4.3
+		req.Header.Set("Prefer", fmt.Sprintf("wait=%d", int(time.Duration(p.LongPollTimeout).Seconds())))
+	}
+
+	res, err := p.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	defer res.Body.Close()
 
+	if res.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
+
 	if res.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("received non-ok response code: %d", res.StatusCode)
 	}
 
-	return io.ReadAll(res.Body)
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lastETag = res.Header.Get("ETag")
+
+	return data, nil
 }
 
 // decodeConfiguration decodes and returns the dynamic configuration from the given data.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -21,6 +22,8 @@ func TestProvider_Init(t *testing.T) {
 		desc         string
 		endpoint     string
 		pollInterval ptypes.Duration
+		basicAuth    *BasicAuth
+		bearerToken  string
 		expErr       bool
 	}{
 		{
@@ -32,6 +35,14 @@ func TestProvider_Init(t *testing.T) {
 			endpoint: "http://localhost:8080",
 			expErr:   true,
 		},
+		{
+			desc:         "should return an error if both basic auth and bearer token are configured",
+			endpoint:     "http://localhost:8080",
+			pollInterval: ptypes.Duration(time.Second),
+			basicAuth:    &BasicAuth{Username: "user", Password: "pass"},
+			bearerToken:  "token",
+			expErr:       true,
+		},
 		{
 			desc:         "should not return an error",
 			endpoint:     "http://localhost:8080",
@@ -45,6 +56,8 @@ func TestProvider_Init(t *testing.T) {
 			provider := &Provider{
 				Endpoint:     test.endpoint,
 				PollInterval: test.pollInterval,
+				BasicAuth:    test.basicAuth,
+				BearerToken:  test.bearerToken,
 			}
 
 			err := provider.Init()
@@ -69,10 +82,11 @@ func TestProvider_SetDefaults(t *testing.T) {
 
 func TestProvider_fetchConfigurationData(t *testing.T) {
 	tests := []struct {
-		desc    string
-		handler func(rw http.ResponseWriter, req *http.Request)
-		expData []byte
-		expErr  bool
+		desc     string
+		provider Provider
+		handler  func(rw http.ResponseWriter, req *http.Request)
+		expData  []byte
+		expErr   bool
 	}{
 		{
 			desc:    "should return the fetched configuration data",
@@ -82,6 +96,49 @@ func TestProvider_fetchConfigurationData(t *testing.T) {
 				_, _ = fmt.Fprintf(rw, "{}")
 			},
 		},
+		{
+			desc: "should send the configured headers and credentials",
+			provider: Provider{
+				Headers:     map[string]string{"X-Foo": "bar"},
+				BearerToken: "token",
+			},
+			expData: []byte("{}"),
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				if req.Header.Get("X-Foo") != "bar" || req.Header.Get("Authorization") != "Bearer token" {
+					rw.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				rw.WriteHeader(http.StatusOK)
+				_, _ = fmt.Fprintf(rw, "{}")
+			},
+		},
+		{
+			desc: "should send the basic auth credentials",
+			provider: Provider{
+				BasicAuth: &BasicAuth{Username: "user", Password: "pass"},
+			},
+			expData: []byte("{}"),
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				if username, password, ok := req.BasicAuth(); !ok || username != "user" || password != "pass" {
+					rw.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				rw.WriteHeader(http.StatusOK)
+				_, _ = fmt.Fprintf(rw, "{}")
+			},
+		},
+		{
+			desc:     "should return no data if the configuration is not modified",
+			provider: Provider{lastETag: `"v1"`},
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				if req.Header.Get("If-None-Match") == `"v1"` {
+					rw.WriteHeader(http.StatusNotModified)
+					return
+				}
+				rw.WriteHeader(http.StatusOK)
+				_, _ = fmt.Fprintf(rw, "{}")
+			},
+		},
 		{
 			desc:   "should return an error if endpoint does not return an OK status code",
 			expErr: true,
@@ -96,16 +153,15 @@ func TestProvider_fetchConfigurationData(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(test.handler))
 			defer server.Close()
 
-			provider := Provider{
-				Endpoint:     server.URL,
-				PollInterval: ptypes.Duration(1 * time.Second),
-				PollTimeout:  ptypes.Duration(1 * time.Second),
-			}
+			provider := test.provider
+			provider.Endpoint = server.URL
+			provider.PollInterval = ptypes.Duration(1 * time.Second)
+			provider.PollTimeout = ptypes.Duration(1 * time.Second)
 
 			err := provider.Init()
 			require.NoError(t, err)
 
-			configData, err := provider.fetchConfigurationData()
+			configData, err := provider.fetchConfigurationData(context.Background())
 			if test.expErr {
 				require.Error(t, err)
 				return
@@ -253,3 +309,105 @@ func TestProvider_ProvideConfigurationOnlyOnceIfUnchanged(t *testing.T) {
 
 	assert.Equal(t, 1, len(configurationChan))
 }
+
+func TestProvider_ProvideConfigurationOnlyOnceIfNotModified(t *testing.T) {
+	var requests int32
+
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		rw.Header().Set("ETag", `"v1"`)
+		rw.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(rw, "{}")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	provider := Provider{
+		Endpoint:     server.URL,
+		PollTimeout:  ptypes.Duration(1 * time.Second),
+		PollInterval: ptypes.Duration(100 * time.Millisecond),
+	}
+
+	err := provider.Init()
+	require.NoError(t, err)
+
+	configurationChan := make(chan dynamic.Message, 10)
+
+	err = provider.Provide(configurationChan, safe.NewPool(context.Background()))
+	require.NoError(t, err)
+
+	time.Sleep(time.Second)
+
+	assert.Equal(t, 1, len(configurationChan))
+	assert.Greater(t, atomic.LoadInt32(&requests), int32(1))
+}
+
+func TestProvider_ProvideWithLongPolling(t *testing.T) {
+	versions := make(chan string, 1)
+
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Prefer") != "wait=10" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if req.Header.Get("If-None-Match") == "" {
+			rw.Header().Set("ETag", `"v0"`)
+			_, _ = fmt.Fprintf(rw, "{}")
+			return
+		}
+
+		// Holds the request until a new version is available.
+		select {
+		case version := <-versions:
+			rw.Header().Set("ETag", `"`+version+`"`)
+			_, _ = fmt.Fprintf(rw, `{"tcp":{"routers":{%q:{}}}}`, version)
+		case <-req.Context().Done():
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	provider := Provider{
+		Endpoint:        server.URL,
+		PollTimeout:     ptypes.Duration(1 * time.Second),
+		PollInterval:    ptypes.Duration(time.Minute),
+		LongPollTimeout: ptypes.Duration(10 * time.Second),
+	}
+
+	err := provider.Init()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configurationChan := make(chan dynamic.Message)
+
+	err = provider.Provide(configurationChan, safe.NewPool(ctx))
+	require.NoError(t, err)
+
+	select {
+	case message := <-configurationChan:
+		assert.Empty(t, message.Configuration.TCP.Routers)
+	case <-time.After(time.Second):
+		t.Fatal("timeout while waiting for config")
+	}
+
+	versions <- "v1"
+
+	// The poll interval being one minute, only long polling can deliver the new configuration in time.
+	select {
+	case message := <-configurationChan:
+		assert.Contains(t, message.Configuration.TCP.Routers, "v1")
+	case <-time.After(time.Second):
+		t.Fatal("timeout while waiting for config")
+	}
+}
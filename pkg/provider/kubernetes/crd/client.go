@@ -8,12 +8,15 @@ import (
 	"runtime"
 	"time"
 
+	goversion "github.com/hashicorp/go-version"
 	"github.com/traefik/traefik/v2/pkg/log"
 	"github.com/traefik/traefik/v2/pkg/provider/kubernetes/crd/generated/clientset/versioned"
 	"github.com/traefik/traefik/v2/pkg/provider/kubernetes/crd/generated/informers/externalversions"
 	"github.com/traefik/traefik/v2/pkg/provider/kubernetes/crd/traefik/v1alpha1"
+	"github.com/traefik/traefik/v2/pkg/provider/kubernetes/k8s"
 	"github.com/traefik/traefik/v2/pkg/version"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
 	kubeerror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -75,6 +78,9 @@ type clientWrapper struct {
 
 	isNamespaceAll    bool
 	watchedNamespaces []string
+
+	useEndpointSlices bool
+	preferredZone     string
 }
 
 func createClientFromConfig(c *rest.Config) (*clientWrapper, error) {
@@ -177,6 +183,18 @@ func (c *clientWrapper) WatchAll(namespaces []string, stopCh <-chan struct{}) (<
 		opts.LabelSelector = c.labelSelector
 	}
 
+	if c.useEndpointSlices {
+		serverVersion, err := c.GetServerVersion()
+		if err != nil {
+			return nil, err
+		}
+
+		if !k8s.SupportsEndpointSlices(serverVersion) {
+			log.WithoutContext().Warnf("EndpointSlices are not supported by Kubernetes %s, falling back to Endpoints", serverVersion)
+			c.useEndpointSlices = false
+		}
+	}
+
 	for _, ns := range namespaces {
 		factoryCrd := externalversions.NewSharedInformerFactoryWithOptions(c.csCrd, resyncPeriod, externalversions.WithNamespace(ns), externalversions.WithTweakListOptions(matchesLabelSelector))
 		factoryCrd.Traefik().V1alpha1().IngressRoutes().Informer().AddEventHandler(eventHandler)
@@ -190,7 +208,11 @@ func (c *clientWrapper) WatchAll(namespaces []string, stopCh <-chan struct{}) (<
 
 		factoryKube := informers.NewSharedInformerFactoryWithOptions(c.csKube, resyncPeriod, informers.WithNamespace(ns))
 		factoryKube.Core().V1().Services().Informer().AddEventHandler(eventHandler)
-		factoryKube.Core().V1().Endpoints().Informer().AddEventHandler(eventHandler)
+		if c.useEndpointSlices {
+			factoryKube.Discovery().V1beta1().EndpointSlices().Informer().AddEventHandler(eventHandler)
+		} else {
+			factoryKube.Core().V1().Endpoints().Informer().AddEventHandler(eventHandler)
+		}
 
 		factorySecret := informers.NewSharedInformerFactoryWithOptions(c.csKube, resyncPeriod, informers.WithNamespace(ns), informers.WithTweakListOptions(notOwnedByHelm))
 		factorySecret.Core().V1().Secrets().Informer().AddEventHandler(eventHandler)
@@ -373,11 +395,41 @@ func (c *clientWrapper) GetEndpoints(namespace, name string) (*corev1.Endpoints,
 		return nil, false, fmt.Errorf("failed to get endpoints %s/%s: namespace is not within watched namespaces", namespace, name)
 	}
 
+	if c.useEndpointSlices {
+		return c.getEndpointsFromSlices(namespace, name)
+	}
+
 	endpoint, err := c.factoriesKube[c.lookupNamespace(namespace)].Core().V1().Endpoints().Lister().Endpoints(namespace).Get(name)
 	exist, err := translateNotFoundError(err)
 	return endpoint, exist, err
 }
 
+// getEndpointsFromSlices returns the endpoints of the named service, built from its EndpointSlices.
+func (c *clientWrapper) getEndpointsFromSlices(namespace, name string) (*corev1.Endpoints, bool, error) {
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1beta1.LabelServiceName: name})
+
+	slices, err := c.factoriesKube[c.lookupNamespace(namespace)].Discovery().V1beta1().EndpointSlices().Lister().EndpointSlices(namespace).List(selector)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(slices) == 0 {
+		return nil, false, nil
+	}
+
+	return k8s.EndpointsFromSlices(namespace, name, slices, c.preferredZone), true, nil
+}
+
+// GetServerVersion returns the cluster server version, or an error.
+func (c *clientWrapper) GetServerVersion() (*goversion.Version, error) {
+	serverVersion, err := c.csKube.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve server version: %w", err)
+	}
+
+	return goversion.NewVersion(serverVersion.GitVersion)
+}
+
 // GetSecret returns the named secret from the given namespace.
 func (c *clientWrapper) GetSecret(namespace, name string) (*corev1.Secret, bool, error) {
 	if !c.isWatchedNamespace(namespace) {
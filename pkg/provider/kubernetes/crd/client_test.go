@@ -8,7 +8,10 @@ import (
 	"github.com/stretchr/testify/require"
 	crdfake "github.com/traefik/traefik/v2/pkg/provider/kubernetes/crd/generated/clientset/versioned/fake"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 )
 
@@ -63,3 +66,57 @@ func TestClientIgnoresHelmOwnedSecrets(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, found)
 }
+
+func TestClientUsesEndpointSlices(t *testing.T) {
+	portName := "http"
+	port := int32(80)
+	slice := &discoveryv1beta1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "whoami-abc",
+			Labels:    map[string]string{discoveryv1beta1.LabelServiceName: "whoami"},
+		},
+		AddressType: discoveryv1beta1.AddressTypeIPv4,
+		Endpoints: []discoveryv1beta1.Endpoint{
+			{
+				Addresses: []string{"10.10.0.1"},
+				Topology:  map[string]string{corev1.LabelTopologyZone: "zone-a"},
+			},
+			{
+				Addresses: []string{"10.10.0.2"},
+				Topology:  map[string]string{corev1.LabelTopologyZone: "zone-b"},
+			},
+		},
+		Ports: []discoveryv1beta1.EndpointPort{{Name: &portName, Port: &port}},
+	}
+
+	kubeClient := kubefake.NewSimpleClientset(slice)
+	crdClient := crdfake.NewSimpleClientset()
+
+	discovery, _ := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.FakedServerVersion = &version.Info{
+		GitVersion: "v1.19",
+	}
+
+	client := newClientImpl(kubeClient, crdClient)
+	client.useEndpointSlices = true
+	client.preferredZone = "zone-b"
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	_, err := client.WatchAll(nil, stopCh)
+	require.NoError(t, err)
+
+	endpoints, found, err := client.GetEndpoints("default", "whoami")
+	require.NoError(t, err)
+	require.True(t, found)
+
+	expected := []corev1.EndpointSubset{
+		{
+			Addresses: []corev1.EndpointAddress{{IP: "10.10.0.2"}},
+			Ports:     []corev1.EndpointPort{{Name: portName, Port: port}},
+		},
+	}
+	assert.Equal(t, expected, endpoints.Subsets)
+}
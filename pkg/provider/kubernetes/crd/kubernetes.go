@@ -49,6 +49,8 @@ type Provider struct {
 	LabelSelector       string          `description:"Kubernetes label selector to use." json:"labelSelector,omitempty" toml:"labelSelector,omitempty" yaml:"labelSelector,omitempty" export:"true"`
 	IngressClass        string          `description:"Value of kubernetes.io/ingress.class annotation to watch for." json:"ingressClass,omitempty" toml:"ingressClass,omitempty" yaml:"ingressClass,omitempty" export:"true"`
 	ThrottleDuration    ptypes.Duration `description:"Ingress refresh throttle duration" json:"throttleDuration,omitempty" toml:"throttleDuration,omitempty" yaml:"throttleDuration,omitempty" export:"true"`
+	UseEndpointSlices   bool            `description:"Use the EndpointSlices instead of the Endpoints to discover the servers." json:"useEndpointSlices,omitempty" toml:"useEndpointSlices,omitempty" yaml:"useEndpointSlices,omitempty" export:"true"`
+	PreferredZone       string          `description:"Zone whose endpoints are preferred over the endpoints of other zones (requires useEndpointSlices)." json:"preferredZone,omitempty" toml:"preferredZone,omitempty" yaml:"preferredZone,omitempty" export:"true"`
 	lastConfiguration   safe.Safe
 }
 
@@ -87,6 +89,8 @@ func (p *Provider) newK8sClient(ctx context.Context) (*clientWrapper, error) {
 	}
 
 	client.labelSelector = p.LabelSelector
+	client.useEndpointSlices = p.UseEndpointSlices
+	client.preferredZone = p.PreferredZone
 	return client, nil
 }
 
@@ -11,8 +11,10 @@ import (
 
 	"github.com/hashicorp/go-version"
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/provider/kubernetes/k8s"
 	traefikversion "github.com/traefik/traefik/v2/pkg/version"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	kubeerror "k8s.io/apimachinery/pkg/api/errors"
@@ -73,6 +75,8 @@ type clientWrapper struct {
 	ingressLabelSelector string
 	isNamespaceAll       bool
 	watchedNamespaces    []string
+	useEndpointSlices    bool
+	preferredZone        string
 }
 
 // newInClusterClient returns a new Provider client that is expected to run
@@ -173,6 +177,11 @@ func (c *clientWrapper) WatchAll(namespaces []string, stopCh <-chan struct{}) (<
 		return nil, fmt.Errorf("failed to get server version: %w", err)
 	}
 
+	if c.useEndpointSlices && !k8s.SupportsEndpointSlices(serverVersion) {
+		log.WithoutContext().Warnf("EndpointSlices are not supported by Kubernetes %s, falling back to Endpoints", serverVersion)
+		c.useEndpointSlices = false
+	}
+
 	for _, ns := range namespaces {
 		factoryIngress := informers.NewSharedInformerFactoryWithOptions(c.clientset, resyncPeriod, informers.WithNamespace(ns), informers.WithTweakListOptions(matchesLabelSelector))
 
@@ -186,7 +195,11 @@ func (c *clientWrapper) WatchAll(namespaces []string, stopCh <-chan struct{}) (<
 
 		factoryKube := informers.NewSharedInformerFactoryWithOptions(c.clientset, resyncPeriod, informers.WithNamespace(ns))
 		factoryKube.Core().V1().Services().Informer().AddEventHandler(eventHandler)
-		factoryKube.Core().V1().Endpoints().Informer().AddEventHandler(eventHandler)
+		if c.useEndpointSlices {
+			factoryKube.Discovery().V1beta1().EndpointSlices().Informer().AddEventHandler(eventHandler)
+		} else {
+			factoryKube.Core().V1().Endpoints().Informer().AddEventHandler(eventHandler)
+		}
 		c.factoriesKube[ns] = factoryKube
 
 		factorySecret := informers.NewSharedInformerFactoryWithOptions(c.clientset, resyncPeriod, informers.WithNamespace(ns), informers.WithTweakListOptions(notOwnedByHelm))
@@ -449,11 +462,31 @@ func (c *clientWrapper) GetEndpoints(namespace, name string) (*corev1.Endpoints,
 		return nil, false, fmt.Errorf("failed to get endpoints %s/%s: namespace is not within watched namespaces", namespace, name)
 	}
 
+	if c.useEndpointSlices {
+		return c.getEndpointsFromSlices(namespace, name)
+	}
+
 	endpoint, err := c.factoriesKube[c.lookupNamespace(namespace)].Core().V1().Endpoints().Lister().Endpoints(namespace).Get(name)
 	exist, err := translateNotFoundError(err)
 	return endpoint, exist, err
 }
 
+// getEndpointsFromSlices returns the endpoints of the named service, built from its EndpointSlices.
+func (c *clientWrapper) getEndpointsFromSlices(namespace, name string) (*corev1.Endpoints, bool, error) {
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1beta1.LabelServiceName: name})
+
+	slices, err := c.factoriesKube[c.lookupNamespace(namespace)].Discovery().V1beta1().EndpointSlices().Lister().EndpointSlices(namespace).List(selector)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(slices) == 0 {
+		return nil, false, nil
+	}
+
+	return k8s.EndpointsFromSlices(namespace, name, slices, c.preferredZone), true, nil
+}
+
 // GetSecret returns the named secret from the given namespace.
 func (c *clientWrapper) GetSecret(namespace, name string) (*corev1.Secret, bool, error) {
 	if !c.isWatchedNamespace(namespace) {
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/api/networking/v1beta1"
 	kubeerror "k8s.io/apimachinery/pkg/api/errors"
@@ -258,3 +259,85 @@ func TestClientUsesCorrectServerVersion(t *testing.T) {
 	case <-time.After(50 * time.Millisecond):
 	}
 }
+
+func TestClientUsesEndpointSlices(t *testing.T) {
+	portName := "http"
+	port := int32(80)
+	slice := &discoveryv1beta1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "whoami-abc",
+			Labels:    map[string]string{discoveryv1beta1.LabelServiceName: "whoami"},
+		},
+		AddressType: discoveryv1beta1.AddressTypeIPv4,
+		Endpoints: []discoveryv1beta1.Endpoint{
+			{Addresses: []string{"10.10.0.1"}},
+		},
+		Ports: []discoveryv1beta1.EndpointPort{{Name: &portName, Port: &port}},
+	}
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "whoami",
+		},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{{IP: "10.10.0.2"}},
+				Ports:     []corev1.EndpointPort{{Name: portName, Port: port}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc          string
+		serverVersion string
+		expectedIP    string
+	}{
+		{
+			desc:          "EndpointSlices are supported",
+			serverVersion: "v1.19",
+			expectedIP:    "10.10.0.1",
+		},
+		{
+			desc:          "EndpointSlices are not supported",
+			serverVersion: "v1.16",
+			expectedIP:    "10.10.0.2",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			kubeClient := kubefake.NewSimpleClientset(slice, endpoints)
+
+			discovery, _ := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+			discovery.FakedServerVersion = &version.Info{
+				GitVersion: test.serverVersion,
+			}
+
+			client := newClientImpl(kubeClient)
+			client.useEndpointSlices = true
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			_, err := client.WatchAll(nil, stopCh)
+			require.NoError(t, err)
+
+			eps, found, err := client.GetEndpoints("default", "whoami")
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Len(t, eps.Subsets, 1)
+			require.Len(t, eps.Subsets[0].Addresses, 1)
+
+			assert.Equal(t, test.expectedIP, eps.Subsets[0].Addresses[0].IP)
+			assert.Equal(t, []corev1.EndpointPort{{Name: portName, Port: port}}, eps.Subsets[0].Ports)
+
+			_, found, err = client.GetEndpoints("default", "unknown")
+			require.NoError(t, err)
+			assert.False(t, found)
+		})
+	}
+}
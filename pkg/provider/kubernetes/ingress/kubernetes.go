@@ -45,6 +45,8 @@ type Provider struct {
 	IngressClass      string           `description:"Value of kubernetes.io/ingress.class annotation or IngressClass name to watch for." json:"ingressClass,omitempty" toml:"ingressClass,omitempty" yaml:"ingressClass,omitempty" export:"true"`
 	IngressEndpoint   *EndpointIngress `description:"Kubernetes Ingress Endpoint." json:"ingressEndpoint,omitempty" toml:"ingressEndpoint,omitempty" yaml:"ingressEndpoint,omitempty" export:"true"`
 	ThrottleDuration  ptypes.Duration  `description:"Ingress refresh throttle duration" json:"throttleDuration,omitempty" toml:"throttleDuration,omitempty" yaml:"throttleDuration,omitempty" export:"true"`
+	UseEndpointSlices bool             `description:"Use the EndpointSlices instead of the Endpoints to discover the servers." json:"useEndpointSlices,omitempty" toml:"useEndpointSlices,omitempty" yaml:"useEndpointSlices,omitempty" export:"true"`
+	PreferredZone     string           `description:"Zone whose endpoints are preferred over the endpoints of other zones (requires useEndpointSlices)." json:"preferredZone,omitempty" toml:"preferredZone,omitempty" yaml:"preferredZone,omitempty" export:"true"`
 	lastConfiguration safe.Safe
 }
 
@@ -88,6 +90,8 @@ func (p *Provider) newK8sClient(ctx context.Context) (*clientWrapper, error) {
 	}
 
 	cl.ingressLabelSelector = p.LabelSelector
+	cl.useEndpointSlices = p.UseEndpointSlices
+	cl.preferredZone = p.PreferredZone
 	return cl, nil
 }
 
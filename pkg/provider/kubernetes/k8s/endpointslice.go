@@ -0,0 +1,120 @@
+package k8s
+
+import (
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SupportsEndpointSlices returns whether the EndpointSlices in discovery.k8s.io/v1beta1 are available,
+// as they are enabled by default starting 1.17.
+func SupportsEndpointSlices(serverVersion *version.Version) bool {
+	endpointSlicesVersion := version.Must(version.NewVersion("1.17"))
+
+	return serverVersion.GreaterThanOrEqual(endpointSlicesVersion)
+}
+
+// EndpointsFromSlices merges the EndpointSlices of a service into an Endpoints object.
+// Only the ready endpoints are kept, and when a preferred zone is given,
+// the endpoints located in this zone are the only ones kept, unless none of them is ready.
+func EndpointsFromSlices(namespace, name string, slices []*discoveryv1beta1.EndpointSlice, preferredZone string) *corev1.Endpoints {
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	// Sorts the slices to get a stable result, the lister order being random.
+	sorted := make([]*discoveryv1beta1.EndpointSlice, len(slices))
+	copy(sorted, slices)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	sameZone := preferredZone != "" && hasReadyEndpointInZone(sorted, preferredZone)
+
+	for _, slice := range sorted {
+		subset := corev1.EndpointSubset{}
+
+		for _, port := range slice.Ports {
+			subset.Ports = append(subset.Ports, toEndpointPort(port))
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			if !isReady(endpoint) || (sameZone && endpointZone(endpoint) != preferredZone) {
+				continue
+			}
+
+			for _, address := range endpoint.Addresses {
+				subset.Addresses = append(subset.Addresses, corev1.EndpointAddress{
+					IP:        address,
+					Hostname:  stringValue(endpoint.Hostname),
+					NodeName:  endpoint.NodeName,
+					TargetRef: endpoint.TargetRef,
+				})
+			}
+		}
+
+		if len(subset.Addresses) == 0 {
+			continue
+		}
+
+		endpoints.Subsets = append(endpoints.Subsets, subset)
+	}
+
+	return endpoints
+}
+
+func hasReadyEndpointInZone(slices []*discoveryv1beta1.EndpointSlice, zone string) bool {
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			if isReady(endpoint) && endpointZone(endpoint) == zone {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isReady returns whether the endpoint is ready, an unknown state being interpreted as ready.
+func isReady(endpoint discoveryv1beta1.Endpoint) bool {
+	return endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+}
+
+func endpointZone(endpoint discoveryv1beta1.Endpoint) string {
+	if zone, ok := endpoint.Topology[corev1.LabelTopologyZone]; ok {
+		return zone
+	}
+
+	return endpoint.Topology[corev1.LabelFailureDomainBetaZone]
+}
+
+func toEndpointPort(port discoveryv1beta1.EndpointPort) corev1.EndpointPort {
+	endpointPort := corev1.EndpointPort{
+		Name:        stringValue(port.Name),
+		AppProtocol: port.AppProtocol,
+	}
+
+	if port.Port != nil {
+		endpointPort.Port = *port.Port
+	}
+
+	if port.Protocol != nil {
+		endpointPort.Protocol = *port.Protocol
+	}
+
+	return endpointPort
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,139 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSupportsEndpointSlices(t *testing.T) {
+	assert.False(t, SupportsEndpointSlices(version.Must(version.NewVersion("v1.16.15"))))
+	assert.True(t, SupportsEndpointSlices(version.Must(version.NewVersion("v1.17.0"))))
+	assert.True(t, SupportsEndpointSlices(version.Must(version.NewVersion("v1.20.2-gke.2500"))))
+}
+
+func TestEndpointsFromSlices(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		slices        []*discoveryv1beta1.EndpointSlice
+		preferredZone string
+		expected      []corev1.EndpointSubset
+	}{
+		{
+			desc: "no slices",
+		},
+		{
+			desc: "ready endpoints only",
+			slices: []*discoveryv1beta1.EndpointSlice{
+				endpointSlice("whoami-abc", "web", 80,
+					endpoint("10.10.0.1", "zone-a", boolPtr(true)),
+					endpoint("10.10.0.2", "zone-a", boolPtr(false)),
+					endpoint("10.10.0.3", "zone-b", nil),
+				),
+			},
+			expected: []corev1.EndpointSubset{
+				{
+					Addresses: []corev1.EndpointAddress{{IP: "10.10.0.1"}, {IP: "10.10.0.3"}},
+					Ports:     []corev1.EndpointPort{{Name: "web", Port: 80, Protocol: corev1.ProtocolTCP}},
+				},
+			},
+		},
+		{
+			desc: "several slices, sorted by name",
+			slices: []*discoveryv1beta1.EndpointSlice{
+				endpointSlice("whoami-def", "web", 80, endpoint("10.10.0.2", "zone-b", nil)),
+				endpointSlice("whoami-abc", "web", 80, endpoint("10.10.0.1", "zone-a", nil)),
+				endpointSlice("whoami-ghi", "web", 80, endpoint("10.10.0.3", "zone-a", boolPtr(false))),
+			},
+			expected: []corev1.EndpointSubset{
+				{
+					Addresses: []corev1.EndpointAddress{{IP: "10.10.0.1"}},
+					Ports:     []corev1.EndpointPort{{Name: "web", Port: 80, Protocol: corev1.ProtocolTCP}},
+				},
+				{
+					Addresses: []corev1.EndpointAddress{{IP: "10.10.0.2"}},
+					Ports:     []corev1.EndpointPort{{Name: "web", Port: 80, Protocol: corev1.ProtocolTCP}},
+				},
+			},
+		},
+		{
+			desc: "preferred zone",
+			slices: []*discoveryv1beta1.EndpointSlice{
+				endpointSlice("whoami-abc", "web", 80,
+					endpoint("10.10.0.1", "zone-a", nil),
+					endpoint("10.10.0.2", "zone-b", nil),
+				),
+				endpointSlice("whoami-def", "web", 80, endpoint("10.10.0.3", "zone-a", nil)),
+			},
+			preferredZone: "zone-b",
+			expected: []corev1.EndpointSubset{
+				{
+					Addresses: []corev1.EndpointAddress{{IP: "10.10.0.2"}},
+					Ports:     []corev1.EndpointPort{{Name: "web", Port: 80, Protocol: corev1.ProtocolTCP}},
+				},
+			},
+		},
+		{
+			desc: "preferred zone without ready endpoints",
+			slices: []*discoveryv1beta1.EndpointSlice{
+				endpointSlice("whoami-abc", "web", 80,
+					endpoint("10.10.0.1", "zone-a", nil),
+					endpoint("10.10.0.2", "zone-b", boolPtr(false)),
+				),
+			},
+			preferredZone: "zone-b",
+			expected: []corev1.EndpointSubset{
+				{
+					Addresses: []corev1.EndpointAddress{{IP: "10.10.0.1"}},
+					Ports:     []corev1.EndpointPort{{Name: "web", Port: 80, Protocol: corev1.ProtocolTCP}},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			endpoints := EndpointsFromSlices("default", "whoami", test.slices, test.preferredZone)
+
+			assert.Equal(t, "default", endpoints.Namespace)
+			assert.Equal(t, "whoami", endpoints.Name)
+			assert.Equal(t, test.expected, endpoints.Subsets)
+		})
+	}
+}
+
+func endpointSlice(name, portName string, port int32, endpoints ...discoveryv1beta1.Endpoint) *discoveryv1beta1.EndpointSlice {
+	protocol := corev1.ProtocolTCP
+
+	return &discoveryv1beta1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    map[string]string{discoveryv1beta1.LabelServiceName: "whoami"},
+		},
+		AddressType: discoveryv1beta1.AddressTypeIPv4,
+		Endpoints:   endpoints,
+		Ports: []discoveryv1beta1.EndpointPort{
+			{Name: &portName, Port: &port, Protocol: &protocol},
+		},
+	}
+}
+
+func endpoint(address, zone string, ready *bool) discoveryv1beta1.Endpoint {
+	return discoveryv1beta1.Endpoint{
+		Addresses:  []string{address},
+		Conditions: discoveryv1beta1.EndpointConditions{Ready: ready},
+		Topology:   map[string]string{corev1.LabelTopologyZone: zone},
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
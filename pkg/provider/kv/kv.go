@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/abronan/valkeyrie"
@@ -24,16 +25,18 @@ import (
 
 // Provider holds configurations of the provider.
 type Provider struct {
-	RootKey string `description:"Root key used for KV store" export:"true" json:"rootKey,omitempty" toml:"rootKey,omitempty" yaml:"rootKey,omitempty"`
+	RootKey    string `description:"Root key used for KV store" export:"true" json:"rootKey,omitempty" toml:"rootKey,omitempty" yaml:"rootKey,omitempty"`
+	VersionKey string `description:"Key holding the configuration version to use, read from the version subtree of the root key. Only the changes of this key are watched." export:"true" json:"versionKey,omitempty" toml:"versionKey,omitempty" yaml:"versionKey,omitempty"`
 
 	Endpoints []string         `description:"KV store endpoints" json:"endpoints,omitempty" toml:"endpoints,omitempty" yaml:"endpoints,omitempty"`
 	Username  string           `description:"KV Username" json:"username,omitempty" toml:"username,omitempty" yaml:"username,omitempty"`
 	Password  string           `description:"KV Password" json:"password,omitempty" toml:"password,omitempty" yaml:"password,omitempty"`
 	TLS       *types.ClientTLS `description:"Enable TLS support" export:"true" json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty"`
 
-	storeType store.Backend
-	kvClient  store.Store
-	name      string
+	storeType      store.Backend
+	kvClient       store.Store
+	name           string
+	currentVersion string
 }
 
 // SetDefaults sets the default values.
@@ -102,6 +105,10 @@ func (p *Provider) Provide(configurationChan chan<- dynamic.Message, pool *safe.
 
 func (p *Provider) watchKv(ctx context.Context, configurationChan chan<- dynamic.Message) error {
 	operation := func() error {
+		if p.VersionKey != "" {
+			return p.watchVersionKey(ctx, configurationChan)
+		}
+
 		events, err := p.kvClient.WatchTree(p.RootKey, ctx.Done(), nil)
 		if err != nil {
 			return fmt.Errorf("failed to watch KV: %w", err)
@@ -143,14 +150,51 @@ func (p *Provider) watchKv(ctx context.Context, configurationChan chan<- dynamic
 	return nil
 }
 
+// watchVersionKey watches the version key only,
+// so that the configuration is rebuilt once the new version subtree has been fully written and the version key switched to it.
+func (p *Provider) watchVersionKey(ctx context.Context, configurationChan chan<- dynamic.Message) error {
+	events, err := p.kvClient.Watch(p.VersionKey, ctx.Done(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to watch KV version key: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-events:
+			if !ok {
+				return errors.New("the Watch channel is closed")
+			}
+
+			configuration, errC := p.buildConfiguration()
+			if errC != nil {
+				return errC
+			}
+
+			if configuration != nil {
+				configurationChan <- dynamic.Message{
+					ProviderName:  p.name,
+					Configuration: configuration,
+				}
+			}
+		}
+	}
+}
+
 func (p *Provider) buildConfiguration() (*dynamic.Configuration, error) {
-	pairs, err := p.kvClient.List(p.RootKey, nil)
+	rootKey, err := p.configurationRootKey()
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := p.kvClient.List(rootKey, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	cfg := &dynamic.Configuration{}
-	err = kv.Decode(pairs, cfg, p.RootKey)
+	err = kv.Decode(pairs, cfg, rootKey)
 	if err != nil {
 		return nil, err
 	}
@@ -158,23 +202,68 @@ func (p *Provider) buildConfiguration() (*dynamic.Configuration, error) {
 	return cfg, nil
 }
 
+// configurationRootKey returns the key of the subtree holding the configuration,
+// which is the version subtree of the root key when a version key is defined.
+func (p *Provider) configurationRootKey() (string, error) {
+	if p.VersionKey == "" {
+		return p.RootKey, nil
+	}
+
+	pair, err := p.kvClient.Get(p.VersionKey, nil)
+	if err != nil {
+		return "", fmt.Errorf("cannot read version key %s: %w", p.VersionKey, err)
+	}
+
+	if pair == nil {
+		return "", fmt.Errorf("version key %s not found", p.VersionKey)
+	}
+
+	version := strings.Trim(strings.TrimSpace(string(pair.Value)), "/")
+	if version == "" {
+		return "", fmt.Errorf("version key %s is empty", p.VersionKey)
+	}
+
+	for _, part := range strings.Split(version, "/") {
+		if part == "." || part == ".." {
+			return "", fmt.Errorf("invalid version %q in version key %s", version, p.VersionKey)
+		}
+	}
+
+	if version != p.currentVersion {
+		log.WithoutContext().WithField(log.ProviderName, p.name).Infof("Using configuration version %s", version)
+		p.currentVersion = version
+	}
+
+	return path.Join(p.RootKey, version), nil
+}
+
 func (p *Provider) createKVClient(ctx context.Context) (store.Store, error) {
+	kvStore, err := NewKVClient(ctx, p.storeType, p.Endpoints, p.Username, p.Password, p.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storeWrapper{Store: kvStore}, nil
+}
+
+// NewKVClient creates a client of a KV store of the given type.
+func NewKVClient(ctx context.Context, storeType store.Backend, endpoints []string, username, password string, clientTLS *types.ClientTLS) (store.Store, error) {
 	storeConfig := &store.Config{
 		ConnectionTimeout: 3 * time.Second,
 		Bucket:            "traefik",
-		Username:          p.Username,
-		Password:          p.Password,
+		Username:          username,
+		Password:          password,
 	}
 
-	if p.TLS != nil {
+	if clientTLS != nil {
 		var err error
-		storeConfig.TLS, err = p.TLS.CreateTLSConfig(ctx)
+		storeConfig.TLS, err = clientTLS.CreateTLSConfig(ctx)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	switch p.storeType {
+	switch storeType {
 	case store.CONSUL:
 		consul.Register()
 	case store.ETCDV3:
@@ -185,10 +274,5 @@ func (p *Provider) createKVClient(ctx context.Context) (store.Store, error) {
 		redis.Register()
 	}
 
-	kvStore, err := valkeyrie.NewStore(p.storeType, p.Endpoints, storeConfig)
-	if err != nil {
-		return nil, err
-	}
-
-	return &storeWrapper{Store: kvStore}, nil
+	return valkeyrie.NewStore(storeType, endpoints, storeConfig)
 }
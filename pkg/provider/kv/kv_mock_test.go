@@ -25,6 +25,7 @@ type Mock struct {
 	Error           KvError
 	KVPairs         []*store.KVPair
 	WatchTreeMethod func() <-chan []*store.KVPair
+	WatchMethod     func() <-chan *store.KVPair
 }
 
 func newKvClientMock(kvPairs []*store.KVPair, err error) *Mock {
@@ -76,7 +77,10 @@ func (s *Mock) Exists(key string, options *store.ReadOptions) (bool, error) {
 
 // Watch mock.
 func (s *Mock) Watch(key string, stopCh <-chan struct{}, options *store.ReadOptions) (<-chan *store.KVPair, error) {
-	return nil, errors.New("method Watch not supported")
+	if s.WatchMethod == nil {
+		return nil, errors.New("method Watch not supported")
+	}
+	return s.WatchMethod(), nil
 }
 
 // WatchTree mock.
@@ -116,6 +116,7 @@ func Test_buildConfiguration(t *testing.T) {
 		"traefik/http/middlewares/Middleware09/headers/stsPreload":                                   "true",
 		"traefik/http/middlewares/Middleware09/headers/frameDeny":                                    "true",
 		"traefik/http/middlewares/Middleware09/headers/isDevelopment":                                "true",
+		"traefik/http/middlewares/Middleware09/headers/ja3Header":                                    "foobar",
 		"traefik/http/middlewares/Middleware09/headers/customResponseHeaders/name1":                  "foobar",
 		"traefik/http/middlewares/Middleware09/headers/customResponseHeaders/name0":                  "foobar",
 		"traefik/http/middlewares/Middleware09/headers/accessControlAllowMethods/0":                  "foobar",
@@ -545,6 +546,7 @@ func Test_buildConfiguration(t *testing.T) {
 							"name0": "foobar",
 							"name1": "foobar",
 						},
+						JA3Header:                     "foobar",
 						AccessControlAllowCredentials: true,
 						AccessControlAllowHeaders: []string{
 							"foobar",
@@ -948,6 +950,129 @@ func TestKvWatchTree(t *testing.T) {
 	}
 }
 
+func Test_buildConfiguration_versionKey(t *testing.T) {
+	provider := newProviderMock(mapToPairs(map[string]string{
+		"traefik/active":                        "v2",
+		"traefik/v1/http/routers/foo/rule":      "Host(`v1.localhost`)",
+		"traefik/v1/http/routers/foo/service":   "foo",
+		"traefik/v2/http/routers/foo/rule":      "Host(`v2.localhost`)",
+		"traefik/v2/http/routers/foo/service":   "foo",
+		"traefik/v2/http/routers/bar/rule":      "Host(`bar.localhost`)",
+		"traefik/v2/http/routers/bar/service":   "bar",
+		"traefik/v20/http/routers/baz/rule":     "Host(`baz.localhost`)",
+		"traefik/v20/http/routers/baz/service":  "baz",
+		"traefik/http/routers/unversioned/rule": "Host(`unversioned.localhost`)",
+	}))
+	provider.VersionKey = "traefik/active"
+
+	cfg, err := provider.buildConfiguration()
+	require.NoError(t, err)
+
+	expected := map[string]*dynamic.Router{
+		"foo": {Rule: "Host(`v2.localhost`)", Service: "foo"},
+		"bar": {Rule: "Host(`bar.localhost`)", Service: "bar"},
+	}
+	assert.Equal(t, expected, cfg.HTTP.Routers)
+}
+
+func Test_buildConfiguration_versionKey_error(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		pairs map[string]string
+	}{
+		{
+			desc: "missing version key",
+			pairs: map[string]string{
+				"traefik/v1/http/routers/foo/rule": "Host(`v1.localhost`)",
+			},
+		},
+		{
+			desc: "empty version",
+			pairs: map[string]string{
+				"traefik/active": " ",
+			},
+		},
+		{
+			desc: "version outside of the root key",
+			pairs: map[string]string{
+				"traefik/active": "../other",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			provider := newProviderMock(mapToPairs(test.pairs))
+			provider.VersionKey = "traefik/active"
+
+			cfg, err := provider.buildConfiguration()
+			require.Error(t, err)
+			assert.Nil(t, cfg)
+		})
+	}
+}
+
+func TestKvWatchVersionKey(t *testing.T) {
+	versions := make(chan *store.KVPair, 10)
+
+	mock := &Mock{
+		KVPairs: mapToPairs(map[string]string{
+			"traefik/active":                   "v1",
+			"traefik/v1/http/routers/foo/rule": "Host(`v1.localhost`)",
+			"traefik/v2/http/routers/foo/rule": "Host(`v2.localhost`)",
+		}),
+		WatchTreeMethod: func() <-chan []*store.KVPair {
+			t.Error("the tree must not be watched when a version key is defined")
+			return nil
+		},
+		WatchMethod: func() <-chan *store.KVPair {
+			return versions
+		},
+	}
+
+	provider := Provider{
+		RootKey:    "traefik",
+		VersionKey: "traefik/active",
+		kvClient:   mock,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configChan := make(chan dynamic.Message)
+	go func() {
+		err := provider.watchKv(ctx, configChan)
+		require.NoError(t, err)
+	}()
+
+	versions <- &store.KVPair{Key: "traefik/active", Value: []byte("v1")}
+
+	select {
+	case msg := <-configChan:
+		assert.Equal(t, "Host(`v1.localhost`)", msg.Configuration.HTTP.Routers["foo"].Rule)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout while waiting for config")
+	}
+
+	// Switches (or rolls back) the version by updating the version key only.
+	for _, pair := range mock.KVPairs {
+		if pair.Key == "traefik/active" {
+			pair.Value = []byte("v2")
+		}
+	}
+	versions <- &store.KVPair{Key: "traefik/active", Value: []byte("v2")}
+
+	select {
+	case msg := <-configChan:
+		assert.Equal(t, "Host(`v2.localhost`)", msg.Configuration.HTTP.Routers["foo"].Rule)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout while waiting for config")
+	}
+}
+
 func mapToPairs(in map[string]string) []*store.KVPair {
 	var out []*store.KVPair
 	for k, v := range in {
@@ -183,12 +183,13 @@ func (i *Provider) getEntryPointPort(name string, def *static.Redirections) (str
 
 func (i *Provider) entryPointModels(cfg *dynamic.Configuration) {
 	for name, ep := range i.staticCfg.EntryPoints {
-		if len(ep.HTTP.Middlewares) == 0 && ep.HTTP.TLS == nil {
+		if len(ep.HTTP.Middlewares) == 0 && ep.HTTP.TLS == nil && ep.HTTP.AccessLog == nil {
 			continue
 		}
 
 		m := &dynamic.Model{
 			Middlewares: ep.HTTP.Middlewares,
+			AccessLog:   ep.HTTP.AccessLog,
 		}
 
 		if ep.HTTP.TLS != nil {
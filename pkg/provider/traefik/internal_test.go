@@ -188,6 +188,23 @@ func Test_createConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "models_access_log.json",
+			staticCfg: static.Configuration{
+				EntryPoints: map[string]*static.EntryPoint{
+					"web": {
+						HTTP: static.HTTPConfig{
+							AccessLog: &types.AccessLogPolicy{
+								SampleRate: 0.1,
+								Filters: &types.AccessLogFilters{
+									StatusCodes: []string{"500-599"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			desc: "redirection.json",
 			staticCfg: static.Configuration{
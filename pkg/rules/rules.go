@@ -9,6 +9,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/traefik/traefik/v2/pkg/log"
 	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
+	"github.com/traefik/traefik/v2/pkg/tls/ja3"
 	"github.com/vulcand/predicate"
 )
 
@@ -22,6 +23,7 @@ var funcs = map[string]func(*mux.Route, ...string) error{
 	"Headers":       headers,
 	"HeadersRegexp": headersRegexp,
 	"Query":         query,
+	"JA3":           clientJA3,
 }
 
 // Router handle routing with rules.
@@ -192,6 +194,27 @@ func query(route *mux.Route, query ...string) error {
 	return route.GetError()
 }
 
+func clientJA3(route *mux.Route, fingerprints ...string) error {
+	for i, fingerprint := range fingerprints {
+		fingerprints[i] = strings.ToLower(fingerprint)
+	}
+
+	route.MatcherFunc(func(req *http.Request, _ *mux.RouteMatch) bool {
+		reqFingerprint := ja3.FromContext(req.Context())
+		if reqFingerprint == "" {
+			return false
+		}
+
+		for _, fingerprint := range fingerprints {
+			if reqFingerprint == fingerprint {
+				return true
+			}
+		}
+		return false
+	})
+	return nil
+}
+
 func addRuleOnRouter(router *mux.Router, rule *tree) error {
 	switch rule.matcher {
 	case "and":
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
 	"github.com/traefik/traefik/v2/pkg/testhelpers"
+	"github.com/traefik/traefik/v2/pkg/tls/ja3"
 )
 
 func Test_addRoute(t *testing.T) {
@@ -696,6 +697,70 @@ func TestHostRegexp(t *testing.T) {
 	}
 }
 
+func TestClientJA3(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		rule         string
+		fingerprint  string
+		expectedCode int
+	}{
+		{
+			desc:         "matching fingerprint",
+			rule:         "JA3(`851235d5e9d490f3e2b43db94ac71961`)",
+			fingerprint:  "851235d5e9d490f3e2b43db94ac71961",
+			expectedCode: http.StatusOK,
+		},
+		{
+			desc:         "matching fingerprint among several",
+			rule:         "JA3(`e7d705a3286e19ea42f587b344ee6865`, `851235D5E9D490F3E2B43DB94AC71961`)",
+			fingerprint:  "851235d5e9d490f3e2b43db94ac71961",
+			expectedCode: http.StatusOK,
+		},
+		{
+			desc:         "not matching fingerprint",
+			rule:         "JA3(`e7d705a3286e19ea42f587b344ee6865`)",
+			fingerprint:  "851235d5e9d490f3e2b43db94ac71961",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			desc:         "without fingerprint",
+			rule:         "JA3(`851235d5e9d490f3e2b43db94ac71961`)",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			desc:         "fingerprint and host",
+			rule:         "Host(`foo`) && JA3(`851235d5e9d490f3e2b43db94ac71961`)",
+			fingerprint:  "851235d5e9d490f3e2b43db94ac71961",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			router, err := NewRouter()
+			require.NoError(t, err)
+
+			err = router.AddRoute(test.rule, 0, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			require.NoError(t, err)
+
+			// RequestDecorator is necessary for the host rule
+			reqHost := requestdecorator.New(nil)
+
+			w := httptest.NewRecorder()
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://foo/", nil)
+			if test.fingerprint != "" {
+				req = req.WithContext(ja3.WithFingerprint(req.Context(), test.fingerprint))
+			}
+
+			reqHost.ServeHTTP(w, req, router.ServeHTTP)
+			assert.Equal(t, test.expectedCode, w.Code)
+		})
+	}
+}
+
 func TestParseDomains(t *testing.T) {
 	testCases := []struct {
 		description   string
@@ -83,7 +83,9 @@ func mergeConfiguration(configurations dynamic.Configurations, defaultEntryPoint
 					continue
 				}
 
-				conf.TLS.Certificates = append(conf.TLS.Certificates, cert)
+				certificate := *cert
+				certificate.Provider = pvd
+				conf.TLS.Certificates = append(conf.TLS.Certificates, &certificate)
 			}
 
 			for key, store := range configuration.TLS.Stores {
@@ -148,6 +150,10 @@ func applyModel(cfg dynamic.Configuration) dynamic.Configuration {
 					cp.TLS = m.TLS
 				}
 
+				if cp.AccessLog == nil {
+					cp.AccessLog = m.AccessLog
+				}
+
 				cp.Middlewares = append(m.Middlewares, cp.Middlewares...)
 
 				rtName := name
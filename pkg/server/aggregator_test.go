@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/tls"
+	"github.com/traefik/traefik/v2/pkg/types"
 )
 
 func Test_mergeConfiguration(t *testing.T) {
@@ -157,6 +158,7 @@ func Test_mergeConfiguration_tlsCertificates(t *testing.T) {
 			expected: []*tls.CertAndStores{{
 				Certificate: tls.Certificate{CertFile: "foo", KeyFile: "bar"},
 				Stores:      []string{tlsalpn01.ACMETLS1Protocol},
+				Provider:    "tlsalpn.acme",
 			}},
 		},
 	}
@@ -587,6 +589,50 @@ func Test_applyModel(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with model, one entry point, and access log policies",
+			input: dynamic.Configuration{
+				HTTP: &dynamic.HTTPConfiguration{
+					Routers: map[string]*dynamic.Router{
+						"test": {
+							EntryPoints: []string{"websecure"},
+						},
+						"payment": {
+							EntryPoints: []string{"websecure"},
+							AccessLog:   &types.AccessLogPolicy{SampleRate: 1},
+						},
+					},
+					Middlewares: make(map[string]*dynamic.Middleware),
+					Services:    make(map[string]*dynamic.Service),
+					Models: map[string]*dynamic.Model{
+						"websecure@internal": {
+							AccessLog: &types.AccessLogPolicy{SampleRate: 0.1},
+						},
+					},
+				},
+			},
+			expected: dynamic.Configuration{
+				HTTP: &dynamic.HTTPConfiguration{
+					Routers: map[string]*dynamic.Router{
+						"test": {
+							EntryPoints: []string{"websecure"},
+							AccessLog:   &types.AccessLogPolicy{SampleRate: 0.1},
+						},
+						"payment": {
+							EntryPoints: []string{"websecure"},
+							AccessLog:   &types.AccessLogPolicy{SampleRate: 1},
+						},
+					},
+					Middlewares: make(map[string]*dynamic.Middleware),
+					Services:    make(map[string]*dynamic.Service),
+					Models: map[string]*dynamic.Model{
+						"websecure@internal": {
+							AccessLog: &types.AccessLogPolicy{SampleRate: 0.1},
+						},
+					},
+				},
+			},
+		},
 		{
 			desc: "with model, two entry points",
 			input: dynamic.Configuration{
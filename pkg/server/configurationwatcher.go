@@ -12,8 +12,12 @@ import (
 	"github.com/traefik/traefik/v2/pkg/log"
 	"github.com/traefik/traefik/v2/pkg/provider"
 	"github.com/traefik/traefik/v2/pkg/safe"
+	"github.com/traefik/traefik/v2/pkg/tls"
 )
 
+// redactedValue replaces the private and session ticket keys inlined in the configurations logged at debug level.
+const redactedValue = "xxxx"
+
 // ConfigurationWatcher watches configuration changes.
 type ConfigurationWatcher struct {
 	provider provider.Provider
@@ -161,15 +165,7 @@ func (c *ConfigurationWatcher) preLoadConfiguration(configMsg dynamic.Message) {
 	logger := log.WithoutContext().WithField(log.ProviderName, configMsg.ProviderName)
 	if log.GetLevel() == logrus.DebugLevel {
 		copyConf := configMsg.Configuration.DeepCopy()
-		if copyConf.TLS != nil {
-			copyConf.TLS.Certificates = nil
-
-			for k := range copyConf.TLS.Stores {
-				st := copyConf.TLS.Stores[k]
-				st.DefaultCertificate = nil
-				copyConf.TLS.Stores[k] = st
-			}
-		}
+		redactSecrets(copyConf)
 
 		jsonConf, err := json.Marshal(copyConf)
 		if err != nil {
@@ -197,6 +193,54 @@ func (c *ConfigurationWatcher) preLoadConfiguration(configMsg dynamic.Message) {
 	providerConfigUpdateCh <- configMsg
 }
 
+// redactSecrets removes the certificates, private keys, and session ticket keys from the configuration, before it is logged.
+func redactSecrets(conf *dynamic.Configuration) {
+	if conf == nil {
+		return
+	}
+
+	if conf.TLS != nil {
+		conf.TLS.Certificates = nil
+
+		for k := range conf.TLS.Stores {
+			st := conf.TLS.Stores[k]
+			st.DefaultCertificate = nil
+			conf.TLS.Stores[k] = st
+		}
+
+		for _, options := range conf.TLS.Options {
+			if options.SessionTickets == nil {
+				continue
+			}
+
+			for i := range options.SessionTickets.KeyFiles {
+				options.SessionTickets.KeyFiles[i] = redactKey(options.SessionTickets.KeyFiles[i])
+			}
+		}
+	}
+
+	if conf.HTTP != nil {
+		for _, serversTransport := range conf.HTTP.ServersTransports {
+			if serversTransport == nil {
+				continue
+			}
+
+			for i := range serversTransport.Certificates {
+				serversTransport.Certificates[i].KeyFile = redactKey(serversTransport.Certificates[i].KeyFile)
+			}
+		}
+	}
+}
+
+// redactKey returns the given reference to a private key, redacted unless it is a file path.
+func redactKey(key tls.FileOrContent) tls.FileOrContent {
+	if key == "" || key.IsPath() {
+		return key
+	}
+
+	return redactedValue
+}
+
 // throttleProviderConfigReload throttles the configuration reload speed for a single provider.
 // It will immediately publish a new configuration and then only publish the next configuration after the throttle duration.
 // Note that in the case it receives N new configs in the timeframe of the throttle duration after publishing,
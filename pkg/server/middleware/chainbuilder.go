@@ -52,6 +52,11 @@ func (c *ChainBuilder) Build(ctx context.Context, entryPointName string) alice.C
 	return chain.Append(requestdecorator.WrapHandler(c.requestDecorator))
 }
 
+// Tracer returns the tracer of the chains, nil if tracing is disabled.
+func (c *ChainBuilder) Tracer() *tracing.Tracing {
+	return c.tracer
+}
+
 // Close accessLogger and tracer.
 func (c *ChainBuilder) Close() {
 	if c.accessLoggerMiddleware != nil {
@@ -116,6 +121,14 @@ func setupTracing(conf *static.Tracing) *tracing.Tracing {
 		}
 	}
 
+	if conf.OpenTelemetry != nil {
+		if backend != nil {
+			log.WithoutContext().Error("Multiple tracing backend are not supported: cannot create OpenTelemetry backend.")
+		} else {
+			backend = conf.OpenTelemetry
+		}
+	}
+
 	if backend == nil {
 		log.WithoutContext().Debug("Could not initialize tracing, using Jaeger by default")
 		defaultBackend := &jaeger.Config{}
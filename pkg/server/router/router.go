@@ -8,7 +8,9 @@ import (
 	"github.com/containous/alice"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/metrics"
 	"github.com/traefik/traefik/v2/pkg/middlewares/accesslog"
+	metricsMiddle "github.com/traefik/traefik/v2/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v2/pkg/middlewares/recovery"
 	"github.com/traefik/traefik/v2/pkg/middlewares/tracing"
 	"github.com/traefik/traefik/v2/pkg/rules"
@@ -27,21 +29,25 @@ type serviceManager interface {
 
 // Manager A route/router manager.
 type Manager struct {
-	routerHandlers     map[string]http.Handler
-	serviceManager     serviceManager
-	middlewaresBuilder middlewareBuilder
-	chainBuilder       *middleware.ChainBuilder
-	conf               *runtime.Configuration
+	routerHandlers         map[string]http.Handler
+	serviceManager         serviceManager
+	middlewaresBuilder     middlewareBuilder
+	chainBuilder           *middleware.ChainBuilder
+	conf                   *runtime.Configuration
+	metricsRegistry        metrics.Registry
+	accessLoggerMiddleware *accesslog.Handler
 }
 
 // NewManager Creates a new Manager.
-func NewManager(conf *runtime.Configuration, serviceManager serviceManager, middlewaresBuilder middlewareBuilder, chainBuilder *middleware.ChainBuilder) *Manager {
+func NewManager(conf *runtime.Configuration, serviceManager serviceManager, middlewaresBuilder middlewareBuilder, chainBuilder *middleware.ChainBuilder, metricsRegistry metrics.Registry, accessLoggerMiddleware *accesslog.Handler) *Manager {
 	return &Manager{
-		routerHandlers:     make(map[string]http.Handler),
-		serviceManager:     serviceManager,
-		middlewaresBuilder: middlewaresBuilder,
-		chainBuilder:       chainBuilder,
-		conf:               conf,
+		routerHandlers:         make(map[string]http.Handler),
+		serviceManager:         serviceManager,
+		middlewaresBuilder:     middlewaresBuilder,
+		chainBuilder:           chainBuilder,
+		conf:                   conf,
+		metricsRegistry:        metricsRegistry,
+		accessLoggerMiddleware: accessLoggerMiddleware,
 	}
 }
 
@@ -142,6 +148,11 @@ func (m *Manager) buildRouterHandler(ctx context.Context, routerName string, rou
 		return nil, err
 	}
 
+	handler, err = accesslog.NewPolicyHandler(m.accessLoggerMiddleware, handler, routerConfig.AccessLog)
+	if err != nil {
+		return nil, err
+	}
+
 	handlerWithAccessLog, err := alice.New(func(next http.Handler) (http.Handler, error) {
 		return accesslog.NewFieldHandler(next, accesslog.RouterName, routerName, nil), nil
 	}).Then(handler)
@@ -177,7 +188,13 @@ func (m *Manager) buildHTTPHandler(ctx context.Context, router *runtime.RouterIn
 		return tracing.NewForwarder(ctx, routerName, router.Service, next), nil
 	}
 
-	return alice.New().Extend(*mHandler).Append(tHandler).Then(sHandler)
+	chain := alice.New()
+
+	if m.metricsRegistry != nil && m.metricsRegistry.IsRouterEnabled() {
+		chain = chain.Append(metricsMiddle.WrapRouterHandler(ctx, m.metricsRegistry, routerName, provider.GetQualifiedName(ctx, router.Service)))
+	}
+
+	return chain.Extend(*mHandler).Append(tHandler).Then(sHandler)
 }
 
 // BuildDefaultHTTPRouter creates a default HTTP router.
@@ -11,11 +11,16 @@ import (
 
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/metrics"
+	"github.com/traefik/traefik/v2/pkg/middlewares/accesslog"
+	metricsMiddle "github.com/traefik/traefik/v2/pkg/middlewares/metrics"
+	mTracing "github.com/traefik/traefik/v2/pkg/middlewares/tracing"
 	"github.com/traefik/traefik/v2/pkg/rules"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
 	tcpservice "github.com/traefik/traefik/v2/pkg/server/service/tcp"
 	"github.com/traefik/traefik/v2/pkg/tcp"
 	traefiktls "github.com/traefik/traefik/v2/pkg/tls"
+	"github.com/traefik/traefik/v2/pkg/tracing"
 )
 
 const (
@@ -29,23 +34,32 @@ func NewManager(conf *runtime.Configuration,
 	httpHandlers map[string]http.Handler,
 	httpsHandlers map[string]http.Handler,
 	tlsManager *traefiktls.Manager,
+	metricsRegistry metrics.Registry,
+	accessLoggerMiddleware *accesslog.Handler,
+	tracer *tracing.Tracing,
 ) *Manager {
 	return &Manager{
-		serviceManager: serviceManager,
-		httpHandlers:   httpHandlers,
-		httpsHandlers:  httpsHandlers,
-		tlsManager:     tlsManager,
-		conf:           conf,
+		serviceManager:         serviceManager,
+		httpHandlers:           httpHandlers,
+		httpsHandlers:          httpsHandlers,
+		tlsManager:             tlsManager,
+		conf:                   conf,
+		metricsRegistry:        metricsRegistry,
+		accessLoggerMiddleware: accessLoggerMiddleware,
+		tracer:                 tracer,
 	}
 }
 
 // Manager is a route/router manager.
 type Manager struct {
-	serviceManager *tcpservice.Manager
-	httpHandlers   map[string]http.Handler
-	httpsHandlers  map[string]http.Handler
-	tlsManager     *traefiktls.Manager
-	conf           *runtime.Configuration
+	serviceManager         *tcpservice.Manager
+	httpHandlers           map[string]http.Handler
+	httpsHandlers          map[string]http.Handler
+	tlsManager             *traefiktls.Manager
+	conf                   *runtime.Configuration
+	metricsRegistry        metrics.Registry
+	accessLoggerMiddleware *accesslog.Handler
+	tracer                 *tracing.Tracing
 }
 
 func (m *Manager) getTCPRouters(ctx context.Context, entryPoints []string) map[string]map[string]*runtime.TCPRouterInfo {
@@ -77,7 +91,7 @@ func (m *Manager) BuildHandlers(rootCtx context.Context, entryPoints []string) m
 
 		ctx := log.With(rootCtx, log.Str(log.EntryPointName, entryPointName))
 
-		handler, err := m.buildEntryPointHandler(ctx, routers, entryPointsRoutersHTTP[entryPointName], m.httpHandlers[entryPointName], m.httpsHandlers[entryPointName])
+		handler, err := m.buildEntryPointHandler(ctx, entryPointName, routers, entryPointsRoutersHTTP[entryPointName], m.httpHandlers[entryPointName], m.httpsHandlers[entryPointName])
 		if err != nil {
 			log.FromContext(ctx).Error(err)
 			continue
@@ -92,7 +106,7 @@ type nameAndConfig struct {
 	TLSConfig  *tls.Config
 }
 
-func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string]*runtime.TCPRouterInfo, configsHTTP map[string]*runtime.RouterInfo, handlerHTTP, handlerHTTPS http.Handler) (*tcp.Router, error) {
+func (m *Manager) buildEntryPointHandler(ctx context.Context, entryPointName string, configs map[string]*runtime.TCPRouterInfo, configsHTTP map[string]*runtime.RouterInfo, handlerHTTP, handlerHTTPS http.Handler) (*tcp.Router, error) {
 	router := &tcp.Router{}
 	router.HTTPHandler(handlerHTTP)
 
@@ -246,6 +260,23 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string
 			continue
 		}
 
+		if m.tracer != nil {
+			handler = mTracing.NewTCPRouter(ctxRouter, m.tracer, entryPointName, routerName, provider.GetQualifiedName(ctxRouter, routerConfig.Service), handler)
+		}
+
+		if m.metricsRegistry != nil && m.metricsRegistry.IsRouterEnabled() {
+			handler = metricsMiddle.NewTCPRouterHandler(ctxRouter, handler, m.metricsRegistry, routerName, provider.GetQualifiedName(ctxRouter, routerConfig.Service))
+		}
+
+		if m.accessLoggerMiddleware != nil {
+			handler, err = accesslog.NewTCPHandler(m.accessLoggerMiddleware, handler, routerName, provider.GetQualifiedName(ctxRouter, routerConfig.Service), routerConfig.AccessLog)
+			if err != nil {
+				routerConfig.AddError(err, true)
+				logger.Error(err)
+				continue
+			}
+		}
+
 		domains, err := rules.ParseHostSNI(routerConfig.Rule)
 		if err != nil {
 			routerErr := fmt.Errorf("unknown rule %s", routerConfig.Rule)
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/metrics"
 	"github.com/traefik/traefik/v2/pkg/server/service/tcp"
 	traefiktls "github.com/traefik/traefik/v2/pkg/tls"
 )
@@ -284,7 +285,7 @@ func TestRuntimeConfiguration(t *testing.T) {
 				TCPServices: test.tcpServiceConfig,
 				TCPRouters:  test.tcpRouterConfig,
 			}
-			serviceManager := tcp.NewManager(conf)
+			serviceManager := tcp.NewManager(conf, metrics.NewVoidRegistry())
 			tlsManager := traefiktls.NewManager()
 			tlsManager.UpdateConfigs(
 				context.Background(),
@@ -303,7 +304,7 @@ func TestRuntimeConfiguration(t *testing.T) {
 				[]*traefiktls.CertAndStores{})
 
 			routerManager := NewManager(conf, serviceManager,
-				nil, nil, tlsManager)
+				nil, nil, tlsManager, metrics.NewVoidRegistry(), nil, nil)
 
 			_ = routerManager.BuildHandlers(context.Background(), entryPoints)
 
@@ -523,7 +524,7 @@ func TestDomainFronting(t *testing.T) {
 				Routers: test.routers,
 			}
 
-			serviceManager := tcp.NewManager(conf)
+			serviceManager := tcp.NewManager(conf, metrics.NewVoidRegistry())
 
 			tlsManager := traefiktls.NewManager()
 			tlsManager.UpdateConfigs(context.Background(), map[string]traefiktls.Store{}, tlsOptions, []*traefiktls.CertAndStores{})
@@ -532,7 +533,7 @@ func TestDomainFronting(t *testing.T) {
 				"web": http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}),
 			}
 
-			routerManager := NewManager(conf, serviceManager, nil, httpsHandler, tlsManager)
+			routerManager := NewManager(conf, serviceManager, nil, httpsHandler, tlsManager, metrics.NewVoidRegistry(), nil, nil)
 
 			routers := routerManager.BuildHandlers(context.Background(), entryPoints)
 
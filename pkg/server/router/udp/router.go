@@ -7,25 +7,39 @@ import (
 
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/metrics"
+	"github.com/traefik/traefik/v2/pkg/middlewares/accesslog"
+	metricsMiddle "github.com/traefik/traefik/v2/pkg/middlewares/metrics"
+	mTracing "github.com/traefik/traefik/v2/pkg/middlewares/tracing"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
 	udpservice "github.com/traefik/traefik/v2/pkg/server/service/udp"
+	"github.com/traefik/traefik/v2/pkg/tracing"
 	"github.com/traefik/traefik/v2/pkg/udp"
 )
 
 // NewManager Creates a new Manager.
 func NewManager(conf *runtime.Configuration,
 	serviceManager *udpservice.Manager,
+	metricsRegistry metrics.Registry,
+	accessLoggerMiddleware *accesslog.Handler,
+	tracer *tracing.Tracing,
 ) *Manager {
 	return &Manager{
-		serviceManager: serviceManager,
-		conf:           conf,
+		serviceManager:         serviceManager,
+		conf:                   conf,
+		metricsRegistry:        metricsRegistry,
+		accessLoggerMiddleware: accessLoggerMiddleware,
+		tracer:                 tracer,
 	}
 }
 
 // Manager is a route/router manager.
 type Manager struct {
-	serviceManager *udpservice.Manager
-	conf           *runtime.Configuration
+	serviceManager         *udpservice.Manager
+	conf                   *runtime.Configuration
+	metricsRegistry        metrics.Registry
+	accessLoggerMiddleware *accesslog.Handler
+	tracer                 *tracing.Tracing
 }
 
 func (m *Manager) getUDPRouters(ctx context.Context, entryPoints []string) map[string]map[string]*runtime.UDPRouterInfo {
@@ -52,7 +66,7 @@ func (m *Manager) BuildHandlers(rootCtx context.Context, entryPoints []string) m
 			log.FromContext(ctx).Warn("Config has more than one udp router for a given entrypoint.")
 		}
 
-		handlers, err := m.buildEntryPointHandlers(ctx, routers)
+		handlers, err := m.buildEntryPointHandlers(ctx, entryPointName, routers)
 		if err != nil {
 			log.FromContext(ctx).Error(err)
 			continue
@@ -66,7 +80,7 @@ func (m *Manager) BuildHandlers(rootCtx context.Context, entryPoints []string) m
 	return entryPointHandlers
 }
 
-func (m *Manager) buildEntryPointHandlers(ctx context.Context, configs map[string]*runtime.UDPRouterInfo) ([]udp.Handler, error) {
+func (m *Manager) buildEntryPointHandlers(ctx context.Context, entryPointName string, configs map[string]*runtime.UDPRouterInfo) ([]udp.Handler, error) {
 	var rtNames []string
 	for routerName := range configs {
 		rtNames = append(rtNames, routerName)
@@ -98,6 +112,23 @@ func (m *Manager) buildEntryPointHandlers(ctx context.Context, configs map[strin
 			continue
 		}
 
+		if m.tracer != nil {
+			handler = mTracing.NewUDPRouter(ctxRouter, m.tracer, entryPointName, routerName, provider.GetQualifiedName(ctxRouter, routerConfig.Service), handler)
+		}
+
+		if m.metricsRegistry != nil && m.metricsRegistry.IsRouterEnabled() {
+			handler = metricsMiddle.NewUDPRouterHandler(ctxRouter, handler, m.metricsRegistry, routerName, provider.GetQualifiedName(ctxRouter, routerConfig.Service))
+		}
+
+		if m.accessLoggerMiddleware != nil {
+			handler, err = accesslog.NewUDPHandler(m.accessLoggerMiddleware, handler, routerName, provider.GetQualifiedName(ctxRouter, routerConfig.Service), routerConfig.AccessLog)
+			if err != nil {
+				routerConfig.AddError(err, true)
+				logger.Error(err)
+				continue
+			}
+		}
+
 		handlers = append(handlers, handler)
 	}
 
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/metrics"
 	"github.com/traefik/traefik/v2/pkg/server/service/udp"
 )
 
@@ -119,8 +120,8 @@ func TestRuntimeConfiguration(t *testing.T) {
 				UDPServices: test.serviceConfig,
 				UDPRouters:  test.routerConfig,
 			}
-			serviceManager := udp.NewManager(conf)
-			routerManager := NewManager(conf, serviceManager)
+			serviceManager := udp.NewManager(conf, metrics.NewVoidRegistry())
+			routerManager := NewManager(conf, serviceManager, metrics.NewVoidRegistry(), nil, nil)
 
 			_ = routerManager.BuildHandlers(context.Background(), entryPoints)
 
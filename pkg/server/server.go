@@ -111,4 +111,5 @@ func stopMetricsClients() {
 	metrics.StopDatadog()
 	metrics.StopStatsd()
 	metrics.StopInfluxDB()
+	metrics.StopOpenTelemetry()
 }
@@ -16,11 +16,16 @@ import (
 	"github.com/traefik/traefik/v2/pkg/config/static"
 	"github.com/traefik/traefik/v2/pkg/ip"
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/metrics"
 	"github.com/traefik/traefik/v2/pkg/middlewares"
 	"github.com/traefik/traefik/v2/pkg/middlewares/forwardedheaders"
+	metricsMiddle "github.com/traefik/traefik/v2/pkg/middlewares/metrics"
+	mTracing "github.com/traefik/traefik/v2/pkg/middlewares/tracing"
 	"github.com/traefik/traefik/v2/pkg/safe"
 	"github.com/traefik/traefik/v2/pkg/server/router"
 	"github.com/traefik/traefik/v2/pkg/tcp"
+	"github.com/traefik/traefik/v2/pkg/tls/ja3"
+	"github.com/traefik/traefik/v2/pkg/tracing"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 )
@@ -31,6 +36,9 @@ type httpForwarder struct {
 	net.Listener
 	connChan chan net.Conn
 	errChan  chan error
+
+	// fingerprints holds the JA3 fingerprints of the served TLS connections, until their context is created.
+	fingerprints sync.Map
 }
 
 func newHTTPForwarder(ln net.Listener) *httpForwarder {
@@ -43,9 +51,25 @@ func newHTTPForwarder(ln net.Listener) *httpForwarder {
 
 // ServeTCP uses the connection to serve it later in "Accept".
 func (h *httpForwarder) ServeTCP(conn tcp.WriteCloser) {
+	// The wrapping chain of the connection is only known while it is being served.
+	if fingerprint := tcp.GetJA3(conn); fingerprint != "" {
+		h.fingerprints.Store(conn, fingerprint)
+	}
+
 	h.connChan <- conn
 }
 
+// connContext adds the JA3 fingerprint of the TLS ClientHello of the connection to its context.
+func (h *httpForwarder) connContext(ctx context.Context, conn net.Conn) context.Context {
+	fingerprint, ok := h.fingerprints.Load(conn)
+	if !ok {
+		return ctx
+	}
+	h.fingerprints.Delete(conn)
+
+	return ja3.WithFingerprint(ctx, fingerprint.(string))
+}
+
 // Accept retrieves a served connection in ServeTCP.
 func (h *httpForwarder) Accept() (net.Conn, error) {
 	select {
@@ -60,7 +84,7 @@ func (h *httpForwarder) Accept() (net.Conn, error) {
 type TCPEntryPoints map[string]*TCPEntryPoint
 
 // NewTCPEntryPoints creates a new TCPEntryPoints.
-func NewTCPEntryPoints(entryPointsConfig static.EntryPoints) (TCPEntryPoints, error) {
+func NewTCPEntryPoints(entryPointsConfig static.EntryPoints, metricsRegistry metrics.Registry, tracer *tracing.Tracing) (TCPEntryPoints, error) {
 	serverEntryPointsTCP := make(TCPEntryPoints)
 	for entryPointName, config := range entryPointsConfig {
 		protocol, err := config.GetProtocol()
@@ -74,7 +98,7 @@ func NewTCPEntryPoints(entryPointsConfig static.EntryPoints) (TCPEntryPoints, er
 
 		ctx := log.With(context.Background(), log.Str(log.EntryPointName, entryPointName))
 
-		serverEntryPointsTCP[entryPointName], err = NewTCPEntryPoint(ctx, config)
+		serverEntryPointsTCP[entryPointName], err = NewTCPEntryPoint(ctx, entryPointName, config, metricsRegistry, tracer)
 		if err != nil {
 			return nil, fmt.Errorf("error while building entryPoint %s: %w", entryPointName, err)
 		}
@@ -121,6 +145,7 @@ func (eps TCPEntryPoints) Switch(routersTCP map[string]*tcp.Router) {
 type TCPEntryPoint struct {
 	listener               net.Listener
 	switcher               *tcp.HandlerSwitcher
+	handler                tcp.Handler
 	transportConfiguration *static.EntryPointsTransport
 	tracker                *connectionTracker
 	httpServer             *httpServer
@@ -130,7 +155,7 @@ type TCPEntryPoint struct {
 }
 
 // NewTCPEntryPoint creates a new TCPEntryPoint.
-func NewTCPEntryPoint(ctx context.Context, configuration *static.EntryPoint) (*TCPEntryPoint, error) {
+func NewTCPEntryPoint(ctx context.Context, name string, configuration *static.EntryPoint, metricsRegistry metrics.Registry, tracer *tracing.Tracing) (*TCPEntryPoint, error) {
 	tracker := newConnectionTracker()
 
 	listener, err := buildListener(ctx, configuration)
@@ -162,9 +187,19 @@ func NewTCPEntryPoint(ctx context.Context, configuration *static.EntryPoint) (*T
 	tcpSwitcher := &tcp.HandlerSwitcher{}
 	tcpSwitcher.Switch(rt)
 
+	var handler tcp.Handler = tcpSwitcher
+	if metricsRegistry != nil && metricsRegistry.IsEpEnabled() {
+		handler = metricsMiddle.NewTCPEntryPointHandler(ctx, handler, metricsRegistry, name)
+	}
+
+	if tracer != nil {
+		handler = mTracing.NewTCPEntryPoint(ctx, handler)
+	}
+
 	return &TCPEntryPoint{
 		listener:               listener,
 		switcher:               tcpSwitcher,
+		handler:                handler,
 		transportConfiguration: configuration.Transport,
 		tracker:                tracker,
 		httpServer:             httpServer,
@@ -221,7 +256,7 @@ func (e *TCPEntryPoint) Start(ctx context.Context) {
 				}
 			}
 
-			e.switcher.ServeTCP(newTrackedConnection(writeCloser, e.tracker))
+			e.handler.ServeTCP(newTrackedConnection(writeCloser, e.tracker))
 		})
 	}
 }
@@ -518,15 +553,17 @@ func createHTTPServer(ctx context.Context, ln net.Listener, configuration *stati
 		handler = h2c.NewHandler(handler, &http2.Server{})
 	}
 
+	listener := newHTTPForwarder(ln)
+
 	serverHTTP := &http.Server{
 		Handler:      handler,
 		ErrorLog:     httpServerLogger,
 		ReadTimeout:  time.Duration(configuration.Transport.RespondingTimeouts.ReadTimeout),
 		WriteTimeout: time.Duration(configuration.Transport.RespondingTimeouts.WriteTimeout),
 		IdleTimeout:  time.Duration(configuration.Transport.RespondingTimeouts.IdleTimeout),
+		ConnContext:  listener.connContext,
 	}
 
-	listener := newHTTPForwarder(ln)
 	go func() {
 		err := serverHTTP.Serve(listener)
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
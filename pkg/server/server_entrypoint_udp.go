@@ -9,6 +9,8 @@ import (
 
 	"github.com/traefik/traefik/v2/pkg/config/static"
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/metrics"
+	metricsMiddle "github.com/traefik/traefik/v2/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v2/pkg/udp"
 )
 
@@ -16,7 +18,7 @@ import (
 type UDPEntryPoints map[string]*UDPEntryPoint
 
 // NewUDPEntryPoints returns all the UDP entry points, keyed by name.
-func NewUDPEntryPoints(cfg static.EntryPoints) (UDPEntryPoints, error) {
+func NewUDPEntryPoints(cfg static.EntryPoints, metricsRegistry metrics.Registry) (UDPEntryPoints, error) {
 	entryPoints := make(UDPEntryPoints)
 	for entryPointName, entryPoint := range cfg {
 		protocol, err := entryPoint.GetProtocol()
@@ -28,7 +30,7 @@ func NewUDPEntryPoints(cfg static.EntryPoints) (UDPEntryPoints, error) {
 			continue
 		}
 
-		ep, err := NewUDPEntryPoint(entryPoint)
+		ep, err := NewUDPEntryPoint(entryPointName, entryPoint, metricsRegistry)
 		if err != nil {
 			return nil, fmt.Errorf("error while building entryPoint %s: %w", entryPointName, err)
 		}
@@ -80,11 +82,12 @@ func (eps UDPEntryPoints) Switch(handlers map[string]udp.Handler) {
 type UDPEntryPoint struct {
 	listener               *udp.Listener
 	switcher               *udp.HandlerSwitcher
+	handler                udp.Handler
 	transportConfiguration *static.EntryPointsTransport
 }
 
 // NewUDPEntryPoint returns a UDP entry point.
-func NewUDPEntryPoint(cfg *static.EntryPoint) (*UDPEntryPoint, error) {
+func NewUDPEntryPoint(name string, cfg *static.EntryPoint, metricsRegistry metrics.Registry) (*UDPEntryPoint, error) {
 	addr, err := net.ResolveUDPAddr("udp", cfg.GetAddress())
 	if err != nil {
 		return nil, err
@@ -95,7 +98,15 @@ func NewUDPEntryPoint(cfg *static.EntryPoint) (*UDPEntryPoint, error) {
 		return nil, err
 	}
 
-	return &UDPEntryPoint{listener: listener, switcher: &udp.HandlerSwitcher{}, transportConfiguration: cfg.Transport}, nil
+	switcher := &udp.HandlerSwitcher{}
+
+	var handler udp.Handler = switcher
+	if metricsRegistry != nil && metricsRegistry.IsEpEnabled() {
+		ctx := log.With(context.Background(), log.Str(log.EntryPointName, name))
+		handler = metricsMiddle.NewUDPEntryPointHandler(ctx, switcher, metricsRegistry, name)
+	}
+
+	return &UDPEntryPoint{listener: listener, switcher: switcher, handler: handler, transportConfiguration: cfg.Transport}, nil
 }
 
 // Start commences the listening for ep.
@@ -108,7 +119,7 @@ func (ep *UDPEntryPoint) Start(ctx context.Context) {
 			return
 		}
 
-		go ep.switcher.ServeUDP(conn)
+		go ep.handler.ServeUDP(conn)
 	}
 }
 
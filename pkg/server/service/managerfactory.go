@@ -8,6 +8,7 @@ import (
 	"github.com/traefik/traefik/v2/pkg/config/static"
 	"github.com/traefik/traefik/v2/pkg/metrics"
 	"github.com/traefik/traefik/v2/pkg/safe"
+	"github.com/traefik/traefik/v2/pkg/tls"
 )
 
 // ManagerFactory a factory of service manager.
@@ -27,7 +28,7 @@ type ManagerFactory struct {
 }
 
 // NewManagerFactory creates a new ManagerFactory.
-func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *safe.Pool, metricsRegistry metrics.Registry, roundTripperManager *RoundTripperManager, acmeHTTPHandler http.Handler) *ManagerFactory {
+func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *safe.Pool, metricsRegistry metrics.Registry, roundTripperManager *RoundTripperManager, acmeHTTPHandler http.Handler, tlsManager *tls.Manager, certificateResolvers map[string]api.CertificateResolver) *ManagerFactory {
 	factory := &ManagerFactory{
 		metricsRegistry:     metricsRegistry,
 		routinesPool:        routinesPool,
@@ -36,7 +37,7 @@ func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *s
 	}
 
 	if staticConfiguration.API != nil {
-		factory.api = api.NewBuilder(staticConfiguration)
+		factory.api = api.NewBuilder(staticConfiguration, tlsManager, certificateResolvers)
 
 		if staticConfiguration.API.Dashboard {
 			factory.dashboardHandler = api.DashboardHandler{Assets: staticConfiguration.API.DashboardAssets}
@@ -9,19 +9,23 @@ import (
 
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/metrics"
+	metricsMiddle "github.com/traefik/traefik/v2/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
 	"github.com/traefik/traefik/v2/pkg/tcp"
 )
 
 // Manager is the TCPHandlers factory.
 type Manager struct {
-	configs map[string]*runtime.TCPServiceInfo
+	configs         map[string]*runtime.TCPServiceInfo
+	metricsRegistry metrics.Registry
 }
 
 // NewManager creates a new manager.
-func NewManager(conf *runtime.Configuration) *Manager {
+func NewManager(conf *runtime.Configuration, metricsRegistry metrics.Registry) *Manager {
 	return &Manager{
-		configs: conf.TCPServices,
+		configs:         conf.TCPServices,
+		metricsRegistry: metricsRegistry,
 	}
 }
 
@@ -65,10 +69,14 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 				continue
 			}
 
+			if m.metricsRegistry != nil && m.metricsRegistry.IsSvcEnabled() {
+				handler.SetDialFailuresCounter(m.metricsRegistry.ServiceDialFailuresCounter().With("protocol", "tcp", "service", serviceQualifiedName))
+			}
+
 			loadBalancer.AddServer(handler)
 			logger.WithField(log.ServerName, name).Debugf("Creating TCP server %d at %s", name, server.Address)
 		}
-		return loadBalancer, nil
+		return m.withMetrics(ctx, loadBalancer, serviceQualifiedName), nil
 	case conf.Weighted != nil:
 		loadBalancer := tcp.NewWRRLoadBalancer()
 		for _, service := range conf.Weighted.Services {
@@ -79,10 +87,19 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 			}
 			loadBalancer.AddWeightServer(handler, service.Weight)
 		}
-		return loadBalancer, nil
+		return m.withMetrics(ctx, loadBalancer, serviceQualifiedName), nil
 	default:
 		err := fmt.Errorf("the service %q does not have any type defined", serviceQualifiedName)
 		conf.AddError(err, true)
 		return nil, err
 	}
 }
+
+// withMetrics wraps the handler with the TCP metrics of the service, if enabled.
+func (m *Manager) withMetrics(ctx context.Context, handler tcp.Handler, serviceName string) tcp.Handler {
+	if m.metricsRegistry == nil || !m.metricsRegistry.IsSvcEnabled() {
+		return handler
+	}
+
+	return metricsMiddle.NewTCPServiceHandler(ctx, handler, m.metricsRegistry, serviceName)
+}
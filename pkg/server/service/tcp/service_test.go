@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/metrics"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
 )
 
@@ -180,7 +181,7 @@ func TestManager_BuildTCP(t *testing.T) {
 
 			manager := NewManager(&runtime.Configuration{
 				TCPServices: test.configs,
-			})
+			}, metrics.NewVoidRegistry())
 
 			ctx := context.Background()
 			if len(test.providerName) > 0 {
@@ -8,19 +8,23 @@ import (
 
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/metrics"
+	metricsMiddle "github.com/traefik/traefik/v2/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
 	"github.com/traefik/traefik/v2/pkg/udp"
 )
 
 // Manager handles UDP services creation.
 type Manager struct {
-	configs map[string]*runtime.UDPServiceInfo
+	configs         map[string]*runtime.UDPServiceInfo
+	metricsRegistry metrics.Registry
 }
 
 // NewManager creates a new manager.
-func NewManager(conf *runtime.Configuration) *Manager {
+func NewManager(conf *runtime.Configuration, metricsRegistry metrics.Registry) *Manager {
 	return &Manager{
-		configs: conf.UDPServices,
+		configs:         conf.UDPServices,
+		metricsRegistry: metricsRegistry,
 	}
 }
 
@@ -58,10 +62,14 @@ func (m *Manager) BuildUDP(rootCtx context.Context, serviceName string) (udp.Han
 				continue
 			}
 
+			if m.metricsRegistry != nil && m.metricsRegistry.IsSvcEnabled() {
+				handler.SetDialFailuresCounter(m.metricsRegistry.ServiceDialFailuresCounter().With("protocol", "udp", "service", serviceQualifiedName))
+			}
+
 			loadBalancer.AddServer(handler)
 			logger.WithField(log.ServerName, name).Debugf("Creating UDP server %d at %s", name, server.Address)
 		}
-		return loadBalancer, nil
+		return m.withMetrics(ctx, loadBalancer, serviceQualifiedName), nil
 	case conf.Weighted != nil:
 		loadBalancer := udp.NewWRRLoadBalancer()
 		for _, service := range conf.Weighted.Services {
@@ -72,10 +80,19 @@ func (m *Manager) BuildUDP(rootCtx context.Context, serviceName string) (udp.Han
 			}
 			loadBalancer.AddWeightedServer(handler, service.Weight)
 		}
-		return loadBalancer, nil
+		return m.withMetrics(ctx, loadBalancer, serviceQualifiedName), nil
 	default:
 		err := fmt.Errorf("the udp service %q does not have any type defined", serviceQualifiedName)
 		conf.AddError(err, true)
 		return nil, err
 	}
 }
+
+// withMetrics wraps the handler with the UDP metrics of the service, if enabled.
+func (m *Manager) withMetrics(ctx context.Context, handler udp.Handler, serviceName string) udp.Handler {
+	if m.metricsRegistry == nil || !m.metricsRegistry.IsSvcEnabled() {
+		return handler
+	}
+
+	return metricsMiddle.NewUDPServiceHandler(ctx, handler, m.metricsRegistry, serviceName)
+}
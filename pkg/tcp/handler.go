@@ -1,6 +1,7 @@
 package tcp
 
 import (
+	"crypto/tls"
 	"net"
 )
 
@@ -26,3 +27,58 @@ type WriteCloser interface {
 	// It corresponds to sending a FIN packet.
 	CloseWrite() error
 }
+
+// ProxyObserver is notified of the forwarding of a connection to a backend by a Proxy.
+// The Proxy notifies the connection it forwards if it implements ProxyObserver,
+// as well as all the connections implementing it among the ones it wraps (see Unwrap).
+type ProxyObserver interface {
+	// BackendConnected is called with the address of the backend once the connection to it is established.
+	BackendConnected(addr net.Addr)
+	// Done is called once the forwarding ends, with the error which ended it, if any.
+	Done(err error)
+}
+
+// Unwrapper is implemented by the connections wrapping another connection.
+type Unwrapper interface {
+	// Unwrap returns the wrapped connection.
+	Unwrap() WriteCloser
+}
+
+// Unwrap returns the connection wrapped by the given one, or nil if it does not wrap any.
+// The TLS connections created by a TLSHandler are unwrapped as well, as long as its next handler handles them.
+func Unwrap(conn WriteCloser) WriteCloser {
+	switch c := conn.(type) {
+	case Unwrapper:
+		return c.Unwrap()
+	case *tls.Conn:
+		if wrapped, ok := tlsConns.Load(c); ok {
+			return wrapped.(WriteCloser)
+		}
+	}
+
+	return nil
+}
+
+// GetJA3 returns the JA3 fingerprint of the TLS ClientHello of the client,
+// found on the connections in the wrapping chain of the given connection.
+func GetJA3(conn WriteCloser) string {
+	for ; conn != nil; conn = Unwrap(conn) {
+		if c, ok := conn.(*Conn); ok && c.JA3 != "" {
+			return c.JA3
+		}
+	}
+
+	return ""
+}
+
+// findProxyObservers returns the connections implementing ProxyObserver in the wrapping chain of the given connection.
+func findProxyObservers(conn WriteCloser) []ProxyObserver {
+	var observers []ProxyObserver
+	for ; conn != nil; conn = Unwrap(conn) {
+		if observer, ok := conn.(ProxyObserver); ok {
+			observers = append(observers, observer)
+		}
+	}
+
+	return observers
+}
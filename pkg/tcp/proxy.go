@@ -6,6 +6,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/go-kit/kit/metrics"
 	"github.com/pires/go-proxyproto"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/log"
@@ -18,6 +19,8 @@ type Proxy struct {
 	terminationDelay time.Duration
 	proxyProtocol    *dynamic.ProxyProtocol
 	refreshTarget    bool
+
+	dialFailuresCounter metrics.Counter
 }
 
 // NewProxy creates a new Proxy.
@@ -46,6 +49,11 @@ func NewProxy(address string, terminationDelay time.Duration, proxyProtocol *dyn
 	}, nil
 }
 
+// SetDialFailuresCounter sets the counter incremented each time the connection to the backend fails.
+func (p *Proxy) SetDialFailuresCounter(counter metrics.Counter) {
+	p.dialFailuresCounter = counter
+}
+
 // ServeTCP forwards the connection to a service.
 func (p *Proxy) ServeTCP(conn WriteCloser) {
 	log.WithoutContext().Debugf("Handling connection from %s", conn.RemoteAddr())
@@ -53,20 +61,36 @@ func (p *Proxy) ServeTCP(conn WriteCloser) {
 	// needed because of e.g. server.trackedConnection
 	defer conn.Close()
 
+	observers := findProxyObservers(conn)
+
 	connBackend, err := p.dialBackend()
 	if err != nil {
 		log.WithoutContext().Errorf("Error while connecting to backend: %v", err)
+		if p.dialFailuresCounter != nil {
+			p.dialFailuresCounter.Add(1)
+		}
+		for _, observer := range observers {
+			observer.Done(fmt.Errorf("error while connecting to backend: %w", err))
+		}
 		return
 	}
 
 	// maybe not needed, but just in case
 	defer connBackend.Close()
+
+	for _, observer := range observers {
+		observer.BackendConnected(connBackend.RemoteAddr())
+	}
+
 	errChan := make(chan error)
 
 	if p.proxyProtocol != nil && p.proxyProtocol.Version > 0 && p.proxyProtocol.Version < 3 {
 		header := proxyproto.HeaderProxyFromAddrs(byte(p.proxyProtocol.Version), conn.RemoteAddr(), conn.LocalAddr())
 		if _, err := header.WriteTo(connBackend); err != nil {
 			log.WithoutContext().Errorf("Error while writing proxy protocol headers to backend connection: %v", err)
+			for _, observer := range observers {
+				observer.Done(fmt.Errorf("error while writing proxy protocol headers: %w", err))
+			}
 			return
 		}
 	}
@@ -80,6 +104,10 @@ func (p *Proxy) ServeTCP(conn WriteCloser) {
 	}
 
 	<-errChan
+
+	for _, observer := range observers {
+		observer.Done(err)
+	}
 }
 
 func (p Proxy) dialBackend() (*net.TCPConn, error) {
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/tls/ja3"
 	"github.com/traefik/traefik/v2/pkg/types"
 )
 
@@ -78,21 +79,28 @@ func (r *Router) ServeTCP(conn WriteCloser) {
 
 	// FIXME Optimize and test the routing table before helloServerName
 	serverName = types.CanonicalDomain(serverName)
+	peekedConn := &Conn{
+		Peeked:      []byte(peeked),
+		ServerName:  serverName,
+		JA3:         clientHelloJA3(peeked),
+		WriteCloser: conn,
+	}
+
 	if r.routingTable != nil && serverName != "" {
 		if target, ok := r.routingTable[serverName]; ok {
-			target.ServeTCP(r.GetConn(conn, peeked))
+			target.ServeTCP(peekedConn)
 			return
 		}
 	}
 
 	// FIXME Needs tests
 	if target, ok := r.routingTable["*"]; ok {
-		target.ServeTCP(r.GetConn(conn, peeked))
+		target.ServeTCP(peekedConn)
 		return
 	}
 
 	if r.httpsForwarder != nil {
-		r.httpsForwarder.ServeTCP(r.GetConn(conn, peeked))
+		r.httpsForwarder.ServeTCP(peekedConn)
 	} else {
 		conn.Close()
 	}
@@ -182,6 +190,14 @@ type Conn struct {
 	// by Read calls. It set to nil by Read when fully consumed.
 	Peeked []byte
 
+	// ServerName is the server name indicated by the client in its TLS ClientHello (SNI),
+	// empty if the connection is not a TLS one or if the client did not send it.
+	ServerName string
+
+	// JA3 is the JA3 fingerprint of the TLS ClientHello of the client,
+	// empty if the connection is not a TLS one or if the ClientHello could not be parsed.
+	JA3 string
+
 	// Conn is the underlying connection.
 	// It can be type asserted against *net.TCPConn or other types
 	// as needed. It should not be read from directly unless
@@ -202,6 +218,11 @@ func (c *Conn) Read(p []byte) (n int, err error) {
 	return c.WriteCloser.Read(p)
 }
 
+// Unwrap returns the underlying connection.
+func (c *Conn) Unwrap() WriteCloser {
+	return c.WriteCloser
+}
+
 // clientHelloServerName returns the SNI server name inside the TLS ClientHello,
 // without consuming any bytes from br.
 // On any error, the empty string is returned.
@@ -256,6 +277,18 @@ func clientHelloServerName(br *bufio.Reader) (string, bool, string, error) {
 	return sni, true, getPeeked(br), nil
 }
 
+// clientHelloJA3 returns the JA3 fingerprint of the TLS ClientHello starting the peeked bytes.
+// On any error, the empty string is returned.
+func clientHelloJA3(peeked string) string {
+	fingerprint, err := ja3.Fingerprint([]byte(peeked))
+	if err != nil {
+		log.WithoutContext().Debugf("Unable to compute the JA3 fingerprint of the ClientHello: %v", err)
+		return ""
+	}
+
+	return fingerprint
+}
+
 func getPeeked(br *bufio.Reader) string {
 	peeked, err := br.Peek(br.Buffered())
 	if err != nil {
@@ -2,8 +2,12 @@ package tcp
 
 import (
 	"crypto/tls"
+	"sync"
 )
 
+// tlsConns holds the connections wrapped by the TLS connections being handled, keyed by the TLS connections (see Unwrap).
+var tlsConns sync.Map
+
 // TLSHandler handles TLS connections.
 type TLSHandler struct {
 	Next   Handler
@@ -12,5 +16,10 @@ type TLSHandler struct {
 
 // ServeTCP terminates the TLS connection.
 func (t *TLSHandler) ServeTCP(conn WriteCloser) {
-	t.Next.ServeTCP(tls.Server(conn, t.Config))
+	tlsConn := tls.Server(conn, t.Config)
+
+	tlsConns.Store(tlsConn, conn)
+	defer tlsConns.Delete(tlsConn)
+
+	t.Next.ServeTCP(tlsConn)
 }
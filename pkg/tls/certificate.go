@@ -148,21 +148,44 @@ func (c *Certificates) isEmpty() bool {
 
 // AppendCertificate appends a Certificate to a certificates map keyed by entrypoint.
 func (c *Certificate) AppendCertificate(certs map[string]map[string]*tls.Certificate, ep string) error {
+	tlsCert, parsedCert, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	appendCertificate(certs, nil, ep, tlsCert, parsedCert)
+
+	return nil
+}
+
+// load reads and parses the Certificate.
+func (c *Certificate) load() (*tls.Certificate, *x509.Certificate, error) {
 	certContent, err := c.CertFile.Read()
 	if err != nil {
-		return fmt.Errorf("unable to read CertFile : %w", err)
+		return nil, nil, fmt.Errorf("unable to read CertFile : %w", err)
 	}
 
 	keyContent, err := c.KeyFile.Read()
 	if err != nil {
-		return fmt.Errorf("unable to read KeyFile : %w", err)
+		return nil, nil, fmt.Errorf("unable to read KeyFile : %w", err)
 	}
 	tlsCert, err := tls.X509KeyPair(certContent, keyContent)
 	if err != nil {
-		return fmt.Errorf("unable to generate TLS certificate : %w", err)
+		return nil, nil, fmt.Errorf("unable to generate TLS certificate : %w", err)
+	}
+
+	parsedCert, err := x509.ParseCertificate(tlsCert.Certificate[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse TLS certificate : %w", err)
 	}
 
-	parsedCert, _ := x509.ParseCertificate(tlsCert.Certificate[0])
+	return &tlsCert, parsedCert, nil
+}
+
+// appendCertificate appends a loaded certificate to a certificates map keyed by entrypoint.
+// If alternatives is not nil, the certificates with the same domains as an appended one, but another key type,
+// are appended to it, keyed by entrypoint and by the same domains.
+func appendCertificate(certs map[string]map[string]*tls.Certificate, alternatives map[string]map[string][]*tls.Certificate, ep string, tlsCert *tls.Certificate, parsedCert *x509.Certificate) {
 
 	var SANs []string
 	if parsedCert.Subject.CommonName != "" {
@@ -196,14 +219,31 @@ func (c *Certificate) AppendCertificate(certs map[string]map[string]*tls.Certifi
 			}
 		}
 	}
-	if certExists {
+	switch {
+	case certExists && alternatives != nil && isAlternativeCertificate(parsedCert, certs[ep][certKey], alternatives[ep][certKey]):
+		log.Debugf("Adding %s certificate for domain(s) %s", parsedCert.PublicKeyAlgorithm, certKey)
+		if alternatives[ep] == nil {
+			alternatives[ep] = make(map[string][]*tls.Certificate)
+		}
+		alternatives[ep][certKey] = append(alternatives[ep][certKey], tlsCert)
+	case certExists:
 		log.Debugf("Skipping addition of certificate for domain(s) %q, to EntryPoint %s, as it already exists for this Entrypoint.", certKey, ep)
-	} else {
+	default:
 		log.Debugf("Adding certificate for domain(s) %s", certKey)
-		certs[ep][certKey] = &tlsCert
+		certs[ep][certKey] = tlsCert
+	}
+}
+
+// isAlternativeCertificate returns whether the certificate has another key type than the certificates with the same domains.
+func isAlternativeCertificate(cert *x509.Certificate, existing *tls.Certificate, alternatives []*tls.Certificate) bool {
+	for _, other := range append([]*tls.Certificate{existing}, alternatives...) {
+		otherCert, err := x509.ParseCertificate(other.Certificate[0])
+		if err != nil || otherCert.PublicKeyAlgorithm == cert.PublicKeyAlgorithm {
+			return false
+		}
 	}
 
-	return err
+	return true
 }
 
 // GetCertificate retrieves Certificate as tls.Certificate.
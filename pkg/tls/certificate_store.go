@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"net"
@@ -15,7 +16,10 @@ import (
 
 // CertificateStore store for dynamic certificates.
 type CertificateStore struct {
-	DynamicCerts       *safe.Safe
+	DynamicCerts *safe.Safe
+	// AlternativeCerts holds the certificates with the same domains as dynamic certificates, but other key types,
+	// keyed as the dynamic certificates.
+	AlternativeCerts   *safe.Safe
 	DefaultCertificate *tls.Certificate
 	CertCache          *cache.Cache
 }
@@ -23,8 +27,9 @@ type CertificateStore struct {
 // NewCertificateStore create a store for dynamic certificates.
 func NewCertificateStore() *CertificateStore {
 	return &CertificateStore{
-		DynamicCerts: &safe.Safe{},
-		CertCache:    cache.New(1*time.Hour, 10*time.Minute),
+		DynamicCerts:     &safe.Safe{},
+		AlternativeCerts: &safe.Safe{},
+		CertCache:        cache.New(1*time.Hour, 10*time.Minute),
 	}
 }
 
@@ -69,6 +74,8 @@ func (c CertificateStore) GetAllDomains() []string {
 }
 
 // GetBestCertificate returns the best match certificate, and caches the response.
+// Among the certificates with different key types for the best match domains,
+// the one supported by the client is returned, preferring the non-RSA ones.
 func (c CertificateStore) GetBestCertificate(clientHello *tls.ClientHelloInfo) *tls.Certificate {
 	domainToCheck := strings.ToLower(strings.TrimSpace(clientHello.ServerName))
 	if len(domainToCheck) == 0 {
@@ -80,16 +87,18 @@ func (c CertificateStore) GetBestCertificate(clientHello *tls.ClientHelloInfo) *
 		domainToCheck = strings.TrimSpace(host)
 	}
 
-	if cert, ok := c.CertCache.Get(domainToCheck); ok {
-		return cert.(*tls.Certificate)
+	if certs, ok := c.CertCache.Get(domainToCheck); ok {
+		return selectCertificate(clientHello, certs.([]*tls.Certificate))
 	}
 
 	matchedCerts := map[string]*tls.Certificate{}
+	matchedKeys := map[string]string{}
 	if c.DynamicCerts != nil && c.DynamicCerts.Get() != nil {
 		for domains, cert := range c.DynamicCerts.Get().(map[string]*tls.Certificate) {
 			for _, certDomain := range strings.Split(domains, ",") {
 				if MatchDomain(domainToCheck, certDomain) {
 					matchedCerts[certDomain] = cert
+					matchedKeys[certDomain] = domains
 				}
 			}
 		}
@@ -104,13 +113,50 @@ func (c CertificateStore) GetBestCertificate(clientHello *tls.ClientHelloInfo) *
 		sort.Strings(keys)
 
 		// cache best match
-		c.CertCache.SetDefault(domainToCheck, matchedCerts[keys[len(keys)-1]])
-		return matchedCerts[keys[len(keys)-1]]
+		certs := c.getCandidateCertificates(matchedKeys[keys[len(keys)-1]], matchedCerts[keys[len(keys)-1]])
+		c.CertCache.SetDefault(domainToCheck, certs)
+		return selectCertificate(clientHello, certs)
 	}
 
 	return nil
 }
 
+// getCandidateCertificates returns the certificate and its alternatives with other key types, the RSA ones last.
+func (c CertificateStore) getCandidateCertificates(certKey string, cert *tls.Certificate) []*tls.Certificate {
+	certs := []*tls.Certificate{cert}
+
+	if c.AlternativeCerts != nil && c.AlternativeCerts.Get() != nil {
+		certs = append(certs, c.AlternativeCerts.Get().(map[string][]*tls.Certificate)[certKey]...)
+	}
+
+	if len(certs) > 1 {
+		sort.SliceStable(certs, func(i, j int) bool {
+			return !isRSACertificate(certs[i]) && isRSACertificate(certs[j])
+		})
+	}
+
+	return certs
+}
+
+// selectCertificate returns the first certificate supported by the client,
+// or the last one, the most compatible, if the client supports none of them.
+func selectCertificate(clientHello *tls.ClientHelloInfo, certs []*tls.Certificate) *tls.Certificate {
+	if len(certs) > 1 {
+		for _, cert := range certs {
+			if clientHello.SupportsCertificate(cert) == nil {
+				return cert
+			}
+		}
+	}
+
+	return certs[len(certs)-1]
+}
+
+func isRSACertificate(cert *tls.Certificate) bool {
+	_, ok := cert.PrivateKey.(*rsa.PrivateKey)
+	return ok
+}
+
 // ResetCache clears the cache in the store.
 func (c CertificateStore) ResetCache() {
 	if c.CertCache != nil {
@@ -1,8 +1,18 @@
 package tls
 
 import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"strings"
 	"testing"
 	"time"
@@ -89,6 +99,90 @@ func TestGetBestCertificate(t *testing.T) {
 	}
 }
 
+func TestGetBestCertificate_keyTypes(t *testing.T) {
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaCert, rsaKey := generateCertificate(t, "snitest.com", rsaPrivateKey)
+
+	ecdsaPrivateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsaCert, ecdsaKey := generateCertificate(t, "snitest.com", ecdsaPrivateKey)
+
+	tlsManager := NewManager()
+	tlsManager.UpdateConfigs(context.Background(), map[string]Store{"default": {}}, nil, []*CertAndStores{
+		{Certificate: Certificate{CertFile: FileOrContent(rsaCert), KeyFile: FileOrContent(rsaKey)}},
+		{Certificate: Certificate{CertFile: FileOrContent(ecdsaCert), KeyFile: FileOrContent(ecdsaKey)}},
+	})
+
+	store := tlsManager.GetStore("default")
+
+	testCases := []struct {
+		desc            string
+		clientHello     *tls.ClientHelloInfo
+		expectedKeyType interface{}
+	}{
+		{
+			desc: "modern client",
+			clientHello: &tls.ClientHelloInfo{
+				ServerName:        "snitest.com",
+				SupportedVersions: []uint16{tls.VersionTLS13, tls.VersionTLS12},
+				SignatureSchemes:  []tls.SignatureScheme{tls.ECDSAWithP256AndSHA256, tls.PSSWithSHA256},
+				SupportedCurves:   []tls.CurveID{tls.X25519, tls.CurveP256},
+				SupportedPoints:   []uint8{0},
+				CipherSuites:      []uint16{tls.TLS_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+			},
+			expectedKeyType: &ecdsa.PrivateKey{},
+		},
+		{
+			desc: "legacy client",
+			clientHello: &tls.ClientHelloInfo{
+				ServerName:        "snitest.com",
+				SupportedVersions: []uint16{tls.VersionTLS12},
+				SignatureSchemes:  []tls.SignatureScheme{tls.PKCS1WithSHA256},
+				SupportedCurves:   []tls.CurveID{tls.CurveP256},
+				SupportedPoints:   []uint8{0},
+				CipherSuites:      []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+			},
+			expectedKeyType: &rsa.PrivateKey{},
+		},
+	}
+
+	for _, test := range testCases {
+		// The second lookup comes from the cache.
+		for i := 0; i < 2; i++ {
+			cert := store.GetBestCertificate(test.clientHello)
+			require.NotNil(t, cert, test.desc)
+			assert.IsType(t, test.expectedKeyType, cert.PrivateKey, test.desc)
+		}
+	}
+
+	assert.Len(t, tlsManager.GetCertificates(), 2)
+}
+
+// generateCertificate generates a PEM-encoded self-signed certificate and private key for the domain.
+func generateCertificate(t *testing.T, domain string, key crypto.Signer) ([]byte, []byte) {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: domain},
+		DNSNames:     []string{domain},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
 func loadTestCert(certName string, uppercase bool) (*tls.Certificate, error) {
 	replacement := "wildcard"
 	if uppercase {
@@ -0,0 +1,194 @@
+package tls
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/log"
+)
+
+// clientCertificateRetryDelay is the delay before a new attempt to issue the client certificate after a failure.
+const clientCertificateRetryDelay = time.Minute
+
+// ClientCertificateIssuer issues the client certificates presented to the backend servers.
+type ClientCertificateIssuer interface {
+	IssueClientCertificate(ctx context.Context, commonName string) (*tls.Certificate, error)
+}
+
+// ClientCertificateManager keeps a client certificate, renewed when two thirds of its lifetime have elapsed.
+type ClientCertificateManager struct {
+	issuer     ClientCertificateIssuer
+	commonName string
+
+	lock sync.RWMutex
+	cert *tls.Certificate
+}
+
+// NewClientCertificateManager creates a new ClientCertificateManager.
+func NewClientCertificateManager(issuer ClientCertificateIssuer, commonName string) *ClientCertificateManager {
+	return &ClientCertificateManager{
+		issuer:     issuer,
+		commonName: commonName,
+	}
+}
+
+// Run issues the client certificate and renews it, until the context is done.
+func (m *ClientCertificateManager) Run(ctx context.Context) {
+	timer := time.NewTimer(m.renew(ctx))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			timer.Reset(m.renew(ctx))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// renew issues a new client certificate, and returns the delay before the next renewal.
+func (m *ClientCertificateManager) renew(ctx context.Context) time.Duration {
+	logger := log.FromContext(ctx)
+
+	cert, err := m.issuer.IssueClientCertificate(ctx, m.commonName)
+	if err == nil && (cert == nil || len(cert.Certificate) == 0) {
+		err = errors.New("no certificate issued")
+	}
+	if err != nil {
+		logger.Errorf("Unable to issue the client certificate for %q: %v", m.commonName, err)
+		return clientCertificateRetryDelay
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		logger.Errorf("Invalid client certificate issued for %q: %v", m.commonName, err)
+		return clientCertificateRetryDelay
+	}
+	cert.Leaf = leaf
+
+	m.lock.Lock()
+	m.cert = cert
+	m.lock.Unlock()
+
+	renewAt := ClientCertificateRenewAt(leaf)
+	logger.Debugf("Client certificate issued for %q, valid until %s, to be renewed at %s", m.commonName, leaf.NotAfter, renewAt)
+
+	delay := time.Until(renewAt)
+	if delay < clientCertificateRetryDelay {
+		delay = clientCertificateRetryDelay
+	}
+
+	return delay
+}
+
+// ClientCertificateRenewAt returns the time from which the client certificate is to be renewed,
+// when two thirds of its lifetime have elapsed.
+func ClientCertificateRenewAt(leaf *x509.Certificate) time.Time {
+	return leaf.NotBefore.Add(2 * leaf.NotAfter.Sub(leaf.NotBefore) / 3)
+}
+
+// GetClientCertificate returns the current client certificate,
+// or an empty certificate, for none to be sent, if it has not been issued yet.
+func (m *ClientCertificateManager) GetClientCertificate(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if m.cert == nil {
+		return &tls.Certificate{}, nil
+	}
+
+	return m.cert, nil
+}
+
+// LocalCA is a local certificate authority issuing client certificates.
+type LocalCA struct {
+	cert     *x509.Certificate
+	key      crypto.Signer
+	duration time.Duration
+}
+
+// NewLocalCA creates a local certificate authority issuing client certificates valid for the given duration.
+func NewLocalCA(certFile, keyFile FileOrContent, duration time.Duration) (*LocalCA, error) {
+	certContent, err := certFile.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CertFile : %w", err)
+	}
+
+	keyContent, err := keyFile.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read KeyFile : %w", err)
+	}
+
+	keyPair, err := tls.X509KeyPair(certContent, keyContent)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the certificate authority key pair: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the certificate authority certificate: %w", err)
+	}
+
+	if !cert.IsCA {
+		return nil, errors.New("the certificate is not a certificate authority")
+	}
+
+	key, ok := keyPair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported certificate authority private key")
+	}
+
+	return &LocalCA{cert: cert, key: key, duration: duration}, nil
+}
+
+// IssueClientCertificate issues a client certificate for the common name, with a new ECDSA P-256 key.
+func (c *LocalCA) IssueClientCertificate(_ context.Context, commonName string) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	// The certificate is valid from a minute ago, to tolerate clock skews with the servers.
+	now := time.Now()
+	notAfter := now.Add(c.duration)
+	if notAfter.After(c.cert.NotAfter) {
+		notAfter = c.cert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, c.cert, key.Public(), c.key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the client certificate: %w", err)
+	}
+
+	return &tls.Certificate{
+		Certificate: [][]byte{der, c.cert.Raw},
+		PrivateKey:  key,
+	}, nil
+}
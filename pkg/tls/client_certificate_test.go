@@ -0,0 +1,191 @@
+package tls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/tls/generate"
+)
+
+func TestNewLocalCA(t *testing.T) {
+	caCert, caKey := generateCA(t, time.Now().Add(24*time.Hour))
+
+	cert, key, err := generate.KeyPair("foo.com", time.Time{})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc      string
+		certFile  FileOrContent
+		keyFile   FileOrContent
+		expectErr bool
+	}{
+		{
+			desc:     "certificate authority",
+			certFile: FileOrContent(caCert),
+			keyFile:  FileOrContent(caKey),
+		},
+		{
+			desc:      "not a certificate authority",
+			certFile:  FileOrContent(cert),
+			keyFile:   FileOrContent(key),
+			expectErr: true,
+		},
+		{
+			desc:      "mismatching private key",
+			certFile:  FileOrContent(caCert),
+			keyFile:   FileOrContent(key),
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewLocalCA(test.certFile, test.keyFile, time.Hour)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLocalCA_IssueClientCertificate(t *testing.T) {
+	caNotAfter := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	caCert, caKey := generateCA(t, caNotAfter)
+
+	testCases := []struct {
+		desc             string
+		duration         time.Duration
+		expectedNotAfter func(time.Time) bool
+	}{
+		{
+			desc:     "duration",
+			duration: time.Hour,
+			expectedNotAfter: func(notAfter time.Time) bool {
+				return notAfter.Before(caNotAfter) && notAfter.After(time.Now().Add(59*time.Minute))
+			},
+		},
+		{
+			desc:     "duration beyond the certificate authority validity",
+			duration: 24 * time.Hour,
+			expectedNotAfter: func(notAfter time.Time) bool {
+				return notAfter.Equal(caNotAfter)
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ca, err := NewLocalCA(FileOrContent(caCert), FileOrContent(caKey), test.duration)
+			require.NoError(t, err)
+
+			cert, err := ca.IssueClientCertificate(context.Background(), "traefik")
+			require.NoError(t, err)
+			require.Len(t, cert.Certificate, 2)
+
+			leaf, err := x509.ParseCertificate(cert.Certificate[0])
+			require.NoError(t, err)
+
+			assert.Equal(t, "traefik", leaf.Subject.CommonName)
+			assert.Equal(t, []string{"traefik"}, leaf.DNSNames)
+			assert.True(t, test.expectedNotAfter(leaf.NotAfter), leaf.NotAfter)
+
+			roots := x509.NewCertPool()
+			roots.AppendCertsFromPEM(caCert)
+
+			_, err = leaf.Verify(x509.VerifyOptions{
+				Roots:     roots,
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClientCertificateManager(t *testing.T) {
+	caCert, caKey := generateCA(t, time.Now().Add(24*time.Hour))
+
+	ca, err := NewLocalCA(FileOrContent(caCert), FileOrContent(caKey), 3*time.Hour)
+	require.NoError(t, err)
+
+	manager := NewClientCertificateManager(ca, "traefik")
+
+	// No certificate is presented until one is issued.
+	cert, err := manager.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Empty(t, cert.Certificate)
+
+	// The certificate is renewed when two thirds of its lifetime have elapsed.
+	delay := manager.renew(context.Background())
+	assert.InDelta(t, float64(2*time.Hour), float64(delay), float64(2*time.Minute))
+
+	cert, err = manager.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	require.NotNil(t, cert.Leaf)
+	assert.Equal(t, "traefik", cert.Leaf.Subject.CommonName)
+}
+
+func TestClientCertificateManager_error(t *testing.T) {
+	manager := NewClientCertificateManager(clientCertificateIssuerFunc(func(context.Context, string) (*tls.Certificate, error) {
+		return nil, errors.New("boom")
+	}), "traefik")
+
+	assert.Equal(t, clientCertificateRetryDelay, manager.renew(context.Background()))
+
+	cert, err := manager.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Empty(t, cert.Certificate)
+}
+
+type clientCertificateIssuerFunc func(ctx context.Context, commonName string) (*tls.Certificate, error)
+
+func (f clientCertificateIssuerFunc) IssueClientCertificate(ctx context.Context, commonName string) (*tls.Certificate, error) {
+	return f(ctx, commonName)
+}
+
+// generateCA generates a PEM-encoded certificate authority certificate and private key.
+func generateCA(t *testing.T, notAfter time.Time) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
@@ -0,0 +1,231 @@
+// Package ja3 computes the JA3 fingerprints of the TLS clients (https://github.com/salesforce/ja3).
+package ja3
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // MD5 is the hash function defined by JA3.
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type contextKey int
+
+const fingerprintKey contextKey = iota
+
+const (
+	recordTypeHandshake      = 0x16
+	handshakeTypeClientHello = 0x01
+
+	extensionSupportedGroups = 10
+	extensionPointFormats    = 11
+)
+
+var errMalformed = errors.New("malformed TLS ClientHello")
+
+// WithFingerprint returns a copy of the context carrying the JA3 fingerprint.
+func WithFingerprint(ctx context.Context, fingerprint string) context.Context {
+	return context.WithValue(ctx, fingerprintKey, fingerprint)
+}
+
+// FromContext returns the JA3 fingerprint carried by the context, if any.
+func FromContext(ctx context.Context) string {
+	if fingerprint, ok := ctx.Value(fingerprintKey).(string); ok {
+		return fingerprint
+	}
+
+	return ""
+}
+
+// Fingerprint returns the JA3 fingerprint, the MD5 hash of the JA3 string, of the TLS record holding a ClientHello.
+func Fingerprint(record []byte) (string, error) {
+	ja3, err := String(record)
+	if err != nil {
+		return "", err
+	}
+
+	hash := md5.Sum([]byte(ja3)) //nolint:gosec // MD5 is the hash function defined by JA3.
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// String returns the JA3 string of the TLS record holding a ClientHello:
+// the version, cipher suites, extensions, elliptic curves and point formats sent by the client,
+// with the GREASE values (RFC 8701) excluded.
+func String(record []byte) (string, error) {
+	r := reader(record)
+
+	recordType, ok := r.readUint8()
+	if !ok || recordType != recordTypeHandshake {
+		return "", errors.New("not a TLS handshake record")
+	}
+
+	var fragment reader
+	if !r.skip(2) || !r.readVector16(&fragment) {
+		return "", errMalformed
+	}
+
+	var hello reader
+	handshakeType, ok := fragment.readUint8()
+	if !ok || handshakeType != handshakeTypeClientHello {
+		return "", errors.New("not a TLS ClientHello")
+	}
+	if !fragment.readVector24(&hello) {
+		return "", errMalformed
+	}
+
+	version, ok := hello.readUint16()
+	if !ok {
+		return "", errMalformed
+	}
+
+	var sessionID, cipherSuites, compressionMethods reader
+	if !hello.skip(32) || // random
+		!hello.readVector8(&sessionID) ||
+		!hello.readVector16(&cipherSuites) ||
+		!hello.readVector8(&compressionMethods) {
+		return "", errMalformed
+	}
+
+	ciphers, ok := readUint16List(cipherSuites)
+	if !ok {
+		return "", errMalformed
+	}
+
+	var extensions, curves, pointFormats []string
+
+	// The extensions are optional.
+	if len(hello) > 0 {
+		var exts reader
+		if !hello.readVector16(&exts) {
+			return "", errMalformed
+		}
+
+		for len(exts) > 0 {
+			var data reader
+			extension, ok := exts.readUint16()
+			if !ok || !exts.readVector16(&data) {
+				return "", errMalformed
+			}
+
+			if isGREASE(extension) {
+				continue
+			}
+			extensions = append(extensions, strconv.Itoa(int(extension)))
+
+			switch extension {
+			case extensionSupportedGroups:
+				var groups reader
+				if !data.readVector16(&groups) {
+					return "", errMalformed
+				}
+				if curves, ok = readUint16List(groups); !ok {
+					return "", errMalformed
+				}
+
+			case extensionPointFormats:
+				var formats reader
+				if !data.readVector8(&formats) {
+					return "", errMalformed
+				}
+				for _, format := range formats {
+					pointFormats = append(pointFormats, strconv.Itoa(int(format)))
+				}
+			}
+		}
+	}
+
+	return strings.Join([]string{
+		strconv.Itoa(int(version)),
+		strings.Join(ciphers, "-"),
+		strings.Join(extensions, "-"),
+		strings.Join(curves, "-"),
+		strings.Join(pointFormats, "-"),
+	}, ","), nil
+}
+
+// readUint16List returns the decimal representations of the uint16 values of the list, except the GREASE ones.
+func readUint16List(r reader) ([]string, bool) {
+	var values []string
+	for len(r) > 0 {
+		value, ok := r.readUint16()
+		if !ok {
+			return nil, false
+		}
+
+		if !isGREASE(value) {
+			values = append(values, strconv.Itoa(int(value)))
+		}
+	}
+
+	return values, true
+}
+
+// isGREASE returns whether the value is one of the values reserved by GREASE (RFC 8701),
+// which clients send at random to prevent the servers from rejecting the unknown ones.
+func isGREASE(value uint16) bool {
+	return value&0x0f0f == 0x0a0a && value>>8 == value&0xff
+}
+
+// reader reads the big-endian values and length-prefixed vectors of a TLS message.
+type reader []byte
+
+func (r *reader) skip(n int) bool {
+	if len(*r) < n {
+		return false
+	}
+
+	*r = (*r)[n:]
+	return true
+}
+
+func (r *reader) readUint8() (uint8, bool) {
+	if len(*r) < 1 {
+		return 0, false
+	}
+
+	value := (*r)[0]
+	*r = (*r)[1:]
+	return value, true
+}
+
+func (r *reader) readUint16() (uint16, bool) {
+	if len(*r) < 2 {
+		return 0, false
+	}
+
+	value := uint16((*r)[0])<<8 | uint16((*r)[1])
+	*r = (*r)[2:]
+	return value, true
+}
+
+func (r *reader) readVector8(out *reader) bool {
+	length, ok := r.readUint8()
+	return ok && r.readBytes(int(length), out)
+}
+
+func (r *reader) readVector16(out *reader) bool {
+	length, ok := r.readUint16()
+	return ok && r.readBytes(int(length), out)
+}
+
+func (r *reader) readVector24(out *reader) bool {
+	if len(*r) < 3 {
+		return false
+	}
+
+	length := int((*r)[0])<<16 | int((*r)[1])<<8 | int((*r)[2])
+	*r = (*r)[3:]
+	return r.readBytes(length, out)
+}
+
+func (r *reader) readBytes(n int, out *reader) bool {
+	if len(*r) < n {
+		return false
+	}
+
+	*out = (*r)[:n]
+	*r = (*r)[n:]
+	return true
+}
@@ -0,0 +1,165 @@
+package ja3
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		record      []byte
+		expected    string
+		expectedErr bool
+	}{
+		{
+			desc: "with extensions",
+			record: clientHello(0x0303, []uint16{0x1301, 0xc02b, 0x002f}, extensions(
+				extension(0, []byte{0, 0}),
+				extension(extensionSupportedGroups, vector16(uint16s(0x001d, 0x0017))),
+				extension(extensionPointFormats, []byte{1, 0}),
+				extension(0xff01, []byte{0}),
+			)),
+			expected: "771,4865-49195-47,0-10-11-65281,29-23,0",
+		},
+		{
+			desc: "GREASE values excluded",
+			record: clientHello(0x0303, []uint16{0x0a0a, 0xc02f}, extensions(
+				extension(0x1a1a, nil),
+				extension(extensionSupportedGroups, vector16(uint16s(0x2a2a, 0x0017))),
+				extension(23, nil),
+			)),
+			expected: "771,49199,10-23,23,",
+		},
+		{
+			desc:     "without extensions",
+			record:   clientHello(0x0301, []uint16{0x0035}, nil),
+			expected: "769,53,,,",
+		},
+		{
+			desc:        "not a handshake",
+			record:      []byte{0x17, 0x03, 0x03, 0x00, 0x00},
+			expectedErr: true,
+		},
+		{
+			desc:        "truncated",
+			record:      clientHello(0x0303, []uint16{0x0035}, nil)[:20],
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ja3, err := String(test.record)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ja3)
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	record := clientHello(0x0301, []uint16{0x0035}, nil)
+
+	fingerprint, err := Fingerprint(record)
+	require.NoError(t, err)
+
+	// MD5 of "769,53,,,".
+	assert.Equal(t, "851235d5e9d490f3e2b43db94ac71961", fingerprint)
+}
+
+func TestFingerprint_cryptoTLSClient(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer func() { _ = serverConn.Close() }()
+
+	go func() {
+		_ = tls.Client(clientConn, &tls.Config{ServerName: "example.com"}).Handshake()
+		_ = clientConn.Close()
+	}()
+
+	header := make([]byte, 5)
+	_, err := serverConn.Read(header)
+	require.NoError(t, err)
+
+	record := make([]byte, 5+(int(header[3])<<8|int(header[4])))
+	copy(record, header)
+	for n := 5; n < len(record); {
+		read, err := serverConn.Read(record[n:])
+		require.NoError(t, err)
+		n += read
+	}
+
+	ja3, err := String(record)
+	require.NoError(t, err)
+
+	fields := strings.Split(ja3, ",")
+	require.Len(t, fields, 5)
+	assert.Equal(t, "771", fields[0])
+	assert.NotEmpty(t, fields[1])
+	assert.Contains(t, strings.Split(fields[2], "-"), "0")
+	assert.Contains(t, strings.Split(fields[3], "-"), "29")
+
+	fingerprint, err := Fingerprint(record)
+	require.NoError(t, err)
+	assert.Len(t, fingerprint, 32)
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Empty(t, FromContext(context.Background()))
+
+	ctx := WithFingerprint(context.Background(), "851235d5e9d490f3e2b43db94ac71961")
+	assert.Equal(t, "851235d5e9d490f3e2b43db94ac71961", FromContext(ctx))
+}
+
+func clientHello(version uint16, cipherSuites []uint16, exts []byte) []byte {
+	var hello []byte
+	hello = append(hello, uint16s(version)...)
+	hello = append(hello, make([]byte, 32)...) // random
+	hello = append(hello, 0)                   // session ID
+	hello = append(hello, vector16(uint16s(cipherSuites...))...)
+	hello = append(hello, 1, 0) // compression methods
+	hello = append(hello, exts...)
+
+	handshake := append([]byte{handshakeTypeClientHello, byte(len(hello) >> 16), byte(len(hello) >> 8), byte(len(hello))}, hello...)
+
+	return append([]byte{recordTypeHandshake, 0x03, 0x01}, vector16(handshake)...)
+}
+
+func extensions(exts ...[]byte) []byte {
+	var data []byte
+	for _, ext := range exts {
+		data = append(data, ext...)
+	}
+
+	return vector16(data)
+}
+
+func extension(extensionType uint16, data []byte) []byte {
+	return append(uint16s(extensionType), vector16(data)...)
+}
+
+func vector16(data []byte) []byte {
+	return append([]byte{byte(len(data) >> 8), byte(len(data))}, data...)
+}
+
+func uint16s(values ...uint16) []byte {
+	var data []byte
+	for _, value := range values {
+		data = append(data, byte(value>>8), byte(value))
+	}
+
+	return data
+}
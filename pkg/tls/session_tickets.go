@@ -0,0 +1,229 @@
+package tls
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// minSessionTicketSecretSize is the minimal size of the secrets the session ticket keys are derived from.
+const minSessionTicketSecretSize = 32
+
+// setSessionTickets configures the session tickets of the TLS configuration.
+// Without key files, the session ticket keys are the random keys generated by crypto/tls for the configuration.
+// With a rotation interval, the configuration is added to the rotation, which sets the new keys at the start of each period.
+func setSessionTickets(conf *tls.Config, sessionTickets SessionTickets, rotation *sessionTicketsRotation) error {
+	if sessionTickets.Disabled {
+		conf.SessionTicketsDisabled = true
+		return nil
+	}
+
+	if len(sessionTickets.KeyFiles) == 0 {
+		return nil
+	}
+
+	keys, err := newSessionTicketKeys(sessionTickets)
+	if err != nil {
+		return err
+	}
+
+	keys.update(conf, time.Now())
+
+	if keys.interval > 0 {
+		rotation.add(conf, keys)
+	}
+
+	return nil
+}
+
+// sessionTicketsRotation rotates the session ticket keys of TLS configurations, at the start of each rotation period.
+// The keys are not rotated during the handshakes,
+// as the configurations used by some servers (e.g. HTTP/3) are not the ones the connections are accepted with.
+type sessionTicketsRotation struct {
+	lock    sync.Mutex
+	configs map[*tls.Config]*sessionTicketKeys
+	added   chan struct{}
+	stop    chan struct{}
+}
+
+// add adds the TLS configuration to the rotation, and starts the rotation if needed.
+func (r *sessionTicketsRotation) add(conf *tls.Config, keys *sessionTicketKeys) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.configs == nil {
+		r.configs = make(map[*tls.Config]*sessionTicketKeys)
+	}
+	r.configs[conf] = keys
+
+	if r.stop == nil {
+		r.stop = make(chan struct{})
+		r.added = make(chan struct{}, 1)
+		go r.run(r.stop, r.added)
+		return
+	}
+
+	// The next rotation of the added configuration may come before the one the rotation is waiting for.
+	select {
+	case r.added <- struct{}{}:
+	default:
+	}
+}
+
+// reset removes all the TLS configurations from the rotation, and stops it.
+func (r *sessionTicketsRotation) reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.configs = nil
+
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+		r.added = nil
+	}
+}
+
+func (r *sessionTicketsRotation) run(stop, added <-chan struct{}) {
+	for {
+		timer := time.NewTimer(r.nextRotation(time.Now()))
+
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-added:
+			timer.Stop()
+		case <-timer.C:
+			r.update(time.Now())
+		}
+	}
+}
+
+// nextRotation returns the duration until the start of the next rotation period of the configurations.
+func (r *sessionTicketsRotation) nextRotation(now time.Time) time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	next := time.Duration(-1)
+	for _, keys := range r.configs {
+		interval := int64(keys.interval)
+		wait := time.Duration((keys.getPeriod(now)+1)*interval - now.UnixNano())
+		if next < 0 || wait < next {
+			next = wait
+		}
+	}
+
+	if next < 0 {
+		// There is nothing to rotate until a configuration is added.
+		return time.Hour
+	}
+
+	return next
+}
+
+// update sets the keys of the current rotation period on the configurations.
+func (r *sessionTicketsRotation) update(now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for conf, keys := range r.configs {
+		keys.update(conf, now)
+	}
+}
+
+// sessionTicketKeys derives the session ticket keys from secrets shared by the instances,
+// so that the sessions can be resumed on any of them, and after a restart.
+type sessionTicketKeys struct {
+	secrets  [][]byte
+	interval time.Duration
+
+	lock   sync.Mutex
+	period int64
+	set    bool
+}
+
+func newSessionTicketKeys(sessionTickets SessionTickets) (*sessionTicketKeys, error) {
+	interval := time.Duration(sessionTickets.RotationInterval)
+	if interval < 0 {
+		return nil, fmt.Errorf("invalid session tickets rotation interval: %s", interval)
+	}
+
+	keys := &sessionTicketKeys{interval: interval}
+
+	for _, keyFile := range sessionTickets.KeyFiles {
+		secret, err := keyFile.Read()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the session ticket key file: %w", err)
+		}
+
+		if len(secret) < minSessionTicketSecretSize {
+			if keyFile.IsPath() {
+				return nil, fmt.Errorf("session ticket key in %s must be at least %d bytes long", keyFile, minSessionTicketSecretSize)
+			}
+			return nil, fmt.Errorf("session ticket key must be at least %d bytes long", minSessionTicketSecretSize)
+		}
+
+		keys.secrets = append(keys.secrets, secret)
+	}
+
+	return keys, nil
+}
+
+// update sets the keys of the current rotation period on the TLS configuration, if not already set.
+func (k *sessionTicketKeys) update(conf *tls.Config, now time.Time) {
+	period := k.getPeriod(now)
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if k.set && k.period == period {
+		return
+	}
+
+	conf.SetSessionTicketKeys(k.keys(period))
+
+	k.period = period
+	k.set = true
+}
+
+// getPeriod returns the rotation period of the given time.
+func (k *sessionTicketKeys) getPeriod(now time.Time) int64 {
+	if k.interval <= 0 {
+		return 0
+	}
+
+	return now.UnixNano() / int64(k.interval)
+}
+
+// keys returns the session ticket keys of the rotation period.
+// The key of the current period derived from the first secret comes first, as it is used to encrypt the new tickets,
+// and the keys of the previous period are kept to decrypt the tickets issued before the rotation.
+func (k *sessionTicketKeys) keys(period int64) [][32]byte {
+	var keys [][32]byte
+	for _, secret := range k.secrets {
+		keys = append(keys, deriveSessionTicketKey(secret, period))
+		if k.interval > 0 {
+			keys = append(keys, deriveSessionTicketKey(secret, period-1))
+		}
+	}
+
+	return keys
+}
+
+func deriveSessionTicketKey(secret []byte, period int64) [32]byte {
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], uint64(period))
+
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(message[:])
+
+	var key [32]byte
+	copy(key[:], mac.Sum(nil))
+
+	return key
+}
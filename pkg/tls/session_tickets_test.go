@@ -0,0 +1,192 @@
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+)
+
+const sessionTicketSecret = FileOrContent("0123456789abcdef0123456789abcdef")
+
+func TestSessionTickets_sharedKeys(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		sessionTickets *SessionTickets
+		expectResume   bool
+	}{
+		{
+			desc: "default random keys",
+		},
+		{
+			desc:           "shared keys",
+			sessionTickets: &SessionTickets{KeyFiles: []FileOrContent{sessionTicketSecret}},
+			expectResume:   true,
+		},
+		{
+			desc: "shared keys with rotation",
+			sessionTickets: &SessionTickets{
+				KeyFiles:         []FileOrContent{sessionTicketSecret},
+				RotationInterval: ptypes.Duration(time.Hour),
+			},
+			expectResume: true,
+		},
+		{
+			desc: "disabled",
+			sessionTickets: &SessionTickets{
+				Disabled: true,
+				KeyFiles: []FileOrContent{sessionTicketSecret},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			options := Options{MaxVersion: "VersionTLS12", SessionTickets: test.sessionTickets}
+
+			// Two managers stand for two instances, or for an instance before and after a restart.
+			clientConfig := &tls.Config{
+				InsecureSkipVerify: true,
+				ClientSessionCache: tls.NewLRUClientSessionCache(1),
+			}
+
+			assert.False(t, handshake(t, newTestManager(options), clientConfig))
+			assert.Equal(t, test.expectResume, handshake(t, newTestManager(options), clientConfig))
+		})
+	}
+}
+
+func TestSessionTickets_invalidKey(t *testing.T) {
+	_, err := buildTLSConfig(Options{SessionTickets: &SessionTickets{KeyFiles: []FileOrContent{"too short"}}}, &sessionTicketsRotation{})
+	assert.Error(t, err)
+
+	_, err = buildTLSConfig(Options{SessionTickets: &SessionTickets{
+		KeyFiles:         []FileOrContent{sessionTicketSecret},
+		RotationInterval: ptypes.Duration(-time.Hour),
+	}}, &sessionTicketsRotation{})
+	assert.Error(t, err)
+}
+
+func TestSessionTicketKeys_rotation(t *testing.T) {
+	keys, err := newSessionTicketKeys(SessionTickets{
+		KeyFiles:         []FileOrContent{sessionTicketSecret, sessionTicketSecret + "-previous"},
+		RotationInterval: ptypes.Duration(time.Hour),
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2021, time.June, 1, 10, 30, 0, 0, time.UTC)
+	current := keys.keys(keys.getPeriod(now))
+	next := keys.keys(keys.getPeriod(now.Add(time.Hour)))
+
+	// The keys of the current and previous periods, for each secret.
+	require.Len(t, current, 4)
+	require.Len(t, next, 4)
+
+	assert.NotEqual(t, current[0], next[0])
+	assert.Equal(t, current[0], next[1])
+	assert.Equal(t, current[2], next[3])
+	assert.NotEqual(t, current[0], current[2])
+
+	assert.Equal(t, current, keys.keys(keys.getPeriod(now.Add(15*time.Minute))))
+}
+
+func TestSessionTickets_rotation(t *testing.T) {
+	interval := 500 * time.Millisecond
+	options := Options{
+		MaxVersion: "VersionTLS12",
+		SessionTickets: &SessionTickets{
+			KeyFiles:         []FileOrContent{sessionTicketSecret},
+			RotationInterval: ptypes.Duration(interval),
+		},
+	}
+
+	manager := newTestManager(options)
+	defer manager.sessionTickets.reset()
+
+	managerConfig, err := manager.Get("default", "default")
+	require.NoError(t, err)
+
+	// Like the HTTP/3 server, the configuration of the manager is only returned by the hook of another configuration,
+	// so the keys must be rotated without any hook.
+	serverConfig := &tls.Config{
+		GetConfigForClient: func(_ *tls.ClientHelloInfo) (*tls.Config, error) {
+			return managerConfig, nil
+		},
+	}
+
+	newClientConfig := func() *tls.Config {
+		return &tls.Config{
+			InsecureSkipVerify: true,
+			ClientSessionCache: tls.NewLRUClientSessionCache(1),
+		}
+	}
+
+	// Starts at the beginning of a rotation period, to leave the handshakes enough time.
+	time.Sleep(time.Duration(int64(interval)-time.Now().UnixNano()%int64(interval)) + 50*time.Millisecond)
+
+	resumedClient := newClientConfig()
+	expiredClient := newClientConfig()
+	assert.False(t, handshakeConfig(t, serverConfig, resumedClient))
+	assert.False(t, handshakeConfig(t, serverConfig, expiredClient))
+
+	// The tickets issued during the previous period are still accepted.
+	time.Sleep(interval)
+	assert.True(t, handshakeConfig(t, serverConfig, resumedClient))
+
+	// The tickets issued two periods ago are not.
+	time.Sleep(interval)
+	assert.False(t, handshakeConfig(t, serverConfig, expiredClient))
+
+	// The rotated keys are the ones of another instance.
+	client := newClientConfig()
+	assert.False(t, handshakeConfig(t, serverConfig, client))
+	assert.True(t, handshake(t, newTestManager(options), client))
+}
+
+func newTestManager(options Options) *Manager {
+	manager := NewManager()
+	manager.UpdateConfigs(context.Background(),
+		map[string]Store{},
+		map[string]Options{"default": options},
+		[]*CertAndStores{{Certificate: Certificate{CertFile: localhostCert, KeyFile: localhostKey}}})
+
+	return manager
+}
+
+// handshake performs a TLS handshake with the server configuration of the manager, and returns whether the session was resumed.
+func handshake(t *testing.T, manager *Manager, clientConfig *tls.Config) bool {
+	t.Helper()
+
+	serverConfig, err := manager.Get("default", "default")
+	require.NoError(t, err)
+
+	return handshakeConfig(t, serverConfig, clientConfig)
+}
+
+// handshakeConfig performs a TLS handshake with the server configuration, and returns whether the session was resumed.
+func handshakeConfig(t *testing.T, serverConfig, clientConfig *tls.Config) bool {
+	t.Helper()
+
+	serverConn, clientConn := net.Pipe()
+	defer func() { _ = serverConn.Close() }()
+	defer func() { _ = clientConn.Close() }()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- tls.Server(serverConn, serverConfig).Handshake()
+	}()
+
+	client := tls.Client(clientConn, clientConfig)
+	require.NoError(t, client.Handshake())
+	require.NoError(t, <-errCh)
+
+	return client.ConnectionState().DidResume
+}
@@ -1,5 +1,7 @@
 package tls
 
+import ptypes "github.com/traefik/paerser/types"
+
 const certificateHeader = "-----BEGIN CERTIFICATE-----\n"
 
 // +k8s:deepcopy-gen=true
@@ -16,13 +18,28 @@ type ClientAuth struct {
 
 // Options configures TLS for an entry point.
 type Options struct {
-	MinVersion               string     `json:"minVersion,omitempty" toml:"minVersion,omitempty" yaml:"minVersion,omitempty" export:"true"`
-	MaxVersion               string     `json:"maxVersion,omitempty" toml:"maxVersion,omitempty" yaml:"maxVersion,omitempty" export:"true"`
-	CipherSuites             []string   `json:"cipherSuites,omitempty" toml:"cipherSuites,omitempty" yaml:"cipherSuites,omitempty" export:"true"`
-	CurvePreferences         []string   `json:"curvePreferences,omitempty" toml:"curvePreferences,omitempty" yaml:"curvePreferences,omitempty" export:"true"`
-	ClientAuth               ClientAuth `json:"clientAuth,omitempty" toml:"clientAuth,omitempty" yaml:"clientAuth,omitempty"`
-	SniStrict                bool       `json:"sniStrict,omitempty" toml:"sniStrict,omitempty" yaml:"sniStrict,omitempty" export:"true"`
-	PreferServerCipherSuites bool       `json:"preferServerCipherSuites,omitempty" toml:"preferServerCipherSuites,omitempty" yaml:"preferServerCipherSuites,omitempty" export:"true"`
+	MinVersion               string          `json:"minVersion,omitempty" toml:"minVersion,omitempty" yaml:"minVersion,omitempty" export:"true"`
+	MaxVersion               string          `json:"maxVersion,omitempty" toml:"maxVersion,omitempty" yaml:"maxVersion,omitempty" export:"true"`
+	CipherSuites             []string        `json:"cipherSuites,omitempty" toml:"cipherSuites,omitempty" yaml:"cipherSuites,omitempty" export:"true"`
+	CurvePreferences         []string        `json:"curvePreferences,omitempty" toml:"curvePreferences,omitempty" yaml:"curvePreferences,omitempty" export:"true"`
+	ClientAuth               ClientAuth      `json:"clientAuth,omitempty" toml:"clientAuth,omitempty" yaml:"clientAuth,omitempty"`
+	SniStrict                bool            `json:"sniStrict,omitempty" toml:"sniStrict,omitempty" yaml:"sniStrict,omitempty" export:"true"`
+	PreferServerCipherSuites bool            `json:"preferServerCipherSuites,omitempty" toml:"preferServerCipherSuites,omitempty" yaml:"preferServerCipherSuites,omitempty" export:"true"`
+	SessionTickets           *SessionTickets `json:"sessionTickets,omitempty" toml:"sessionTickets,omitempty" yaml:"sessionTickets,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// SessionTickets configures the session tickets used to resume the TLS sessions.
+type SessionTickets struct {
+	// Disabled disables the session tickets.
+	Disabled bool `json:"disabled,omitempty" toml:"disabled,omitempty" yaml:"disabled,omitempty" export:"true"`
+	// KeyFiles are the secrets the session ticket keys are derived from, shared by the instances.
+	// The first one is used to encrypt the new tickets, all of them are used to decrypt the tickets.
+	KeyFiles []FileOrContent `json:"keyFiles,omitempty" toml:"keyFiles,omitempty" yaml:"keyFiles,omitempty"`
+	// RotationInterval is the interval at which new session ticket keys are derived from the secrets.
+	// The keys of the previous interval are kept to decrypt the tickets.
+	RotationInterval ptypes.Duration `json:"rotationInterval,omitempty" toml:"rotationInterval,omitempty" yaml:"rotationInterval,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -38,4 +55,6 @@ type Store struct {
 type CertAndStores struct {
 	Certificate `yaml:",inline" export:"true"`
 	Stores      []string `json:"stores,omitempty" toml:"stores,omitempty" yaml:"stores,omitempty" export:"true"`
+	// Provider is the name of the provider of the certificate, set when the configurations are merged.
+	Provider string `json:"-" toml:"-" yaml:"-" label:"-" file:"-"`
 }
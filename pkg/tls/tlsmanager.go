@@ -24,7 +24,30 @@ type Manager struct {
 	stores       map[string]*CertificateStore
 	configs      map[string]Options
 	certs        []*CertAndStores
+	certsInfo    []CertificateInfo
+	issuers      []CertificateIssuer
 	lock         sync.RWMutex
+
+	// sessionTickets rotates the session ticket keys of the TLS configurations built since the last update.
+	sessionTickets sessionTicketsRotation
+}
+
+// CertificateIssuer issues certificates on demand,
+// during the TLS handshakes with server names for which the stores have no certificate.
+type CertificateIssuer interface {
+	// IssueCertificate returns a certificate for the server name, to be used in the given store,
+	// or nil if the issuer does not handle it.
+	IssueCertificate(serverName, storeName string) (*tls.Certificate, error)
+}
+
+// CertificateInfo describes a certificate loaded in the stores.
+type CertificateInfo struct {
+	// Provider is the name of the provider of the certificate, empty for the default certificates of the stores.
+	Provider string
+	Stores   []string
+	// Default is true for the default certificates of the stores.
+	Default     bool
+	Certificate *x509.Certificate
 }
 
 // NewManager creates a new Manager.
@@ -46,6 +69,9 @@ func (m *Manager) UpdateConfigs(ctx context.Context, stores map[string]Store, co
 	m.storesConfig = stores
 	m.certs = certs
 
+	// The TLS configurations built from the previous options are replaced once the routers are built again.
+	m.sessionTickets.reset()
+
 	m.stores = make(map[string]*CertificateStore)
 	for storeName, storeConfig := range m.storesConfig {
 		ctxStore := log.With(ctx, log.Str(log.TLSStoreName, storeName))
@@ -57,7 +83,9 @@ func (m *Manager) UpdateConfigs(ctx context.Context, stores map[string]Store, co
 		m.stores[storeName] = store
 	}
 
+	var certsInfo []CertificateInfo
 	storesCertificates := make(map[string]map[string]*tls.Certificate)
+	storesAlternativeCertificates := make(map[string]map[string][]*tls.Certificate)
 	for _, conf := range certs {
 		if len(conf.Stores) == 0 {
 			if log.GetLevel() >= logrus.DebugLevel {
@@ -66,16 +94,35 @@ func (m *Manager) UpdateConfigs(ctx context.Context, stores map[string]Store, co
 			}
 			conf.Stores = []string{"default"}
 		}
-		for _, store := range conf.Stores {
-			ctxStore := log.With(ctx, log.Str(log.TLSStoreName, store))
-			if err := conf.Certificate.AppendCertificate(storesCertificates, store); err != nil {
+
+		// The certificate is loaded once for all its stores.
+		tlsCert, x509Cert, err := conf.Certificate.load()
+		if err != nil {
+			for _, store := range conf.Stores {
+				ctxStore := log.With(ctx, log.Str(log.TLSStoreName, store))
 				log.FromContext(ctxStore).Errorf("Unable to append certificate %s to store: %v", conf.Certificate.GetTruncatedCertificateName(), err)
 			}
+			continue
+		}
+
+		for _, store := range conf.Stores {
+			appendCertificate(storesCertificates, storesAlternativeCertificates, store, tlsCert, x509Cert)
+		}
+
+		if !isChallengeCertificate(conf) {
+			certsInfo = append(certsInfo, CertificateInfo{
+				Provider:    conf.Provider,
+				Stores:      conf.Stores,
+				Certificate: x509Cert,
+			})
 		}
 	}
 
+	m.certsInfo = certsInfo
+
 	for storeName, certs := range storesCertificates {
 		m.getStore(storeName).DynamicCerts.Set(certs)
+		m.getStore(storeName).AlternativeCerts.Set(storesAlternativeCertificates[storeName])
 	}
 }
 
@@ -97,7 +144,7 @@ func (m *Manager) Get(storeName, configName string) (*tls.Config, error) {
 	acmeTLSStore := m.getStore(tlsalpn01.ACMETLS1Protocol)
 
 	if err == nil {
-		tlsConfig, err = buildTLSConfig(config)
+		tlsConfig, err = buildTLSConfig(config, &m.sessionTickets)
 		if err != nil {
 			tlsConfig = &tls.Config{}
 		}
@@ -120,6 +167,10 @@ func (m *Manager) Get(storeName, configName string) (*tls.Config, error) {
 			return bestCertificate, nil
 		}
 
+		if certificate := m.issueCertificate(domainToCheck, storeName); certificate != nil {
+			return certificate, nil
+		}
+
 		if m.configs[configName].SniStrict {
 			return nil, fmt.Errorf("strict SNI enabled - No certificate found for domain: %q, closing connection", domainToCheck)
 		}
@@ -131,6 +182,39 @@ func (m *Manager) Get(storeName, configName string) (*tls.Config, error) {
 	return tlsConfig, err
 }
 
+// AddCertificateIssuer adds an issuer of certificates for the server names unknown to the stores.
+func (m *Manager) AddCertificateIssuer(issuer CertificateIssuer) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.issuers = append(m.issuers, issuer)
+}
+
+// issueCertificate asks the issuers for a certificate for the server name, in turn.
+func (m *Manager) issueCertificate(serverName, storeName string) *tls.Certificate {
+	if serverName == "" {
+		return nil
+	}
+
+	m.lock.RLock()
+	issuers := m.issuers
+	m.lock.RUnlock()
+
+	for _, issuer := range issuers {
+		certificate, err := issuer.IssueCertificate(serverName, storeName)
+		if err != nil {
+			log.WithoutContext().Debugf("Unable to issue a certificate on demand for %q: %v", serverName, err)
+			continue
+		}
+
+		if certificate != nil {
+			return certificate
+		}
+	}
+
+	return nil
+}
+
 // GetCertificates returns all stored certificates.
 func (m *Manager) GetCertificates() []*x509.Certificate {
 	var certificates []*x509.Certificate
@@ -147,11 +231,66 @@ func (m *Manager) GetCertificates() []*x509.Certificate {
 				certificates = append(certificates, x509Cert)
 			}
 		}
+
+		if store.AlternativeCerts != nil && store.AlternativeCerts.Get() != nil {
+			for _, certs := range store.AlternativeCerts.Get().(map[string][]*tls.Certificate) {
+				for _, cert := range certs {
+					x509Cert, err := x509.ParseCertificate(cert.Certificate[0])
+					if err != nil {
+						continue
+					}
+
+					certificates = append(certificates, x509Cert)
+				}
+			}
+		}
 	}
 
 	return certificates
 }
 
+// GetCertificatesInfo returns the description of the certificates loaded in the stores,
+// followed by the default certificates of the stores.
+func (m *Manager) GetCertificatesInfo() []CertificateInfo {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	certsInfo := make([]CertificateInfo, 0, len(m.certsInfo)+len(m.stores))
+	certsInfo = append(certsInfo, m.certsInfo...)
+
+	for storeName, store := range m.stores {
+		if storeName == tlsalpn01.ACMETLS1Protocol || store.DefaultCertificate == nil || len(store.DefaultCertificate.Certificate) == 0 {
+			continue
+		}
+
+		x509Cert, err := x509.ParseCertificate(store.DefaultCertificate.Certificate[0])
+		if err != nil {
+			continue
+		}
+
+		certsInfo = append(certsInfo, CertificateInfo{
+			Stores:      []string{storeName},
+			Default:     true,
+			Certificate: x509Cert,
+		})
+	}
+
+	return certsInfo
+}
+
+// GetOptions returns the TLS options, by name.
+func (m *Manager) GetOptions() map[string]Options {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	options := make(map[string]Options, len(m.configs))
+	for name, config := range m.configs {
+		options[name] = config
+	}
+
+	return options
+}
+
 func (m *Manager) getStore(storeName string) *CertificateStore {
 	_, ok := m.stores[storeName]
 	if !ok {
@@ -189,8 +328,19 @@ func buildCertificateStore(ctx context.Context, tlsStore Store) (*CertificateSto
 	return certificateStore, nil
 }
 
+// isChallengeCertificate returns whether the certificate is a temporary certificate of a TLS-ALPN-01 challenge.
+func isChallengeCertificate(conf *CertAndStores) bool {
+	for _, store := range conf.Stores {
+		if store == tlsalpn01.ACMETLS1Protocol {
+			return true
+		}
+	}
+
+	return false
+}
+
 // creates a TLS config that allows terminating HTTPS for multiple domains using SNI.
-func buildTLSConfig(tlsOption Options) (*tls.Config, error) {
+func buildTLSConfig(tlsOption Options, sessionTickets *sessionTicketsRotation) (*tls.Config, error) {
 	conf := &tls.Config{}
 
 	// ensure http2 enabled
@@ -281,6 +431,12 @@ func buildTLSConfig(tlsOption Options) (*tls.Config, error) {
 		}
 	}
 
+	if tlsOption.SessionTickets != nil {
+		if err := setSessionTickets(conf, *tlsOption.SessionTickets, sessionTickets); err != nil {
+			return nil, err
+		}
+	}
+
 	return conf, nil
 }
 
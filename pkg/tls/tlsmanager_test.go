@@ -5,8 +5,10 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"net"
 	"testing"
 
+	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -91,6 +93,41 @@ func TestTLSInvalidStore(t *testing.T) {
 	}
 }
 
+func TestManager_GetCertificatesInfo(t *testing.T) {
+	dynamicConfigs := []*CertAndStores{
+		{
+			Certificate: Certificate{CertFile: localhostCert, KeyFile: localhostKey},
+			Provider:    "file",
+		},
+		{
+			Certificate: Certificate{CertFile: localhostCert, KeyFile: localhostKey},
+			Stores:      []string{tlsalpn01.ACMETLS1Protocol},
+			Provider:    "tlsalpn.acme",
+		},
+		{
+			Certificate: Certificate{CertFile: "/wrong", KeyFile: "/wrong"},
+			Provider:    "file",
+		},
+	}
+
+	tlsManager := NewManager()
+	tlsManager.UpdateConfigs(context.Background(), map[string]Store{"default": {}}, nil, dynamicConfigs)
+
+	certsInfo := tlsManager.GetCertificatesInfo()
+	require.Len(t, certsInfo, 2)
+
+	assert.Equal(t, "file", certsInfo[0].Provider)
+	assert.Equal(t, []string{"default"}, certsInfo[0].Stores)
+	assert.False(t, certsInfo[0].Default)
+	require.NotNil(t, certsInfo[0].Certificate)
+	assert.Equal(t, []string{"example.com"}, certsInfo[0].Certificate.DNSNames)
+
+	assert.Empty(t, certsInfo[1].Provider)
+	assert.Equal(t, []string{"default"}, certsInfo[1].Stores)
+	assert.True(t, certsInfo[1].Default)
+	assert.NotNil(t, certsInfo[1].Certificate)
+}
+
 func TestManager_Get(t *testing.T) {
 	dynamicConfigs := []*CertAndStores{{
 		Certificate: Certificate{
@@ -152,6 +189,54 @@ func TestManager_Get(t *testing.T) {
 	}
 }
 
+type issuerMock struct {
+	storeName   string
+	serverNames []string
+}
+
+func (i *issuerMock) IssueCertificate(serverName, storeName string) (*tls.Certificate, error) {
+	i.serverNames = append(i.serverNames, serverName)
+	if storeName != i.storeName {
+		return nil, nil
+	}
+
+	cert, err := tls.X509KeyPair([]byte(localhostCert), []byte(localhostKey))
+	return &cert, err
+}
+
+func TestManager_Get_certificateIssuer(t *testing.T) {
+	tlsManager := NewManager()
+	tlsManager.UpdateConfigs(context.Background(), nil, map[string]Options{"default": {}}, nil)
+
+	issuer := &issuerMock{storeName: "default"}
+	tlsManager.AddCertificateIssuer(issuer)
+
+	config, err := tlsManager.Get("default", "default")
+	require.NoError(t, err)
+
+	cert, err := config.GetCertificate(&tls.ClientHelloInfo{ServerName: "Foo.com"})
+	require.NoError(t, err)
+	require.NotNil(t, cert)
+	assert.NotEqual(t, tlsManager.GetStore("default").DefaultCertificate, cert)
+
+	// No certificate is issued without server name.
+	conn, _ := net.Pipe()
+	defer func() { _ = conn.Close() }()
+
+	cert, err = config.GetCertificate(&tls.ClientHelloInfo{Conn: conn})
+	require.NoError(t, err)
+	assert.Equal(t, tlsManager.GetStore("default").DefaultCertificate, cert)
+
+	config, err = tlsManager.Get("other", "default")
+	require.NoError(t, err)
+
+	cert, err = config.GetCertificate(&tls.ClientHelloInfo{ServerName: "bar.com"})
+	require.NoError(t, err)
+	assert.Equal(t, tlsManager.GetStore("other").DefaultCertificate, cert)
+
+	assert.Equal(t, []string{"foo.com", "bar.com"}, issuer.serverNames)
+}
+
 func TestClientAuth(t *testing.T) {
 	tlsConfigs := map[string]Options{
 		"eca": {
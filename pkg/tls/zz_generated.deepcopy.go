@@ -85,6 +85,11 @@ func (in *Options) DeepCopyInto(out *Options) {
 		copy(*out, *in)
 	}
 	in.ClientAuth.DeepCopyInto(&out.ClientAuth)
+	if in.SessionTickets != nil {
+		in, out := &in.SessionTickets, &out.SessionTickets
+		*out = new(SessionTickets)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -98,6 +103,27 @@ func (in *Options) DeepCopy() *Options {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionTickets) DeepCopyInto(out *SessionTickets) {
+	*out = *in
+	if in.KeyFiles != nil {
+		in, out := &in.KeyFiles, &out.KeyFiles
+		*out = make([]FileOrContent, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionTickets.
+func (in *SessionTickets) DeepCopy() *SessionTickets {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionTickets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Store) DeepCopyInto(out *Store) {
 	*out = *in
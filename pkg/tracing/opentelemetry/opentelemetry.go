@@ -0,0 +1,87 @@
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/otlp"
+	"github.com/traefik/traefik/v2/pkg/types"
+	jaegercli "github.com/uber/jaeger-client-go"
+)
+
+// Name sets the name of this tracer.
+const Name = "opentelemetry"
+
+// Config provides configuration settings for an OpenTelemetry tracer.
+type Config struct {
+	GRPC *GRPC `description:"gRPC specific configuration for the OpenTelemetry collector." json:"grpc,omitempty" toml:"grpc,omitempty" yaml:"grpc,omitempty" export:"true" label:"allowEmpty" file:"allowEmpty"`
+
+	Address            string            `description:"Sets the address (host:port) of the collector endpoint (default: localhost:4318 with HTTP, localhost:4317 with gRPC)." json:"address,omitempty" toml:"address,omitempty" yaml:"address,omitempty"`
+	Path               string            `description:"Sets the URL path of the collector endpoint." json:"path,omitempty" toml:"path,omitempty" yaml:"path,omitempty" export:"true"`
+	Insecure           bool              `description:"Disables client transport security for the exporter." json:"insecure,omitempty" toml:"insecure,omitempty" yaml:"insecure,omitempty" export:"true"`
+	Headers            map[string]string `description:"Defines additional headers to be sent with the payloads." json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty"`
+	TLS                *types.ClientTLS  `description:"Defines client transport security parameters." json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+	SampleRate         float64           `description:"The rate between 0.0 and 1.0 of requests to trace." json:"sampleRate,omitempty" toml:"sampleRate,omitempty" yaml:"sampleRate,omitempty" export:"true"`
+	ResourceAttributes map[string]string `description:"Defines additional resource attributes (key:value)." json:"resourceAttributes,omitempty" toml:"resourceAttributes,omitempty" yaml:"resourceAttributes,omitempty" export:"true"`
+}
+
+// GRPC provides configuration settings for the gRPC transport of the OpenTelemetry exporter.
+type GRPC struct{}
+
+// SetDefaults sets the default values.
+func (c *Config) SetDefaults() {
+	c.Path = "/v1/traces"
+	c.SampleRate = 1.0
+}
+
+// Setup sets up the tracer.
+func (c *Config) Setup(componentName string) (opentracing.Tracer, io.Closer, error) {
+	exp, err := otlp.NewExporter(context.Background(), otlp.Endpoint{
+		Address:  c.Address,
+		Path:     c.Path,
+		Insecure: c.Insecure,
+		Headers:  c.Headers,
+		TLS:      c.TLS,
+		GRPC:     c.GRPC != nil,
+	}, otlp.TracesGRPCMethod)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating exporter: %w", err)
+	}
+
+	sampler, err := c.newSampler()
+	if err != nil {
+		_ = exp.Close()
+		return nil, nil, err
+	}
+
+	propagator := &w3cPropagator{}
+
+	tracer, closer := jaegercli.NewTracer(
+		componentName,
+		sampler,
+		newReporter(exp, otlp.ResourceAttributes(componentName, c.ResourceAttributes)),
+		jaegercli.TracerOptions.Gen128Bit(true),
+		jaegercli.TracerOptions.Injector(opentracing.HTTPHeaders, propagator),
+		jaegercli.TracerOptions.Extractor(opentracing.HTTPHeaders, propagator),
+		jaegercli.TracerOptions.Injector(opentracing.TextMap, propagator),
+		jaegercli.TracerOptions.Extractor(opentracing.TextMap, propagator),
+	)
+
+	// Without this, child spans are getting the NOOP tracer
+	opentracing.SetGlobalTracer(tracer)
+
+	log.WithoutContext().Debug("OpenTelemetry tracer configured")
+
+	return tracer, closer, nil
+}
+
+func (c *Config) newSampler() (jaegercli.Sampler, error) {
+	if c.SampleRate >= 1 {
+		return jaegercli.NewConstSampler(true), nil
+	}
+
+	return jaegercli.NewProbabilisticSampler(c.SampleRate)
+}
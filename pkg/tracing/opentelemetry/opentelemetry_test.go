@@ -0,0 +1,223 @@
+package opentelemetry
+
+import (
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/otlp"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestTracing_HTTP(t *testing.T) {
+	payloads := make(chan []byte, 10)
+
+	collector := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/v1/traces", req.URL.Path)
+		assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", req.Header.Get("X-Api-Key"))
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		payloads <- body
+	}))
+	t.Cleanup(collector.Close)
+
+	config := &Config{}
+	config.SetDefaults()
+	config.Address = strings.TrimPrefix(collector.URL, "http://")
+	config.Insecure = true
+	config.Headers = map[string]string{"X-Api-Key": "secret"}
+	config.ResourceAttributes = map[string]string{"deployment.environment": "test"}
+
+	generateTrace(t, config)
+
+	require.Len(t, payloads, 1)
+	assertTrace(t, <-payloads)
+}
+
+func TestTracing_Sampling(t *testing.T) {
+	payloads := make(chan []byte, 10)
+
+	collector := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		payloads <- body
+	}))
+	t.Cleanup(collector.Close)
+
+	config := &Config{}
+	config.SetDefaults()
+	config.Address = strings.TrimPrefix(collector.URL, "http://")
+	config.Insecure = true
+	config.SampleRate = 0
+
+	generateTrace(t, config)
+
+	assert.Empty(t, payloads)
+}
+
+// generateTrace creates a server span, continuing the trace of an incoming request, and its child client span.
+func generateTrace(t *testing.T, config *Config) {
+	t.Helper()
+
+	tracer, closer, err := config.Setup("traefik")
+	require.NoError(t, err)
+
+	headers := http.Header{}
+	headers.Set("Traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	headers.Set("Tracestate", "congo=t61rcWkgMzE")
+
+	parentContext, err := tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(headers))
+	require.NoError(t, err)
+
+	if config.SampleRate == 0 {
+		// Discard the sampling decision of the incoming request.
+		parentContext = nil
+	}
+
+	serverSpan := tracer.StartSpan("EntryPoint web", ext.RPCServerOption(parentContext))
+	serverSpan.SetTag("http.method", http.MethodGet)
+	serverSpan.SetTag("http.status_code", 503)
+	ext.Error.Set(serverSpan, true)
+
+	clientSpan := tracer.StartSpan("forward whoami", opentracing.ChildOf(serverSpan.Context()), ext.SpanKindRPCClient)
+	clientSpan.LogKV("event", "retry", "attempt", 2)
+
+	outgoing := http.Header{}
+	require.NoError(t, tracer.Inject(clientSpan.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(outgoing)))
+	if config.SampleRate != 0 {
+		assert.True(t, strings.HasPrefix(outgoing.Get("Traceparent"), "00-0af7651916cd43dd8448eb211c80319c-"))
+		assert.Equal(t, "congo=t61rcWkgMzE", outgoing.Get("Tracestate"))
+	}
+
+	clientSpan.Finish()
+	serverSpan.Finish()
+
+	require.NoError(t, closer.Close())
+}
+
+func assertTrace(t *testing.T, payload []byte) {
+	t.Helper()
+
+	resourceSpans := decodeMessage(t, singleField(t, decodeMessage(t, payload), 1))
+
+	resource := decodeMessage(t, singleField(t, resourceSpans, 1))
+	assert.Equal(t, map[string]interface{}{
+		"service.name":           "traefik",
+		"deployment.environment": "test",
+	}, decodeAttributes(t, resource[1]))
+
+	scopeSpans := decodeMessage(t, singleField(t, resourceSpans, 2))
+	scope := decodeMessage(t, singleField(t, scopeSpans, 1))
+	assert.Equal(t, otlp.InstrumentationScope, string(scope[1][0]))
+
+	require.Len(t, scopeSpans[2], 2)
+	clientSpan := decodeMessage(t, scopeSpans[2][0])
+	serverSpan := decodeMessage(t, scopeSpans[2][1])
+
+	traceID := []byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x80, 0x31, 0x9c}
+
+	assert.Equal(t, traceID, serverSpan[1][0])
+	assert.Equal(t, []byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31}, serverSpan[4][0])
+	assert.Equal(t, "congo=t61rcWkgMzE", string(serverSpan[3][0]))
+	assert.Equal(t, "EntryPoint web", string(serverSpan[5][0]))
+	assert.Equal(t, uint64(spanKindServer), decodeVarint(t, serverSpan[6][0]))
+	assert.Equal(t, map[string]interface{}{
+		"http.method":      http.MethodGet,
+		"http.status_code": int64(503),
+	}, decodeAttributes(t, serverSpan[9]))
+
+	status := decodeMessage(t, singleField(t, serverSpan, 15))
+	assert.Equal(t, uint64(statusCodeError), decodeVarint(t, status[3][0]))
+
+	assert.Equal(t, traceID, clientSpan[1][0])
+	assert.Equal(t, serverSpan[2][0], clientSpan[4][0])
+	assert.Equal(t, "forward whoami", string(clientSpan[5][0]))
+	assert.Equal(t, uint64(spanKindClient), decodeVarint(t, clientSpan[6][0]))
+
+	spanEvent := decodeMessage(t, singleField(t, clientSpan, 11))
+	assert.Equal(t, "retry", string(spanEvent[2][0]))
+	assert.Equal(t, map[string]interface{}{"attempt": int64(2)}, decodeAttributes(t, spanEvent[3]))
+}
+
+// decodeMessage decodes a protobuf message into its raw field values indexed by field number,
+// the varint and fixed64 values being kept in their wire representation.
+func decodeMessage(t *testing.T, b []byte) map[protowire.Number][][]byte {
+	t.Helper()
+
+	fields := make(map[protowire.Number][][]byte)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		require.GreaterOrEqual(t, n, 0)
+
+		value := b[:n]
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		fields[num] = append(fields[num], value)
+
+		b = b[n:]
+	}
+
+	return fields
+}
+
+func singleField(t *testing.T, fields map[protowire.Number][][]byte, num protowire.Number) []byte {
+	t.Helper()
+
+	values := fields[num]
+	require.Len(t, values, 1)
+
+	return values[0]
+}
+
+func decodeVarint(t *testing.T, b []byte) uint64 {
+	t.Helper()
+
+	v, n := protowire.ConsumeVarint(b)
+	require.GreaterOrEqual(t, n, 0)
+
+	return v
+}
+
+func decodeAttributes(t *testing.T, kvs [][]byte) map[string]interface{} {
+	t.Helper()
+
+	attrs := make(map[string]interface{})
+	for _, raw := range kvs {
+		kv := decodeMessage(t, raw)
+		anyValue := decodeMessage(t, kv[2][0])
+
+		var value interface{}
+		switch {
+		case anyValue[1] != nil:
+			value = string(anyValue[1][0])
+		case anyValue[2] != nil:
+			value = protowire.DecodeBool(decodeVarint(t, anyValue[2][0]))
+		case anyValue[3] != nil:
+			value = int64(decodeVarint(t, anyValue[3][0]))
+		case anyValue[4] != nil:
+			v, _ := protowire.ConsumeFixed64(anyValue[4][0])
+			value = math.Float64frombits(v)
+		}
+
+		attrs[string(kv[1][0])] = value
+	}
+
+	return attrs
+}
@@ -0,0 +1,96 @@
+package opentelemetry
+
+import (
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/otlp"
+)
+
+// The OTLP span kinds.
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/trace/v1/trace.proto
+const (
+	spanKindInternal = 1
+	spanKindServer   = 2
+	spanKindClient   = 3
+	spanKindProducer = 4
+	spanKindConsumer = 5
+)
+
+// statusCodeError is the OTLP status code of the spans in error.
+const statusCodeError = 2
+
+// span is the OTLP representation of a finished span.
+type span struct {
+	traceID       [16]byte
+	spanID        [8]byte
+	parentSpanID  [8]byte
+	traceState    string
+	name          string
+	kind          int
+	start         time.Time
+	end           time.Time
+	attributes    []otlp.KeyValue
+	events        []event
+	statusCode    int
+	statusMessage string
+}
+
+// event is the OTLP representation of a span log.
+type event struct {
+	time       time.Time
+	name       string
+	attributes []otlp.KeyValue
+}
+
+// marshalTraces encodes the spans as an OTLP ExportTraceServiceRequest.
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/collector/trace/v1/trace_service.proto
+func marshalTraces(resource []otlp.KeyValue, spans []span) []byte {
+	scopeSpans := otlp.AppendMessage(nil, 1, otlp.MarshalScope())
+	for _, s := range spans {
+		scopeSpans = otlp.AppendMessage(scopeSpans, 2, marshalSpan(s))
+	}
+
+	var resourceSpans []byte
+	resourceSpans = otlp.AppendMessage(resourceSpans, 1, otlp.MarshalResource(resource))
+	resourceSpans = otlp.AppendMessage(resourceSpans, 2, scopeSpans)
+
+	return otlp.AppendMessage(nil, 1, resourceSpans)
+}
+
+func marshalSpan(s span) []byte {
+	var b []byte
+	b = otlp.AppendMessage(b, 1, s.traceID[:])
+	b = otlp.AppendMessage(b, 2, s.spanID[:])
+	b = otlp.AppendString(b, 3, s.traceState)
+
+	if s.parentSpanID != [8]byte{} {
+		b = otlp.AppendMessage(b, 4, s.parentSpanID[:])
+	}
+
+	b = otlp.AppendString(b, 5, s.name)
+	b = otlp.AppendVarint(b, 6, uint64(s.kind))
+	b = otlp.AppendFixed64(b, 7, uint64(s.start.UnixNano()))
+	b = otlp.AppendFixed64(b, 8, uint64(s.end.UnixNano()))
+	b = otlp.AppendAttributes(b, 9, s.attributes)
+
+	for _, e := range s.events {
+		b = otlp.AppendMessage(b, 11, marshalEvent(e))
+	}
+
+	if s.statusCode != 0 {
+		var status []byte
+		status = otlp.AppendString(status, 2, s.statusMessage)
+		status = otlp.AppendVarint(status, 3, uint64(s.statusCode))
+
+		b = otlp.AppendMessage(b, 15, status)
+	}
+
+	return b
+}
+
+func marshalEvent(e event) []byte {
+	var b []byte
+	b = otlp.AppendFixed64(b, 1, uint64(e.time.UnixNano()))
+	b = otlp.AppendString(b, 2, e.name)
+	return otlp.AppendAttributes(b, 3, e.attributes)
+}
@@ -0,0 +1,150 @@
+package opentelemetry
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	jaegercli "github.com/uber/jaeger-client-go"
+)
+
+const (
+	traceParentHeader = "traceparent"
+	traceStateHeader  = "tracestate"
+
+	// traceStateBaggageKey is the baggage item carrying the W3C tracestate,
+	// the baggage being inherited by the child spans.
+	traceStateBaggageKey = "w3c-tracestate"
+
+	supportedVersion = "00"
+	sampledFlag      = 0x01
+)
+
+// w3cPropagator propagates the span contexts using the W3C Trace Context format.
+// https://www.w3.org/TR/trace-context/
+type w3cPropagator struct{}
+
+// Inject implements jaegercli.Injector.
+func (p *w3cPropagator) Inject(sc jaegercli.SpanContext, abstractCarrier interface{}) error {
+	carrier, ok := abstractCarrier.(opentracing.TextMapWriter)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+
+	var flags byte
+	if sc.IsSampled() {
+		flags = sampledFlag
+	}
+
+	traceID := sc.TraceID()
+	carrier.Set(traceParentHeader, fmt.Sprintf("%s-%016x%016x-%016x-%02x", supportedVersion, traceID.High, traceID.Low, uint64(sc.SpanID()), flags))
+
+	var traceState string
+	sc.ForeachBaggageItem(func(k, v string) bool {
+		if k == traceStateBaggageKey {
+			traceState = v
+			return false
+		}
+		return true
+	})
+
+	if traceState != "" {
+		carrier.Set(traceStateHeader, traceState)
+	}
+
+	return nil
+}
+
+// Extract implements jaegercli.Extractor.
+func (p *w3cPropagator) Extract(abstractCarrier interface{}) (jaegercli.SpanContext, error) {
+	carrier, ok := abstractCarrier.(opentracing.TextMapReader)
+	if !ok {
+		return jaegercli.SpanContext{}, opentracing.ErrInvalidCarrier
+	}
+
+	var traceParent, traceState string
+	err := carrier.ForeachKey(func(key, val string) error {
+		switch strings.ToLower(key) {
+		case traceParentHeader:
+			traceParent = val
+		case traceStateHeader:
+			traceState = val
+		}
+		return nil
+	})
+	if err != nil {
+		return jaegercli.SpanContext{}, err
+	}
+
+	if traceParent == "" {
+		return jaegercli.SpanContext{}, opentracing.ErrSpanContextNotFound
+	}
+
+	traceID, spanID, sampled, err := parseTraceParent(traceParent)
+	if err != nil {
+		return jaegercli.SpanContext{}, err
+	}
+
+	var baggage map[string]string
+	if traceState != "" {
+		baggage = map[string]string{traceStateBaggageKey: traceState}
+	}
+
+	return jaegercli.NewSpanContext(traceID, spanID, 0, sampled, baggage), nil
+}
+
+// parseTraceParent parses a traceparent header value: version-traceid-parentid-flags.
+func parseTraceParent(value string) (jaegercli.TraceID, jaegercli.SpanID, bool, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return jaegercli.TraceID{}, 0, false, opentracing.ErrSpanContextCorrupted
+	}
+
+	version, err := decodeHex(parts[0], 1)
+	if err != nil || version[0] == 0xff {
+		return jaegercli.TraceID{}, 0, false, opentracing.ErrSpanContextCorrupted
+	}
+
+	// Future versions may append fields, the version 00 does not.
+	if parts[0] == supportedVersion && len(parts) != 4 {
+		return jaegercli.TraceID{}, 0, false, opentracing.ErrSpanContextCorrupted
+	}
+
+	rawTraceID, err := decodeHex(parts[1], 16)
+	if err != nil {
+		return jaegercli.TraceID{}, 0, false, opentracing.ErrSpanContextCorrupted
+	}
+
+	rawSpanID, err := decodeHex(parts[2], 8)
+	if err != nil {
+		return jaegercli.TraceID{}, 0, false, opentracing.ErrSpanContextCorrupted
+	}
+
+	flags, err := decodeHex(parts[3], 1)
+	if err != nil {
+		return jaegercli.TraceID{}, 0, false, opentracing.ErrSpanContextCorrupted
+	}
+
+	traceID := jaegercli.TraceID{
+		High: binary.BigEndian.Uint64(rawTraceID[:8]),
+		Low:  binary.BigEndian.Uint64(rawTraceID[8:]),
+	}
+	spanID := jaegercli.SpanID(binary.BigEndian.Uint64(rawSpanID))
+
+	if !traceID.IsValid() || spanID == 0 {
+		return jaegercli.TraceID{}, 0, false, opentracing.ErrSpanContextCorrupted
+	}
+
+	return traceID, spanID, flags[0]&sampledFlag == sampledFlag, nil
+}
+
+// decodeHex decodes a lowercase hexadecimal string of the given decoded length.
+func decodeHex(value string, length int) ([]byte, error) {
+	if len(value) != 2*length || strings.ToLower(value) != value {
+		return nil, fmt.Errorf("invalid hexadecimal value: %q", value)
+	}
+
+	return hex.DecodeString(value)
+}
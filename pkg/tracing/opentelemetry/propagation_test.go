@@ -0,0 +1,148 @@
+package opentelemetry
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jaegercli "github.com/uber/jaeger-client-go"
+)
+
+func TestW3CPropagator_Extract(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		headers         map[string]string
+		expectedErr     error
+		expectedTraceID jaegercli.TraceID
+		expectedSpanID  jaegercli.SpanID
+		expectedSampled bool
+		expectedState   string
+	}{
+		{
+			desc:        "no traceparent",
+			headers:     map[string]string{},
+			expectedErr: opentracing.ErrSpanContextNotFound,
+		},
+		{
+			desc: "sampled",
+			headers: map[string]string{
+				"Traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+			},
+			expectedTraceID: jaegercli.TraceID{High: 0x0af7651916cd43dd, Low: 0x8448eb211c80319c},
+			expectedSpanID:  0xb7ad6b7169203331,
+			expectedSampled: true,
+		},
+		{
+			desc: "not sampled with tracestate",
+			headers: map[string]string{
+				"Traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00",
+				"Tracestate":  "rojo=00f067aa0ba902b7,congo=t61rcWkgMzE",
+			},
+			expectedTraceID: jaegercli.TraceID{High: 0x0af7651916cd43dd, Low: 0x8448eb211c80319c},
+			expectedSpanID:  0xb7ad6b7169203331,
+			expectedState:   "rojo=00f067aa0ba902b7,congo=t61rcWkgMzE",
+		},
+		{
+			desc: "future version with additional fields",
+			headers: map[string]string{
+				"Traceparent": "cc-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-what-the-future-will-be-like",
+			},
+			expectedTraceID: jaegercli.TraceID{High: 0x0af7651916cd43dd, Low: 0x8448eb211c80319c},
+			expectedSpanID:  0xb7ad6b7169203331,
+			expectedSampled: true,
+		},
+		{
+			desc: "version 00 with additional fields",
+			headers: map[string]string{
+				"Traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra",
+			},
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc: "forbidden version",
+			headers: map[string]string{
+				"Traceparent": "ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+			},
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc: "uppercase trace ID",
+			headers: map[string]string{
+				"Traceparent": "00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01",
+			},
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc: "zero trace ID",
+			headers: map[string]string{
+				"Traceparent": "00-00000000000000000000000000000000-b7ad6b7169203331-01",
+			},
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc: "zero parent ID",
+			headers: map[string]string{
+				"Traceparent": "00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01",
+			},
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+		{
+			desc: "truncated",
+			headers: map[string]string{
+				"Traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+			},
+			expectedErr: opentracing.ErrSpanContextCorrupted,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			headers := http.Header{}
+			for k, v := range test.headers {
+				headers.Set(k, v)
+			}
+
+			propagator := &w3cPropagator{}
+			sc, err := propagator.Extract(opentracing.HTTPHeadersCarrier(headers))
+			if test.expectedErr != nil {
+				assert.Equal(t, test.expectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedTraceID, sc.TraceID())
+			assert.Equal(t, test.expectedSpanID, sc.SpanID())
+			assert.Equal(t, test.expectedSampled, sc.IsSampled())
+
+			var state string
+			sc.ForeachBaggageItem(func(k, v string) bool {
+				if k == traceStateBaggageKey {
+					state = v
+				}
+				return true
+			})
+			assert.Equal(t, test.expectedState, state)
+		})
+	}
+}
+
+func TestW3CPropagator_Inject(t *testing.T) {
+	traceID := jaegercli.TraceID{High: 0x0af7651916cd43dd, Low: 0x8448eb211c80319c}
+	sc := jaegercli.NewSpanContext(traceID, 0xb7ad6b7169203331, 0, true, map[string]string{traceStateBaggageKey: "congo=t61rcWkgMzE"})
+
+	carrier := opentracing.TextMapCarrier{}
+	propagator := &w3cPropagator{}
+	require.NoError(t, propagator.Inject(sc, carrier))
+
+	assert.Equal(t, opentracing.TextMapCarrier{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"tracestate":  "congo=t61rcWkgMzE",
+	}, carrier)
+
+	assert.Equal(t, opentracing.ErrInvalidCarrier, propagator.Inject(sc, "carrier"))
+}
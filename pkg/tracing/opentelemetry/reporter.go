@@ -0,0 +1,248 @@
+package opentelemetry
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/traefik/traefik/v2/pkg/log"
+	"github.com/traefik/traefik/v2/pkg/otlp"
+	"github.com/traefik/traefik/v2/pkg/safe"
+	jaegercli "github.com/uber/jaeger-client-go"
+)
+
+const (
+	defaultFlushInterval = 5 * time.Second
+	defaultBatchSize     = 512
+	defaultMaxQueueSize  = 2048
+	exportTimeout        = 10 * time.Second
+)
+
+// reporter is a jaegercli.Reporter converting the finished spans to OTLP,
+// and exporting them by batches.
+type reporter struct {
+	exporter      otlp.Exporter
+	resource      []otlp.KeyValue
+	flushInterval time.Duration
+	batchSize     int
+	maxQueueSize  int
+
+	mu    sync.Mutex
+	queue []span
+
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+	once    sync.Once
+}
+
+func newReporter(exp otlp.Exporter, resource []otlp.KeyValue) *reporter {
+	r := &reporter{
+		exporter:      exp,
+		resource:      resource,
+		flushInterval: defaultFlushInterval,
+		batchSize:     defaultBatchSize,
+		maxQueueSize:  defaultMaxQueueSize,
+		flushCh:       make(chan struct{}, 1),
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+
+	safe.Go(r.loop)
+
+	return r
+}
+
+// Report implements jaegercli.Reporter.
+func (r *reporter) Report(s *jaegercli.Span) {
+	converted := convertSpan(s)
+
+	r.mu.Lock()
+	if len(r.queue) >= r.maxQueueSize {
+		r.mu.Unlock()
+		log.WithoutContext().Debugf("OpenTelemetry span queue is full, dropping span %q", converted.name)
+		return
+	}
+
+	r.queue = append(r.queue, converted)
+	full := len(r.queue) >= r.batchSize
+	r.mu.Unlock()
+
+	if full {
+		select {
+		case r.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close implements jaegercli.Reporter.
+// It exports the remaining spans before closing the exporter.
+func (r *reporter) Close() {
+	r.once.Do(func() {
+		close(r.stopCh)
+		<-r.doneCh
+
+		r.flush()
+
+		if err := r.exporter.Close(); err != nil {
+			log.WithoutContext().Errorf("Error while closing OpenTelemetry exporter: %v", err)
+		}
+	})
+}
+
+func (r *reporter) loop() {
+	defer close(r.doneCh)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			r.flush()
+		case <-r.flushCh:
+			r.flush()
+		}
+	}
+}
+
+// flush exports all the queued spans.
+func (r *reporter) flush() {
+	for {
+		r.mu.Lock()
+		n := len(r.queue)
+		if n == 0 {
+			r.mu.Unlock()
+			return
+		}
+		if n > r.batchSize {
+			n = r.batchSize
+		}
+		batch := r.queue[:n:n]
+		r.queue = r.queue[n:]
+		r.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		err := r.exporter.Export(ctx, marshalTraces(r.resource, batch))
+		cancel()
+
+		if err != nil {
+			log.WithoutContext().Errorf("Error while exporting %d spans to OpenTelemetry collector: %v", len(batch), err)
+		}
+	}
+}
+
+// convertSpan converts the finished span to its OTLP representation.
+// It must not retain the span, which is reused by the tracer once reported.
+func convertSpan(s *jaegercli.Span) span {
+	sc := s.SpanContext()
+
+	converted := span{
+		name:  s.OperationName(),
+		kind:  spanKindInternal,
+		start: s.StartTime(),
+		end:   s.StartTime().Add(s.Duration()),
+	}
+
+	traceID := sc.TraceID()
+	binary.BigEndian.PutUint64(converted.traceID[:8], traceID.High)
+	binary.BigEndian.PutUint64(converted.traceID[8:], traceID.Low)
+	binary.BigEndian.PutUint64(converted.spanID[:], uint64(sc.SpanID()))
+	binary.BigEndian.PutUint64(converted.parentSpanID[:], uint64(sc.ParentID()))
+
+	sc.ForeachBaggageItem(func(k, v string) bool {
+		if k == traceStateBaggageKey {
+			converted.traceState = v
+			return false
+		}
+		return true
+	})
+
+	for key, value := range s.Tags() {
+		switch key {
+		case string(ext.SpanKind):
+			converted.kind = convertSpanKind(value)
+		case string(ext.Error):
+			if isErr, ok := value.(bool); ok && isErr {
+				converted.statusCode = statusCodeError
+			}
+		case jaegercli.SamplerTypeTagKey, jaegercli.SamplerParamTagKey:
+			// Jaeger internals, meaningless for OpenTelemetry.
+		default:
+			converted.attributes = append(converted.attributes, otlp.KeyValue{Key: key, Value: convertValue(value)})
+		}
+	}
+
+	for _, record := range s.Logs() {
+		converted.events = append(converted.events, convertLog(record))
+	}
+
+	return converted
+}
+
+func convertSpanKind(value interface{}) int {
+	switch fmt.Sprint(value) {
+	case string(ext.SpanKindRPCServerEnum):
+		return spanKindServer
+	case string(ext.SpanKindRPCClientEnum):
+		return spanKindClient
+	case string(ext.SpanKindProducerEnum):
+		return spanKindProducer
+	case string(ext.SpanKindConsumerEnum):
+		return spanKindConsumer
+	default:
+		return spanKindInternal
+	}
+}
+
+func convertLog(record opentracing.LogRecord) event {
+	e := event{time: record.Timestamp, name: "log"}
+
+	for _, field := range record.Fields {
+		if field.Key() == "event" {
+			e.name = fmt.Sprint(field.Value())
+			continue
+		}
+
+		e.attributes = append(e.attributes, otlp.KeyValue{Key: field.Key(), Value: convertValue(field.Value())})
+	}
+
+	return e
+}
+
+// convertValue converts the value to one of the attribute value types supported by the encoder.
+func convertValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string, bool, int64, float64:
+		return v
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case float32:
+		return float64(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
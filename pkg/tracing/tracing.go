@@ -79,6 +79,20 @@ func (t *Tracing) StartSpanf(r *http.Request, spanKind ext.SpanKindEnum, opPrefi
 	return StartSpan(r, operationName, spanKind, opts...)
 }
 
+// StartConnSpanf starts a span for a TCP connection or a UDP session, as a child of the given parent span, if any.
+func (t *Tracing) StartConnSpanf(parent opentracing.Span, spanKind ext.SpanKindEnum, opPrefix string, opParts []string, separator string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	operationName := generateOperationName(opPrefix, opParts, separator, t.SpanNameLimit)
+
+	if parent != nil {
+		opts = append(opts, opentracing.ChildOf(parent.Context()))
+	}
+
+	span := t.StartSpan(operationName, opts...)
+	setSpanKind(span, spanKind)
+
+	return span
+}
+
 // Inject delegates to opentracing.Tracer.
 func (t *Tracing) Inject(sm opentracing.SpanContext, format, carrier interface{}) error {
 	return t.tracer.Inject(sm, format, carrier)
@@ -151,7 +165,13 @@ func LogEventf(r *http.Request, format string, args ...interface{}) {
 // StartSpan starts a new span from the one in the request context.
 func StartSpan(r *http.Request, operationName string, spanKind ext.SpanKindEnum, opts ...opentracing.StartSpanOption) (opentracing.Span, *http.Request, func()) {
 	span, ctx := opentracing.StartSpanFromContext(r.Context(), operationName, opts...)
+	setSpanKind(span, spanKind)
 
+	r = r.WithContext(ctx)
+	return span, r, func() { span.Finish() }
+}
+
+func setSpanKind(span opentracing.Span, spanKind ext.SpanKindEnum) {
 	switch spanKind {
 	case ext.SpanKindRPCClientEnum:
 		ext.SpanKindRPCClient.Set(span)
@@ -164,9 +184,6 @@ func StartSpan(r *http.Request, operationName string, spanKind ext.SpanKindEnum,
 	default:
 		// noop
 	}
-
-	r = r.WithContext(ctx)
-	return span, r, func() { span.Finish() }
 }
 
 // SetError flags the span associated with this request as in error.
@@ -1,6 +1,10 @@
 package types
 
-import "github.com/traefik/paerser/types"
+import (
+	"time"
+
+	"github.com/traefik/paerser/types"
+)
 
 const (
 	// AccessLogKeep is the keep string value.
@@ -17,6 +21,15 @@ const (
 
 	// JSONFormat is the JSON logging format.
 	JSONFormat string = "json"
+
+	// LogfmtFormat is the logfmt logging format.
+	LogfmtFormat string = "logfmt"
+
+	// CEFFormat is the ArcSight Common Event Format (CEF) logging format.
+	CEFFormat string = "cef"
+
+	// TemplateFormat is the user-defined template logging format.
+	TemplateFormat string = "template"
 )
 
 // TraefikLog holds the configuration settings for the traefik logger.
@@ -24,6 +37,10 @@ type TraefikLog struct {
 	Level    string `description:"Log level set to traefik logs." json:"level,omitempty" toml:"level,omitempty" yaml:"level,omitempty" export:"true"`
 	FilePath string `description:"Traefik log file path. Stdout is used when omitted or empty." json:"filePath,omitempty" toml:"filePath,omitempty" yaml:"filePath,omitempty"`
 	Format   string `description:"Traefik log format: json | common" json:"format,omitempty" toml:"format,omitempty" yaml:"format,omitempty" export:"true"`
+
+	Levels    map[string]string `description:"Log levels of specific components, by name of provider, entry point, router, service or middleware (e.g. kubernetes=DEBUG)." json:"levels,omitempty" toml:"levels,omitempty" yaml:"levels,omitempty" export:"true"`
+	Fields    map[string]string `description:"Fields added to all the log entries." json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty" export:"true"`
+	RateLimit *LogRateLimit     `description:"Limits the number of identical warning and error log entries." json:"rateLimit,omitempty" toml:"rateLimit,omitempty" yaml:"rateLimit,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
 }
 
 // SetDefaults sets the default values.
@@ -32,13 +49,29 @@ func (l *TraefikLog) SetDefaults() {
 	l.Level = "ERROR"
 }
 
+// LogRateLimit holds the configuration of the rate limiting of the identical warning and error log entries.
+type LogRateLimit struct {
+	Burst    int            `description:"Number of identical log entries written per interval." json:"burst,omitempty" toml:"burst,omitempty" yaml:"burst,omitempty" export:"true"`
+	Interval types.Duration `description:"Interval over which the identical log entries are limited." json:"interval,omitempty" toml:"interval,omitempty" yaml:"interval,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (l *LogRateLimit) SetDefaults() {
+	l.Burst = 10
+	l.Interval = types.Duration(time.Minute)
+}
+
 // AccessLog holds the configuration settings for the access logger (middlewares/accesslog).
 type AccessLog struct {
-	FilePath      string            `description:"Access log file path. Stdout is used when omitted or empty." json:"filePath,omitempty" toml:"filePath,omitempty" yaml:"filePath,omitempty"`
-	Format        string            `description:"Access log format: json | common" json:"format,omitempty" toml:"format,omitempty" yaml:"format,omitempty" export:"true"`
+	FilePath      string            `description:"Access log file path. Stdout is used when omitted or empty, unless another output is configured." json:"filePath,omitempty" toml:"filePath,omitempty" yaml:"filePath,omitempty"`
+	Format        string            `description:"Access log format: json | common | logfmt | cef | template" json:"format,omitempty" toml:"format,omitempty" yaml:"format,omitempty" export:"true"`
+	Template      string            `description:"Access log template, used by the template format (e.g. '$ClientHost $RequestMethod $RequestPath $DownstreamStatus')." json:"template,omitempty" toml:"template,omitempty" yaml:"template,omitempty" export:"true"`
 	Filters       *AccessLogFilters `description:"Access log filters, used to keep only specific access logs." json:"filters,omitempty" toml:"filters,omitempty" yaml:"filters,omitempty" export:"true"`
 	Fields        *AccessLogFields  `description:"AccessLogFields." json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty" export:"true"`
 	BufferingSize int64             `description:"Number of access log lines to process in a buffered way." json:"bufferingSize,omitempty" toml:"bufferingSize,omitempty" yaml:"bufferingSize,omitempty" export:"true"`
+	SampleRate    float64           `description:"Rate of the access logs to keep, between 0 and 1 (e.g. 0.1 keeps 10% of them). All of them are kept when omitted or 0." json:"sampleRate,omitempty" toml:"sampleRate,omitempty" yaml:"sampleRate,omitempty" export:"true"`
+	Syslog        *AccessLogSyslog  `description:"Sends the access logs to a syslog server." json:"syslog,omitempty" toml:"syslog,omitempty" yaml:"syslog,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+	HTTP          *AccessLogHTTP    `description:"Sends the access logs to an HTTP endpoint, in batches." json:"http,omitempty" toml:"http,omitempty" yaml:"http,omitempty" export:"true"`
 }
 
 // SetDefaults sets the default values.
@@ -50,6 +83,69 @@ func (l *AccessLog) SetDefaults() {
 	l.Fields.SetDefaults()
 }
 
+// AccessLogSyslog holds the configuration of the syslog output of the access logs.
+type AccessLogSyslog struct {
+	Network    string `description:"Network of the syslog server: udp | tcp | unix | unixgram" json:"network,omitempty" toml:"network,omitempty" yaml:"network,omitempty" export:"true"`
+	Address    string `description:"Address of the syslog server (host:port, or socket path for the unix networks)." json:"address,omitempty" toml:"address,omitempty" yaml:"address,omitempty"`
+	Tag        string `description:"Tag of the syslog messages." json:"tag,omitempty" toml:"tag,omitempty" yaml:"tag,omitempty" export:"true"`
+	Facility   string `description:"Facility of the syslog messages (e.g. user, daemon, local0)." json:"facility,omitempty" toml:"facility,omitempty" yaml:"facility,omitempty" export:"true"`
+	BufferSize int    `description:"Number of access log lines kept in memory while waiting to be sent, the lines being dropped when it is full." json:"bufferSize,omitempty" toml:"bufferSize,omitempty" yaml:"bufferSize,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (s *AccessLogSyslog) SetDefaults() {
+	s.Network = "udp"
+	s.Address = "localhost:514"
+	s.Tag = "traefik"
+	s.Facility = "user"
+	s.BufferSize = 1000
+}
+
+// AccessLogHTTP holds the configuration of the HTTP output of the access logs.
+type AccessLogHTTP struct {
+	URL           string            `description:"URL of the HTTP endpoint the batches of access log lines are POSTed to." json:"url,omitempty" toml:"url,omitempty" yaml:"url,omitempty"`
+	Headers       map[string]string `description:"Headers sent with the batches." json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty"`
+	TLS           *ClientTLS        `description:"Enable TLS with a specific configuration." json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+	BatchSize     int               `description:"Maximum number of access log lines sent in a single batch." json:"batchSize,omitempty" toml:"batchSize,omitempty" yaml:"batchSize,omitempty" export:"true"`
+	FlushInterval types.Duration    `description:"Maximum time an access log line waits before its batch is sent." json:"flushInterval,omitempty" toml:"flushInterval,omitempty" yaml:"flushInterval,omitempty" export:"true"`
+	BufferSize    int               `description:"Number of access log lines kept in memory while waiting to be sent, the lines being dropped when it is full." json:"bufferSize,omitempty" toml:"bufferSize,omitempty" yaml:"bufferSize,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (h *AccessLogHTTP) SetDefaults() {
+	h.BatchSize = 100
+	h.FlushInterval = types.Duration(time.Second)
+	h.BufferSize = 1000
+}
+
+// +k8s:deepcopy-gen=true
+
+// AccessLogPolicy holds the access log configuration of a router (or of the routers of an entry point),
+// overriding the global one for the requests (or the connections) it handles.
+type AccessLogPolicy struct {
+	Disabled   bool              `description:"Disables the access logs." json:"disabled,omitempty" toml:"disabled,omitempty" yaml:"disabled,omitempty" export:"true"`
+	SampleRate float64           `description:"Rate of the access logs to keep, between 0 and 1 (e.g. 0.1 keeps 10% of them). The global rate is used when omitted or 0." json:"sampleRate,omitempty" toml:"sampleRate,omitempty" yaml:"sampleRate,omitempty" export:"true"`
+	Filters    *AccessLogFilters `description:"Access log filters, used to keep only specific access logs. The global filters are used when omitted." json:"filters,omitempty" toml:"filters,omitempty" yaml:"filters,omitempty" export:"true"`
+	Fields     *AccessLogFields  `description:"AccessLogFields. The global fields configuration is used when omitted." json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty" export:"true"`
+	Bodies     *AccessLogBodies  `description:"Captures the beginning of the request and response bodies in the access logs." json:"bodies,omitempty" toml:"bodies,omitempty" yaml:"bodies,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// AccessLogBodies holds the configuration of the capture of the request and response bodies.
+type AccessLogBodies struct {
+	MaxSize      int      `description:"Maximum number of bytes captured from each body." json:"maxSize,omitempty" toml:"maxSize,omitempty" yaml:"maxSize,omitempty" export:"true"`
+	ContentTypes []string `description:"Content types of the bodies to capture (e.g. application/json or text/*). All of them are captured when omitted." json:"contentTypes,omitempty" toml:"contentTypes,omitempty" yaml:"contentTypes,omitempty" export:"true"`
+	Redactions   []string `description:"Regular expressions matching the parts of the bodies to redact (only their capturing groups, if they have some)." json:"redactions,omitempty" toml:"redactions,omitempty" yaml:"redactions,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (b *AccessLogBodies) SetDefaults() {
+	b.MaxSize = 4096
+}
+
+// +k8s:deepcopy-gen=true
+
 // AccessLogFilters holds filters configuration.
 type AccessLogFilters struct {
 	StatusCodes   []string       `description:"Keep access logs with status codes in the specified range." json:"statusCodes,omitempty" toml:"statusCodes,omitempty" yaml:"statusCodes,omitempty" export:"true"`
@@ -57,12 +153,16 @@ type AccessLogFilters struct {
 	MinDuration   types.Duration `description:"Keep access logs when request took longer than the specified duration." json:"minDuration,omitempty" toml:"minDuration,omitempty" yaml:"minDuration,omitempty" export:"true"`
 }
 
+// +k8s:deepcopy-gen=true
+
 // FieldHeaders holds configuration for access log headers.
 type FieldHeaders struct {
 	DefaultMode string            `description:"Default mode for fields: keep | drop | redact" json:"defaultMode,omitempty" toml:"defaultMode,omitempty" yaml:"defaultMode,omitempty" export:"true"`
 	Names       map[string]string `description:"Override mode for headers" json:"names,omitempty" toml:"names,omitempty" yaml:"names,omitempty" export:"true"`
 }
 
+// +k8s:deepcopy-gen=true
+
 // AccessLogFields holds configuration for access log fields.
 type AccessLogFields struct {
 	DefaultMode string            `description:"Default mode for fields: keep | drop" json:"defaultMode,omitempty" toml:"defaultMode,omitempty" yaml:"defaultMode,omitempty"  export:"true"`
@@ -28,6 +28,112 @@ THE SOFTWARE.
 
 package types
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogBodies) DeepCopyInto(out *AccessLogBodies) {
+	*out = *in
+	if in.ContentTypes != nil {
+		in, out := &in.ContentTypes, &out.ContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Redactions != nil {
+		in, out := &in.Redactions, &out.Redactions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogBodies.
+func (in *AccessLogBodies) DeepCopy() *AccessLogBodies {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogBodies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogFields) DeepCopyInto(out *AccessLogFields) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = new(FieldHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogFields.
+func (in *AccessLogFields) DeepCopy() *AccessLogFields {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogFields)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogFilters) DeepCopyInto(out *AccessLogFilters) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogFilters.
+func (in *AccessLogFilters) DeepCopy() *AccessLogFilters {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogFilters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogPolicy) DeepCopyInto(out *AccessLogPolicy) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = new(AccessLogFilters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = new(AccessLogFields)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bodies != nil {
+		in, out := &in.Bodies, &out.Bodies
+		*out = new(AccessLogBodies)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogPolicy.
+func (in *AccessLogPolicy) DeepCopy() *AccessLogPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Domain) DeepCopyInto(out *Domain) {
 	*out = *in
@@ -48,3 +154,26 @@ func (in *Domain) DeepCopy() *Domain {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldHeaders) DeepCopyInto(out *FieldHeaders) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldHeaders.
+func (in *FieldHeaders) DeepCopy() *FieldHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -202,6 +202,49 @@ type Conn struct {
 	timeout  time.Duration // for timeouts
 	doneOnce sync.Once
 	doneCh   chan struct{}
+
+	muObservers sync.RWMutex
+	observers   []ConnObserver
+}
+
+// ConnObserver is notified of the traffic of a Conn.
+type ConnObserver interface {
+	// Received is called with the number of bytes read from the Conn.
+	Received(n int)
+	// Sent is called with the number of bytes written to the Conn.
+	Sent(n int)
+}
+
+// ProxyObserver is a ConnObserver also notified of the forwarding of the Conn to a backend by a Proxy.
+type ProxyObserver interface {
+	ConnObserver
+	// BackendConnected is called with the address of the backend once the connection to it is established.
+	BackendConnected(addr net.Addr)
+	// Done is called once the forwarding ends, with the error which ended it, if any.
+	Done(err error)
+}
+
+// AddObserver registers an observer notified of the traffic of the Conn.
+func (c *Conn) AddObserver(observer ConnObserver) {
+	c.muObservers.Lock()
+	defer c.muObservers.Unlock()
+
+	c.observers = append(c.observers, observer)
+}
+
+// proxyObservers returns the registered observers implementing ProxyObserver.
+func (c *Conn) proxyObservers() []ProxyObserver {
+	c.muObservers.RLock()
+	defer c.muObservers.RUnlock()
+
+	var observers []ProxyObserver
+	for _, observer := range c.observers {
+		if proxyObserver, ok := observer.(ProxyObserver); ok {
+			observers = append(observers, proxyObserver)
+		}
+	}
+
+	return observers
 }
 
 // readLoop waits for data to come from the listener's readLoop.
@@ -257,6 +300,13 @@ func (c *Conn) Read(p []byte) (int, error) {
 		c.muActivity.Lock()
 		c.lastActivity = time.Now()
 		c.muActivity.Unlock()
+
+		c.muObservers.RLock()
+		for _, observer := range c.observers {
+			observer.Received(n)
+		}
+		c.muObservers.RUnlock()
+
 		return n, nil
 	case <-c.doneCh:
 		return 0, io.EOF
@@ -273,7 +323,21 @@ func (c *Conn) Write(p []byte) (n int, err error) {
 	c.muActivity.Lock()
 	c.lastActivity = time.Now()
 	c.muActivity.Unlock()
-	return l.pConn.WriteTo(p, c.rAddr)
+
+	n, err = l.pConn.WriteTo(p, c.rAddr)
+
+	c.muObservers.RLock()
+	for _, observer := range c.observers {
+		observer.Sent(n)
+	}
+	c.muObservers.RUnlock()
+
+	return n, err
+}
+
+// RemoteAddr returns the address of the client of the session.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.rAddr
 }
 
 func (c *Conn) close() {
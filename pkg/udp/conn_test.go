@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -342,3 +343,58 @@ func requireEcho(t *testing.T, data string, conn io.ReadWriter, timeout time.Dur
 		t.Fatalf("Timeout during echo for: %s", data)
 	}
 }
+
+func TestConnObservers(t *testing.T) {
+	addr, err := net.ResolveUDPAddr("udp", ":0")
+	require.NoError(t, err)
+
+	ln, err := Listen("udp", addr, 3*time.Second)
+	require.NoError(t, err)
+	defer func() {
+		err := ln.Close()
+		require.NoError(t, err)
+	}()
+
+	observer := &countingObserver{}
+
+	go func() {
+		conn, err := ln.Accept()
+		if errors.Is(err, errClosedListener) {
+			return
+		}
+		require.NoError(t, err)
+
+		conn.AddObserver(observer)
+
+		b := make([]byte, 2048)
+		n, err := conn.Read(b)
+		require.NoError(t, err)
+
+		_, err = conn.Write(b[:n])
+		require.NoError(t, err)
+	}()
+
+	udpConn, err := net.Dial("udp", ln.Addr().String())
+	require.NoError(t, err)
+
+	requireEcho(t, "TESTDATA", udpConn, time.Second)
+
+	assert.Equal(t, int64(8), atomic.LoadInt64(&observer.received))
+	// The write is observed once the data is sent, hence possibly after the echo has been read.
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt64(&observer.sent) == 8
+	}, time.Second, 10*time.Millisecond)
+}
+
+type countingObserver struct {
+	received int64
+	sent     int64
+}
+
+func (o *countingObserver) Received(n int) {
+	atomic.AddInt64(&o.received, int64(n))
+}
+
+func (o *countingObserver) Sent(n int) {
+	atomic.AddInt64(&o.sent, int64(n))
+}
@@ -1,9 +1,11 @@
 package udp
 
 import (
+	"fmt"
 	"io"
 	"net"
 
+	"github.com/go-kit/kit/metrics"
 	"github.com/traefik/traefik/v2/pkg/log"
 )
 
@@ -11,6 +13,8 @@ import (
 type Proxy struct {
 	// TODO: maybe optimize by pre-resolving it at proxy creation time
 	target string
+
+	dialFailuresCounter metrics.Counter
 }
 
 // NewProxy creates a new Proxy.
@@ -18,6 +22,11 @@ func NewProxy(address string) (*Proxy, error) {
 	return &Proxy{target: address}, nil
 }
 
+// SetDialFailuresCounter sets the counter incremented each time the connection to the backend fails.
+func (p *Proxy) SetDialFailuresCounter(counter metrics.Counter) {
+	p.dialFailuresCounter = counter
+}
+
 // ServeUDP implements the Handler interface.
 func (p *Proxy) ServeUDP(conn *Conn) {
 	log.Debugf("Handling connection from %s", conn.rAddr)
@@ -25,15 +34,27 @@ func (p *Proxy) ServeUDP(conn *Conn) {
 	// needed because of e.g. server.trackedConnection
 	defer conn.Close()
 
+	observers := conn.proxyObservers()
+
 	connBackend, err := net.Dial("udp", p.target)
 	if err != nil {
 		log.Errorf("Error while connecting to backend: %v", err)
+		if p.dialFailuresCounter != nil {
+			p.dialFailuresCounter.Add(1)
+		}
+		for _, observer := range observers {
+			observer.Done(fmt.Errorf("error while connecting to backend: %w", err))
+		}
 		return
 	}
 
 	// maybe not needed, but just in case
 	defer connBackend.Close()
 
+	for _, observer := range observers {
+		observer.BackendConnected(connBackend.RemoteAddr())
+	}
+
 	errChan := make(chan error)
 	go p.connCopy(conn, connBackend, errChan)
 	go p.connCopy(connBackend, conn, errChan)
@@ -44,6 +65,10 @@ func (p *Proxy) ServeUDP(conn *Conn) {
 	}
 
 	<-errChan
+
+	for _, observer := range observers {
+		observer.Done(err)
+	}
 }
 
 func (p Proxy) connCopy(dst io.WriteCloser, src io.Reader, errCh chan error) {